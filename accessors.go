@@ -0,0 +1,91 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// initialisms holds the words spelled in upper case in accessor
+// names, following the naming conventions of Go.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true,
+	"CSV": true, "DNS": true, "EOF": true, "GUID": true, "HTML": true,
+	"HTTP": true, "HTTPS": true, "ID": true, "IP": true, "JS": true,
+	"JSON": true, "RPC": true, "SQL": true, "SSH": true, "SVG": true,
+	"TCP": true, "TLS": true, "TTL": true, "UDP": true, "UI": true,
+	"UID": true, "UUID": true, "URI": true, "URL": true, "UTF8": true,
+	"XML": true, "XSRF": true, "XSS": true, "YAML": true,
+}
+
+// accessorName converts the given asset name into an exported identifier
+// in mixed caps, like IndexHTML for "index.html". Names taken already get
+// a numeric suffix.
+func accessorName(name string, known map[string]bool) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var ident string
+	for _, word := range words {
+		if upper := strings.ToUpper(word); initialisms[upper] {
+			ident += upper
+			continue
+		}
+
+		r := []rune(word)
+		r[0] = unicode.ToUpper(r[0])
+		ident += string(r)
+	}
+
+	// Names starting with a digit, or a letter without an upper
+	// case form, are prefixed to get an exported identifier.
+	if !token.IsExported(ident) {
+		ident = "Asset" + ident
+	}
+
+	base := ident
+	for num := 2; known[ident]; num++ {
+		ident = fmt.Sprintf("%s%d", base, num)
+	}
+
+	known[ident] = true
+	return ident
+}
+
+// writeAccessors writes an exported function returning the contents of
+// each asset. Their names neither clash with the generated API, nor
+// with the constants of the asset names.
+func writeAccessors(w io.Writer, c *Config, toc []Asset) error {
+	known := reservedFuncs()
+	if c.typedNames() {
+		constants := reservedFuncs()
+		for i := range toc {
+			known[constantName(toc[i].Name, constants)] = true
+		}
+	}
+
+	for i := range toc {
+		name := toc[i].Name
+		ident := accessorName(name, known)
+		_, err := fmt.Fprintf(w, `// %s returns the contents of the asset %q.
+// It panics if the asset cannot be read, like MustAsset.
+func %s() []byte {
+	return MustAsset(%s)
+}
+
+`, ident, name, ident, c.nameArg(strconv.Quote(name)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
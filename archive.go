@@ -0,0 +1,235 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// isArchive reports whether the given input path names an archive,
+// whose entries are embedded as if it were a directory.
+func isArchive(name string) bool {
+	name = strings.ToLower(name)
+	for _, ext := range []string{".zip", ".tar", ".tar.gz", ".tgz"} {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// findArchiveFiles adds the files held by the archive of the given input
+// to the table of contents. The archive is unpacked into a temporary
+// directory, which is returned and must be removed by the caller. Asset
+// names are formed as if the archive were a directory of the same name.
+func findArchiveFiles(c *Config, input *InputConfig, toc *[]Asset, knownFuncs map[string]int) (string, error) {
+	dir, found, err := archiveFiles(c, input, input.Path)
+	if err != nil {
+		return dir, err
+	}
+
+	archive, _ := filepath.Abs(input.Path)
+	_, prefix := resolvePrefix(input.Path, c.prefix(input))
+	for _, asset := range found {
+		name := filepath.ToSlash(filepath.Join(input.Path, filepath.FromSlash(asset.Name)))
+		if len(prefix) > 0 {
+			abs, _ := filepath.Abs(filepath.FromSlash(name))
+			name = strings.TrimPrefix(filepath.ToSlash(abs), prefix)
+		}
+
+		asset.Name = strings.TrimPrefix(name, "/")
+		if len(asset.Name) == 0 {
+			return dir, fmt.Errorf("Invalid file: %v", asset.Path)
+		}
+
+		asset.Func = safeFunctionName(asset.Name, knownFuncs)
+		asset.source = filepath.Join(archive, asset.source)
+		*toc = append(*toc, asset)
+	}
+
+	return dir, nil
+}
+
+// archiveFiles unpacks the named archive into a temporary directory,
+// and returns it along with the files found there. Their names are
+// relative to the root of the archive, and so is their source.
+func archiveFiles(c *Config, input *InputConfig, name string) (string, []Asset, error) {
+	dir, err := extractArchive(name)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// An ignore file at the root of the archive applies to its entries.
+	filter := newFilter(c, input)
+	filter.rules, err = readIgnoreFile(dir)
+	if err != nil {
+		return dir, nil, err
+	}
+
+	var found []Asset
+	err = findFiles(dir, dir, input.Recursive, &found, filter, make(map[string]int))
+	for i := range found {
+		found[i].source = found[i].Name
+	}
+
+	return dir, found, err
+}
+
+// extractArchive unpacks the named zip or tar archive into a new
+// temporary directory. Modes and modification times are taken from
+// the archive headers. Entries other than regular files and
+// directories are skipped.
+func extractArchive(name string) (string, error) {
+	dir, err := ioutil.TempDir("", "bindata-archive")
+	if err != nil {
+		return "", err
+	}
+
+	if strings.HasSuffix(strings.ToLower(name), ".zip") {
+		err = extractZip(name, dir)
+	} else {
+		err = extractTar(name, dir)
+	}
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("Failed to read archive %s: %v", name, err)
+	}
+
+	return dir, nil
+}
+
+// extractZip unpacks a zip archive into dir.
+func extractZip(name, dir string) error {
+	r, err := zip.OpenReader(name)
+	if err != nil {
+		return err
+	}
+
+	defer r.Close()
+
+	for _, f := range r.File {
+		fi := f.FileInfo()
+		if !fi.Mode().IsRegular() && !fi.IsDir() {
+			continue
+		}
+
+		var rc io.ReadCloser
+		if !fi.IsDir() {
+			rc, err = f.Open()
+			if err != nil {
+				return err
+			}
+		}
+
+		err = extractEntry(dir, f.Name, fi, rc)
+		if rc != nil {
+			rc.Close()
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractTar unpacks a tar archive into dir,
+// which may be gzip compressed.
+func extractTar(name, dir string) error {
+	fd, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	var r io.Reader = fd
+	lower := strings.ToLower(name)
+	if strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(fd)
+		if err != nil {
+			return err
+		}
+
+		defer gz.Close()
+		r = gz
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		fi := hdr.FileInfo()
+		if !fi.Mode().IsRegular() && !fi.IsDir() {
+			continue
+		}
+
+		err = extractEntry(dir, hdr.Name, fi, tr)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// extractEntry writes a single archive entry below dir. Entries
+// with names leaving the directory are rejected.
+func extractEntry(dir, name string, fi os.FileInfo, r io.Reader) error {
+	clean := path.Clean("/" + name)
+	if clean == "/" {
+		return nil
+	}
+	if clean != "/"+strings.TrimSuffix(strings.TrimPrefix(name, "./"), "/") {
+		return fmt.Errorf("Invalid archive entry %q", name)
+	}
+
+	target := filepath.Join(dir, filepath.FromSlash(clean[1:]))
+	if fi.IsDir() {
+		return os.MkdirAll(target, 0755)
+	}
+
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+
+	fd, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fd, r)
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	err = fd.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(target, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	return os.Chtimes(target, time.Now(), fi.ModTime())
+}
@@ -0,0 +1,246 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// asmPath returns the name of the assembly file holding the asset
+// data, which is the output file with a .s extension.
+func (c *Config) asmPath() string {
+	return strings.TrimSuffix(c.Output, ".go") + ".s"
+}
+
+// validateAssembly ensures the assembly output is only
+// used along with options supporting it.
+func validateAssembly(c *Config) error {
+	if !c.Assembly {
+		return nil
+	}
+
+	if !strings.HasSuffix(c.Output, ".go") {
+		return fmt.Errorf("Assembly output requires an output file ending in .go")
+	}
+
+	switch {
+	case c.SplitOutput:
+		return fmt.Errorf("Assembly output cannot be combined with split output")
+	case len(c.IncrementalCache) > 0:
+		return fmt.Errorf("Assembly output cannot be combined with an incremental cache")
+	case len(c.platforms()) > 0:
+		return fmt.Errorf("Assembly output cannot be combined with platform specific inputs")
+	case c.Debug:
+		return nil
+	case c.SingleBlob:
+		return fmt.Errorf("Assembly output cannot be combined with the single blob layout")
+	case c.stringData() || c.extNoMemCopy():
+		return fmt.Errorf("Assembly output cannot be combined with NoMemCopy or NoUnsafe")
+	case c.MaxLiteralSize > 0:
+		return fmt.Errorf("Assembly output cannot be combined with a maximum literal size")
+	}
+
+	return nil
+}
+
+// writeAssembly writes the assembly file. Its contents, following
+// the header, are written by fn. Debug builds write the header only,
+// so the data of an earlier release build is dropped.
+func writeAssembly(c *Config, fn func(w io.Writer) error) error {
+	return writeFile(c, c.asmPath(), func(w io.Writer) error {
+		err := writeFileHeader(w, c)
+		if err != nil {
+			return err
+		}
+
+		if len(c.Tags) > 0 {
+			err = writeBuildConstraint(w, c.Tags, !c.goAtLeast(17))
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(w, "#include \"textflag.h\"\n\n")
+		if err != nil || fn == nil {
+			return err
+		}
+
+		return fn(w)
+	})
+}
+
+// writeAsmAssets writes the data of all assets into the assembly file,
+// and the Go declarations and functions reading it to w. The assembly
+// file defines a byte array per asset, like _index_html_data, which
+// the Go code refers to through a slice of the usual name. This is the
+// release output for the Assembly option.
+func writeAsmAssets(w io.Writer, c *Config, toc []Asset) error {
+	code := make([]bytes.Buffer, len(toc))
+
+	err := writeAssembly(c, func(s io.Writer) error {
+		return encodeAssets(s, len(toc), c.jobs(), func(s io.Writer, i int) error {
+			asset := &toc[i]
+			if asset.original != nil {
+				return writeDuplicateAsset(&code[i], c, asset)
+			}
+
+			return writeAsmAsset(&code[i], s, c, asset)
+		}, c.assetEvents(toc))
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range code {
+		_, err = code[i].WriteTo(w)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeAsmAsset writes the data of the asset to s, and its
+// declaration and functions to w.
+func writeAsmAsset(w, s io.Writer, c *Config, asset *Asset) error {
+	fd, err := openAsset(c, asset)
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	h := sha256.New()
+	var size countWriter
+	r := io.TeeReader(fd, io.MultiWriter(h, &size))
+
+	aw := &asmWriter{w: s, sym: "_" + asset.Func + "_data"}
+	if asset.Compressed {
+		err = writeEncoded(aw, c, asset, r)
+	} else {
+		err = writeRaw(aw, c, asset, r)
+	}
+	if err == nil {
+		err = aw.Close()
+	}
+	if err != nil {
+		return err
+	}
+
+	copy(asset.Digest[:], h.Sum(nil))
+	asset.Size = size.n
+
+	if aw.off == 0 {
+		_, err = fmt.Fprintf(w, "var _%s = []byte{}\n\n", asset.Func)
+	} else {
+		_, err = fmt.Fprintf(w, `// %s is defined in the assembly file.
+var %s [%d]byte
+
+var _%s = %s[:]
+
+`, aw.sym, aw.sym, aw.off, asset.Func, aw.sym)
+	}
+	if err != nil {
+		return err
+	}
+
+	if asset.Compressed {
+		err = writeCompressedBytes(w, c, asset)
+	} else {
+		read := fmt.Sprintf("_%s, nil", asset.Func)
+		if c.encrypt() {
+			read = fmt.Sprintf("bindata_decrypt(_%s, %q)", asset.Func, asset.Name)
+		}
+
+		_, err = fmt.Fprintf(w, `func %s_bytes() ([]byte, error) {
+	return %s
+}
+
+`, asset.Func, read)
+	}
+	if err != nil {
+		return err
+	}
+
+	return asset_release_common(w, c, asset)
+}
+
+// asmWriter writes data as the DATA directives of the named symbol,
+// eight bytes per line, and declares the symbol on Close. Nothing
+// is declared without data, as the assembler rejects empty symbols.
+type asmWriter struct {
+	w   io.Writer
+	sym string
+	buf []byte
+	off int64
+}
+
+func (w *asmWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		m := 8 - len(w.buf)
+		if m > len(p) {
+			m = len(p)
+		}
+
+		w.buf = append(w.buf, p[:m]...)
+		p = p[m:]
+
+		if len(w.buf) == 8 {
+			err := w.flush()
+			if err != nil {
+				return n - len(p), err
+			}
+		}
+	}
+	return n, nil
+}
+
+// flush writes the buffered bytes as a DATA directive.
+func (w *asmWriter) flush() error {
+	var line []byte
+	line = append(line, "DATA ·"...)
+	line = append(line, w.sym...)
+	line = append(line, fmt.Sprintf("+%d(SB)/%d, $\"", w.off, len(w.buf))...)
+	for _, b := range w.buf {
+		switch {
+		case b == '"' || b == '\\':
+			line = append(line, '\\', b)
+		case b >= ' ' && b <= '~':
+			line = append(line, b)
+		default:
+			line = append(line, '\\', 'x', lowerHex[b/16], lowerHex[b%16])
+		}
+	}
+	line = append(line, "\"\n"...)
+
+	w.off += int64(len(w.buf))
+	w.buf = w.buf[:0]
+	_, err := w.w.Write(line)
+	return err
+}
+
+// Close writes the remaining bytes and declares the symbol.
+// Its data is read only.
+func (w *asmWriter) Close() error {
+	if len(w.buf) > 0 {
+		err := w.flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	if w.off == 0 {
+		return nil
+	}
+
+	_, err := fmt.Fprintf(w.w, "GLOBL ·%s(SB), RODATA|NOPTR, $%d\n\n", w.sym, w.off)
+	return err
+}
@@ -4,9 +4,83 @@
 
 package bindata
 
+import (
+	"crypto/sha256"
+	"io/fs"
+)
+
 // Asset holds information about a single asset to be processed.
 type Asset struct {
 	Path string // Full file path.
 	Name string // Key used in TOC -- name by which asset is referenced.
 	Func string // Function name for the procedure returning the asset contents.
+
+	// Digest holds the SHA-256 sum of the asset contents.
+	// It is computed while writing release output.
+	Digest [sha256.Size]byte
+
+	// Size is the size of the asset contents, and EmbeddedSize the
+	// size of the data embedded for them, which is smaller for
+	// compressed assets. Both are set while writing release output,
+	// and by Plan.
+	Size         int64
+	EmbeddedSize int64
+
+	// Compressed reports whether the asset is embedded compressed.
+	// Assets which do not benefit from compression are embedded as they
+	// are, even if compression is enabled.
+	Compressed bool
+
+	// label is the original name of an asset, whose name was obfuscated.
+	label string
+
+	// sum is the SHA-256 sum of the source file of an asset whose
+	// contents were transformed, and nil for all other assets.
+	sum *[sha256.Size]byte
+
+	// parts is the number of variables holding the embedded data,
+	// which is split if it exceeds Config.MaxLiteralSize.
+	parts int
+
+	// original is the earlier asset with the same contents, whose
+	// data is shared by this one. It is nil for unique assets.
+	original *Asset
+
+	// source is the location an asset was unpacked or downloaded
+	// from, if Path names a temporary copy.
+	source string
+
+	// platforms are the target platforms of the input holding the
+	// asset. It is built for all platforms if there are none.
+	platforms []string
+
+	// collisions is the number of other assets with the same name,
+	// which were dropped or caused this one to be renamed.
+	collisions int
+
+	// fsys is the file system holding the asset at Path.
+	// It is nil for files on disk.
+	fsys fs.FS
+
+	// kept marks an asset of an earlier run, whose file is
+	// left as it is when merging split output.
+	kept bool
+}
+
+// origin returns the location the asset was read from.
+func (a *Asset) origin() string {
+	if len(a.source) > 0 {
+		return a.source
+	}
+	return a.Path
+}
+
+// DuplicateOf returns the name of the earlier asset with the same
+// contents, whose data is shared by this one. It is empty for unique
+// assets, and for those of debug builds.
+func (a *Asset) DuplicateOf() string {
+	if a.original == nil {
+		return ""
+	}
+	return a.original.Name
 }
@@ -0,0 +1,313 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+// writeBlob writes all assets into a single string, followed by
+// an index holding the location of each asset within it. With a
+// DataFile, the assets are written to that file instead.
+// This is the release output for the SingleBlob layout.
+func writeBlob(w io.Writer, c *Config, toc []Asset) error {
+	err := header_blob(w, c)
+	if err != nil {
+		return err
+	}
+
+	lengths := make([]int64, len(toc))
+	infos := make([]string, len(toc))
+
+	// The data goes to the data file, if there is one.
+	if len(c.DataFile) > 0 {
+		df, err := writeDataFile(c, func(w io.Writer) error {
+			return encodeBlob(w, c, toc, lengths, infos, false)
+		})
+		if err != nil {
+			return err
+		}
+
+		err = writeDataFileInfo(w, c, df)
+		if err != nil {
+			return err
+		}
+	} else {
+		_, err = fmt.Fprintf(w, `var _bindata_blob = "`)
+		if err != nil {
+			return err
+		}
+
+		err = encodeBlob(w, c, toc, lengths, infos, true)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "\"\n\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "var _bindata_entries = [...]bindata_entry{\n")
+	if err != nil {
+		return err
+	}
+
+	// Duplicates point at the data of their original.
+	var offset int64
+	offsets := make(map[*Asset]int64)
+	for i := range toc {
+		asset := &toc[i]
+		start, length, compressed := offset, lengths[i], asset.Compressed
+		if original := asset.original; original != nil {
+			start, length, compressed = offsets[original], original.EmbeddedSize, original.Compressed
+		} else {
+			offsets[asset] = offset
+			offset += length
+		}
+
+		doc, err := provenance(c, asset, "\t")
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "%s\t{name: %q, offset: %d, length: %d, compressed: %v, info: %s",
+			doc, asset.Name, start, length, compressed, infos[i])
+		if err != nil {
+			return err
+		}
+
+		if c.authenticate() {
+			mac, err := assetMAC(c, asset)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(w, ", mac: %s", mac)
+			if err != nil {
+				return err
+			}
+		}
+
+		if c.Checksums {
+			sum, err := assetChecksum(asset)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Fprintf(w, ", sum: 0x%08x", sum)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(w, "},\n")
+		if err != nil {
+			return err
+		}
+
+		// There are no functions per asset. The table of contents
+		// refers to the entries instead.
+		asset.Func = fmt.Sprintf("_bindata_entries[%d].load", i)
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
+
+// encodeBlob writes the data of all assets to w, one after another, and
+// records the length of the data and the file info of each of them. If
+// literal is set, the data is written as the contents of a string literal.
+func encodeBlob(w io.Writer, c *Config, toc []Asset, lengths []int64, infos []string, literal bool) error {
+	return encodeAssets(w, len(toc), c.jobs(), func(w io.Writer, i int) error {
+		asset := &toc[i]
+		if asset.original != nil {
+			info, err := fileInfo(c, asset)
+			infos[i] = info
+			return err
+		}
+
+		fd, err := openAsset(c, asset)
+		if err != nil {
+			return err
+		}
+
+		defer fd.Close()
+
+		var counter, size countWriter
+		h := sha256.New()
+		r := io.TeeReader(fd, io.MultiWriter(h, &size))
+		out := io.MultiWriter(w, &counter)
+		if literal {
+			out = io.MultiWriter(newStringWriter(w, c), &counter)
+		}
+
+		// Start every asset on a new line, if their length is limited.
+		if literal && i > 0 && c.lineLength() > 0 {
+			_, err := w.Write(continued)
+			if err != nil {
+				return err
+			}
+		}
+
+		if asset.Compressed {
+			err = writeEncoded(out, c, asset, r)
+		} else {
+			err = writeRaw(out, c, asset, r)
+		}
+		if err != nil {
+			return err
+		}
+
+		copy(asset.Digest[:], h.Sum(nil))
+		asset.Size = size.n
+		asset.EmbeddedSize = counter.n
+		lengths[i] = counter.n
+		infos[i], err = fileInfo(c, asset)
+		return err
+	}, c.assetEvents(toc))
+}
+
+// header_blob writes the bindata_entry type, which
+// locates an asset within the blob.
+func header_blob(w io.Writer, c *Config) error {
+	cached := c.CacheDecompressed && c.compression() != CompressNone
+
+	cache, load := "", "e.read()"
+	if cached {
+		cache, load = "\tcache      bindata_cache\n", "e.cache.get(e.read)"
+	}
+
+	mac, verify := "", ""
+	if c.authenticate() {
+		mac = "\tmac        string\n"
+		verify = "\terr = bindata_verify(e.name, bytes, e.mac)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\n"
+	}
+	if c.Checksums {
+		mac += "\tsum        uint32\n"
+		verify = "\terr = bindata_checksum(e.name, bytes, e.sum)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\n" + verify
+	}
+
+	// The data is kept by the package if it is cached, or if
+	// uncompressed data refers to the blob without copying.
+	fields := ""
+	if cached {
+		fields = ", shared: true"
+	} else if c.NoMemCopy && !c.NoUnsafe && len(c.DataFile) == 0 && !c.encrypt() {
+		fields = ", shared: !e.compressed"
+	}
+
+	// Uncompressed data is sliced from the blob constant.
+	text := len(c.DataFile) == 0 && !c.encrypt()
+	if text {
+		fields += ", text: e.text()"
+	}
+
+	blob := "_bindata_blob"
+	if len(c.DataFile) > 0 {
+		blob = "the data file"
+	}
+
+	_, err := fmt.Fprintf(w, `// bindata_entry locates the data of an asset in %s.
+type bindata_entry struct {
+	name       string
+	offset     int
+	length     int
+	compressed bool
+	info       bindata_file_info
+%s%s}
+
+// load returns the asset described by the entry.
+func (e *bindata_entry) load() (*asset, error) {
+	bytes, err := %s
+	if err != nil {
+		return nil, err
+	}
+
+%s	return &asset{bytes: bytes, info: e.info%s}, nil
+}
+
+// raw returns the data of the entry as it is embedded.
+func (e *bindata_entry) raw() ([]byte, error) {
+`, blob, mac, cache, load, verify, fields)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case len(c.DataFile) > 0:
+		_, err = fmt.Fprintf(w, `	r, err := bindata_data()
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, e.length)
+	_, err = r.ReadAt(data, int64(e.offset))
+	if err != nil {
+		return nil, fmt.Errorf("Read %%q: %%w", e.name, err)
+	}
+	return data, nil
+}
+
+`)
+	case c.NoMemCopy && !c.NoUnsafe:
+		_, err = fmt.Fprintf(w, "\treturn bindata_read_raw(_bindata_blob[e.offset:e.offset+e.length], e.name)\n}\n\n")
+	default:
+		_, err = fmt.Fprintf(w, "\treturn []byte(_bindata_blob[e.offset : e.offset+e.length]), nil\n}\n\n")
+	}
+	if err != nil {
+		return err
+	}
+
+	if text {
+		_, err = fmt.Fprintf(w, `// text returns the data of the entry as a string, if it is not compressed.
+func (e *bindata_entry) text() string {
+	if e.compressed {
+		return ""
+	}
+	return _bindata_blob[e.offset : e.offset+e.length]
+}
+
+`)
+		if err != nil {
+			return err
+		}
+	}
+
+	decrypt := ""
+	if c.encrypt() {
+		decrypt = `	if err == nil {
+		data, err = bindata_decrypt(data, e.name)
+	}
+`
+	}
+
+	if c.compression() == CompressNone {
+		_, err = fmt.Fprintf(w, `// read returns the data of the entry.
+func (e *bindata_entry) read() ([]byte, error) {
+	data, err := e.raw()
+%s	return data, err
+}
+
+`, decrypt)
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `// read returns the data of the entry, decompressing it if needed.
+func (e *bindata_entry) read() ([]byte, error) {
+	data, err := e.raw()
+%s	if err != nil || !e.compressed {
+		return data, err
+	}
+
+	return bindata_decompress(data, e.name)
+}
+
+`, decrypt)
+	return err
+}
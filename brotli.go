@@ -0,0 +1,30 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+//go:build brotli
+// +build brotli
+
+package bindata
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+
+	"github.com/andybalholm/brotli"
+)
+
+func init() {
+	encoders[CompressBrotli] = func(w io.Writer, c *Config) (io.WriteCloser, error) {
+		level := c.CompressionLevel
+		if level == 0 {
+			level = brotli.DefaultCompression
+		}
+		return brotli.NewWriterLevel(w, level), nil
+	}
+
+	decoders[CompressBrotli] = func(data []byte) ([]byte, error) {
+		return ioutil.ReadAll(brotli.NewReader(bytes.NewReader(data)))
+	}
+}
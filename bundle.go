@@ -0,0 +1,154 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// walkCache holds the sorted directory listings read while locating
+// assets, so bundles reading the same inputs list them only once.
+type walkCache map[string][]os.FileInfo
+
+// readDir returns the sorted listing of the given directory.
+// It is read from disk only once, if the cache is set.
+func (f *fileFilter) readDir(dir string) ([]os.FileInfo, error) {
+	if list, ok := f.walked[dir]; ok {
+		return list, nil
+	}
+
+	fd, err := os.Open(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	list, err := fd.Readdir(0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort to make output stable between invocations
+	sort.Sort(ByName(list))
+
+	if f.walked != nil {
+		f.walked[dir] = list
+	}
+	return list, nil
+}
+
+// bundleNames returns the names of the bundles, sorted.
+func (c *Config) bundleNames() []string {
+	names := make([]string, 0, len(c.Bundles))
+	for name := range c.Bundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// configs returns the configuration itself, if it has inputs, followed
+// by those of its bundles. These are generated by TranslateStats.
+func (c *Config) configs() []*Config {
+	var list []*Config
+	if len(c.Input) > 0 || len(c.Bundles) == 0 {
+		list = append(list, c)
+	}
+	for _, name := range c.bundleNames() {
+		list = append(list, c.Bundles[name])
+	}
+	return list
+}
+
+// validateBundles ensures the bundles can be generated in one run,
+// without overwriting each other.
+func validateBundles(c *Config) error {
+	outputs := make(map[string]string)
+	if len(c.Input) > 0 {
+		output, _ := filepath.Abs(c.Output)
+		outputs[output] = "the configuration"
+	}
+
+	for _, name := range c.bundleNames() {
+		b := c.Bundles[name]
+		if b == nil {
+			return fmt.Errorf("Bundle %s has no configuration", name)
+		}
+
+		if len(b.Bundles) > 0 {
+			return fmt.Errorf("Bundle %s cannot have bundles of its own", name)
+		}
+
+		if len(b.Output) == 0 {
+			return fmt.Errorf("Bundle %s has no output path", name)
+		}
+
+		output, _ := filepath.Abs(b.Output)
+		if other, ok := outputs[output]; ok {
+			return fmt.Errorf("Bundle %s has the same output as %s", name, other)
+		}
+		outputs[output] = "bundle " + name
+	}
+
+	return nil
+}
+
+// translateBundles generates the configuration itself, if it has inputs,
+// followed by each of its bundles in the order of their names. Their
+// statistics are returned along with those of the configuration.
+func translateBundles(c *Config) (*Stats, error) {
+	start := time.Now()
+
+	err := validateBundles(c)
+	if err != nil {
+		return nil, err
+	}
+
+	walked := make(walkCache)
+
+	// Files which cannot be read are reported once all
+	// bundles were generated, with ContinueOnError.
+	var failed AssetErrors
+
+	stats := &Stats{}
+	if len(c.Input) > 0 {
+		top := *c
+		top.Bundles = nil
+		top.walked = walked
+		stats, err = TranslateStats(&top)
+		if errs, ok := err.(AssetErrors); ok {
+			failed = append(failed, errs...)
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	stats.Bundles = make(map[string]*Stats, len(c.Bundles))
+	for _, name := range c.bundleNames() {
+		b := *c.Bundles[name]
+		b.walked = walked
+		if b.Events == nil {
+			b.Events = c.Events
+		}
+		s, err := TranslateStats(&b)
+		if errs, ok := err.(AssetErrors); ok {
+			failed = append(failed, errs...)
+		} else if err != nil {
+			return nil, fmt.Errorf("Bundle %s: %v", name, err)
+		}
+		stats.Bundles[name] = s
+	}
+
+	stats.Duration = time.Since(start)
+	if len(failed) > 0 {
+		return stats, failed
+	}
+	return stats, nil
+}
@@ -0,0 +1,213 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// header_cache writes the bindata_cache type, which holds the
+// decompressed data of an asset, and the list of cached assets,
+// which keeps the total size of the cached data within its limit.
+func header_cache(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// bindata_cache holds the decompressed data of an asset. The data
+// is decompressed once, on first use, until the cache is flushed, or
+// the data is evicted to keep the cache within its size limit.
+type bindata_cache struct {
+	entry *bindata_cache_entry
+}
+
+type bindata_cache_entry struct {
+	once  sync.Once
+	bytes []byte
+	err   error
+	cache *bindata_cache
+	elem  *list.Element
+}
+
+// _bindata_cache_lru lists the cached entries, the most recently used
+// first, along with the total size of their data. Once the size
+// exceeds the limit, the least recently used entries are evicted.
+// Zero does not limit the size.
+var _bindata_cache_lru struct {
+	mu      sync.Mutex
+	entries list.List
+	size    int64
+	limit   int64
+}
+
+func init() {
+	_bindata_cache_lru.limit = %d
+}
+
+// get returns the cached data, calling read if there is none. An error
+// of read is cached as well, until the entry is flushed or evicted.
+func (c *bindata_cache) get(read func() ([]byte, error)) ([]byte, error) {
+	lru := &_bindata_cache_lru
+	lru.mu.Lock()
+	e := c.entry
+	if e == nil {
+		e = &bindata_cache_entry{cache: c}
+		c.entry = e
+	} else if e.elem != nil {
+		lru.entries.MoveToFront(e.elem)
+	}
+	lru.mu.Unlock()
+
+	e.once.Do(func() {
+		e.bytes, e.err = read()
+
+		lru.mu.Lock()
+		if c.entry == e {
+			e.elem = lru.entries.PushFront(e)
+			lru.size += int64(len(e.bytes))
+			bindata_cache_evict()
+		}
+		lru.mu.Unlock()
+	})
+	return e.bytes, e.err
+}
+
+// flush drops the cached data.
+func (c *bindata_cache) flush() {
+	lru := &_bindata_cache_lru
+	lru.mu.Lock()
+	if e := c.entry; e != nil && e.elem != nil {
+		bindata_cache_remove(e)
+	}
+	c.entry = nil
+	lru.mu.Unlock()
+}
+
+// bindata_cache_evict drops the least recently used entries, until
+// the cached data fits the limit. The caller holds the lock.
+func bindata_cache_evict() {
+	lru := &_bindata_cache_lru
+	for lru.limit > 0 && lru.size > lru.limit {
+		e := lru.entries.Back().Value.(*bindata_cache_entry)
+		bindata_cache_remove(e)
+		e.cache.entry = nil
+	}
+}
+
+// bindata_cache_remove removes the entry from the list.
+// The caller holds the lock.
+func bindata_cache_remove(e *bindata_cache_entry) {
+	lru := &_bindata_cache_lru
+	lru.entries.Remove(e.elem)
+	lru.size -= int64(len(e.bytes))
+	e.elem = nil
+}
+
+`, c.CacheSize)
+	return err
+}
+
+// writeCompressedBytes writes the function returning the decompressed
+// data of the given asset. With CacheDecompressed, the result is kept
+// in a cache for the asset.
+func writeCompressedBytes(w io.Writer, c *Config, asset *Asset) error {
+	read := "bindata_read"
+	if c.assetStringData(asset) && !c.stringData() {
+		read = "bindata_read_string"
+	}
+
+	if !c.CacheDecompressed {
+		_, err := fmt.Fprintf(w, `func %s_bytes() ([]byte, error) {
+	return %s(
+		%s,
+		%q,
+	)
+}
+
+`, asset.Func, read, dataExpr(c, asset), asset.Name)
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, `var _%s_cache bindata_cache
+
+func %s_bytes() ([]byte, error) {
+	return _%s_cache.get(func() ([]byte, error) {
+		return %s(
+			%s,
+			%q,
+		)
+	})
+}
+
+`, asset.Func, asset.Func, asset.Func, read, dataExpr(c, asset), asset.Name)
+	return err
+}
+
+// writeCacheSize writes the SetAssetCacheSize function. In debug
+// builds, or if no asset is compressed, there is nothing to limit.
+func writeCacheSize(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// SetAssetCacheSize limits the total size of the decompressed data kept
+// for the assets, in bytes. Once it is exceeded, the data of the assets
+// used least recently is dropped, and decompressed again on next use.
+// Zero does not limit the size.
+func SetAssetCacheSize(bytes int64) {
+`)
+	if err != nil {
+		return err
+	}
+
+	if c.Debug || c.compression() == CompressNone {
+		_, err = fmt.Fprintf(w, "}\n\n")
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `	lru := &_bindata_cache_lru
+	lru.mu.Lock()
+	lru.limit = bytes
+	bindata_cache_evict()
+	lru.mu.Unlock()
+}
+
+`)
+	return err
+}
+
+// writeFlushCache writes the FlushAssetCache function. In debug
+// builds, or if no asset is compressed, there is nothing to flush.
+func writeFlushCache(w io.Writer, c *Config, toc []Asset) error {
+	_, err := fmt.Fprintf(w, `// FlushAssetCache drops the decompressed data of all assets, so the
+// memory can be reclaimed. Assets are decompressed again on next use.
+func FlushAssetCache() {
+`)
+	if err != nil {
+		return err
+	}
+
+	if c.Debug || c.compression() == CompressNone {
+		_, err = fmt.Fprintf(w, "}\n\n")
+		return err
+	}
+
+	if c.SingleBlob {
+		_, err = fmt.Fprintf(w, "\tfor i := range _bindata_entries {\n\t\t_bindata_entries[i].cache.flush()\n\t}\n}\n\n")
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "\tfor _, c := range []*bindata_cache{\n")
+	if err != nil {
+		return err
+	}
+
+	for i := range toc {
+		if !toc[i].Compressed || toc[i].original != nil {
+			continue
+		}
+
+		_, err = fmt.Fprintf(w, "\t\t&_%s_cache,\n", toc[i].Func)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\t} {\n\t\tc.flush()\n\t}\n}\n\n")
+	return err
+}
@@ -0,0 +1,114 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// certExtensions holds the extensions of files,
+// which are looked at for PEM encoded certificates.
+var certExtensions = map[string]bool{".pem": true, ".crt": true, ".cer": true}
+
+// certBundle reports whether the asset holds PEM encoded certificates.
+// Files with other PEM blocks only, like private keys, do not count.
+// Certificates which cannot be parsed fail the generation.
+func certBundle(asset *Asset) (bool, error) {
+	if !certExtensions[strings.ToLower(filepath.Ext(asset.Path))] {
+		return false, nil
+	}
+
+	fd, err := asset.open()
+	if err != nil {
+		return false, err
+	}
+
+	defer fd.Close()
+
+	data, err := ioutil.ReadAll(fd)
+	if err != nil {
+		return false, fmt.Errorf("Read %s: %v", asset.origin(), err)
+	}
+
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return found, nil
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		_, err = x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return false, fmt.Errorf("Invalid certificate in %s: %v", asset.origin(), err)
+		}
+		found = true
+	}
+}
+
+// writeRootCAs writes the RootCAs function, which assembles a
+// certificate pool from the certificate bundles among the assets.
+func writeRootCAs(w io.Writer, c *Config, toc []Asset) error {
+	var names []string
+	for i := range toc {
+		ok, err := certBundle(&toc[i])
+		if err != nil {
+			return err
+		}
+		if ok {
+			names = append(names, toc[i].Name)
+		}
+	}
+
+	if len(names) == 0 {
+		return fmt.Errorf("No PEM encoded certificates found for RootCAs")
+	}
+
+	_, err := fmt.Fprintf(w, `// RootCAs returns a certificate pool holding the certificates of the
+// PEM encoded bundles among the assets, which were found during
+// generation. It suits the RootCAs of a tls.Config, for programs running
+// without the certificates of an operating system.
+func RootCAs() (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	for _, name := range _bindata_certs {
+		data, err := AssetUnsafe(%s)
+		if err != nil {
+			return nil, err
+		}
+
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("No certificates found in %%s", name)
+		}
+	}
+	return pool, nil
+}
+
+// _bindata_certs holds the names of the certificate bundles.
+var _bindata_certs = []string{
+`, c.nameArg("name"))
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		_, err = fmt.Fprintf(w, "\t%q,\n", name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
@@ -0,0 +1,66 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// assetChecksum returns the CRC-32 checksum of the contents of the asset.
+// The contents are read once more, as duplicates may be written before
+// their original was read.
+func assetChecksum(asset *Asset) (uint32, error) {
+	fd, err := asset.open()
+	if err != nil {
+		return 0, err
+	}
+
+	defer fd.Close()
+
+	h := crc32.NewIEEE()
+	_, err = io.Copy(h, fd)
+	if err != nil {
+		return 0, fmt.Errorf("Read %s: %v", asset.origin(), err)
+	}
+	return h.Sum32(), nil
+}
+
+// checksumStep returns the generated statements, which check the
+// contents of the asset in variable bytes against its checksum.
+func checksumStep(c *Config, asset *Asset) (string, error) {
+	if !c.Checksums {
+		return "", nil
+	}
+
+	sum, err := assetChecksum(asset)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`	err = bindata_checksum(%q, bytes, 0x%08x)
+	if err != nil {
+		return nil, err
+	}
+
+`, asset.Name, sum), nil
+}
+
+// header_checksum writes the function checking
+// asset contents against their checksum.
+func header_checksum(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `// bindata_checksum checks the contents of the named asset, as they are
+// returned after decompression, against their CRC-32 checksum.
+func bindata_checksum(name string, data []byte, sum uint32) error {
+	if got := crc32.ChecksumIEEE(data); got != sum {
+		return fmt.Errorf("Asset %%s is corrupt: %%d bytes with checksum %%08x, want %%08x", name, len(data), got, sum)
+	}
+	return nil
+}
+
+`)
+	return err
+}
@@ -0,0 +1,267 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package main
+
+import (
+	"bindata"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// commands maps the names of subcommands to their implementation.
+// Each receives the arguments following its name and returns
+// the exit code.
+var commands = map[string]func(args []string) int{
+	"list":          list,
+	"extract":       extract,
+	"verify":        verify,
+	"diff":          diff,
+	"outdated":      outdated,
+	"plan":          plan,
+	"codetemplates": codetemplates,
+}
+
+// list prints the assets embedded in a generated file.
+func list(args []string) int {
+	flags := flag.NewFlagSet("list", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s list <generated file>\n\n", os.Args[0])
+		fmt.Printf("Prints the mode, size, modification time and name of each embedded asset.\n")
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	assets, err := bindata.ReadEmbedded(flags.Arg(0))
+	if err != nil {
+		return fail(err)
+	}
+
+	for _, a := range assets {
+		fmt.Printf("%v %10d %s %s\n", a.Mode, a.Size, a.ModTime.UTC().Format(time.RFC3339), a.Name)
+	}
+
+	return 0
+}
+
+// extract writes the assets embedded in a generated file back to disk.
+func extract(args []string) int {
+	var dir string
+
+	flags := flag.NewFlagSet("extract", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s extract [options] <generated file> [asset names]\n\n", os.Args[0])
+		fmt.Printf("Writes the named assets, or all of them, to disk.\n\n")
+		flags.PrintDefaults()
+	}
+	flags.StringVar(&dir, "o", ".", "Directory to write the assets to.")
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		flags.Usage()
+		return 2
+	}
+
+	assets, err := bindata.ReadEmbedded(flags.Arg(0))
+	if err != nil {
+		return fail(err)
+	}
+
+	names := make(map[string]bool)
+	for _, name := range flags.Args()[1:] {
+		names[name] = true
+	}
+
+	for i := range assets {
+		a := &assets[i]
+		if len(names) > 0 && !names[a.Name] {
+			continue
+		}
+		delete(names, a.Name)
+
+		err = writeAsset(dir, a)
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	for name := range names {
+		return fail(fmt.Errorf("Asset %s not found", name))
+	}
+
+	return 0
+}
+
+// writeAsset writes the given asset below dir, restoring
+// its mode and modification time.
+func writeAsset(dir string, a *bindata.EmbeddedAsset) error {
+	data, err := a.Bytes()
+	if err != nil {
+		return err
+	}
+
+	// Names are cleaned as absolute paths, so they can not point outside dir.
+	file := filepath.Join(dir, filepath.FromSlash(path.Clean("/"+a.Name)))
+	err = os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(file, data, a.Mode)
+	if err != nil {
+		return err
+	}
+
+	return os.Chtimes(file, a.ModTime, a.ModTime)
+}
+
+// verify checks whether a generated file matches the input assets.
+// It exits with a non-zero code if it does not, which is useful in CI.
+func verify(args []string) int {
+	changes, code := changes("verify", args)
+	if code != 0 {
+		return code
+	}
+
+	if len(changes) > 0 {
+		printChanges(changes)
+		fmt.Fprintf(os.Stderr, "bindata: %d asset(s) out of date\n", len(changes))
+		return 1
+	}
+
+	return 0
+}
+
+// diff prints the assets which differ between a generated
+// file and the input assets.
+func diff(args []string) int {
+	changes, code := changes("diff", args)
+	if code == 0 {
+		printChanges(changes)
+	}
+	return code
+}
+
+// changes parses the options as they are given to generate the
+// code and compares the generated code with its inputs.
+func changes(name string, args []string) ([]bindata.Change, int) {
+	flags := flag.NewFlagSet(name, flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s %s [options] <input directories>\n\n", os.Args[0], name)
+		fmt.Printf("Compares the output file with the input assets. Takes the\n")
+		fmt.Printf("options the output was generated with; most have no effect.\n\n")
+		flags.PrintDefaults()
+	}
+
+	c, _, _, _ := parseArgs(flags, args)
+	changes, err := bindata.Diff(c)
+	if err != nil {
+		return nil, fail(err)
+	}
+
+	return changes, 0
+}
+
+// outdated checks the sums of the input files against those recorded
+// in a generated file, and prints the assets which changed since. It
+// exits with a non-zero code if there are any, which is useful in CI.
+func outdated(args []string) int {
+	flags := flag.NewFlagSet("outdated", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s outdated [options] <input directories>\n\n", os.Args[0])
+		fmt.Printf("Compares the input files with the sums recorded in the output file,\n")
+		fmt.Printf("without decoding the embedded data. Takes the options the output\n")
+		fmt.Printf("was generated with; most have no effect.\n\n")
+		flags.PrintDefaults()
+	}
+
+	c, _, _, _ := parseArgs(flags, args)
+	stale, names, err := bindata.Outdated(c, "")
+	if err != nil {
+		return fail(err)
+	}
+
+	if stale {
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		fmt.Fprintf(os.Stderr, "bindata: %d asset(s) out of date\n", len(names))
+		return 1
+	}
+
+	return 0
+}
+
+func printChanges(changes []bindata.Change) {
+	for _, change := range changes {
+		fmt.Printf("%-8s %s\n", change.Kind, change.Name)
+	}
+}
+
+// plan prints the assets which would be embedded for the given
+// options, without writing the output file.
+func plan(args []string) int {
+	flags := flag.NewFlagSet("plan", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s plan [options] <input directories>\n\n", os.Args[0])
+		fmt.Printf("Prints the size, embedded size and compression ratio of each asset\n")
+		fmt.Printf("which would be embedded for the given options, the largest assets\n")
+		fmt.Printf("and the totals, without writing the output.\n\n")
+		flags.PrintDefaults()
+	}
+
+	c, _, _, _ := parseArgs(flags, args)
+	_, stats, err := bindata.Plan(c)
+	if err != nil {
+		return fail(err)
+	}
+
+	printWarnings(stats)
+
+	err = stats.WriteReport(os.Stdout, 10)
+	if err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+// codetemplates writes the built-in code templates into a directory,
+// to be changed and passed to -templatedir.
+func codetemplates(args []string) int {
+	flags := flag.NewFlagSet("codetemplates", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s codetemplates <directory>\n\n", os.Args[0])
+		fmt.Printf("Writes the templates the code of release builds is generated from,\n")
+		fmt.Printf("to be changed and passed to -templatedir.\n")
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+
+	err := bindata.WriteCodeTemplates(flags.Arg(0))
+	if err != nil {
+		return fail(err)
+	}
+
+	return 0
+}
+
+// fail prints the error and returns the exit code for it.
+func fail(err error) int {
+	fmt.Fprintf(os.Stderr, "bindata: %v\n", err)
+	return 1
+}
@@ -0,0 +1,451 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+// Command bindata converts asset files into Go source code,
+// using the bindata package.
+package main
+
+import (
+	"bindata"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+func main() {
+	if len(os.Args) > 1 {
+		if run, ok := commands[os.Args[1]]; ok {
+			os.Exit(run(os.Args[2:]))
+		}
+	}
+
+	flags := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Printf("Usage: %s [options] <input directories>\n", os.Args[0])
+		fmt.Printf("       %s list|extract|verify|diff|outdated|plan|codetemplates [options] ...\n\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+
+	cfg, watch, stats, report := parseArgs(flags, os.Args[1:])
+
+	var err error
+	if watch {
+		stop := make(chan struct{})
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt)
+		go func() {
+			<-sig
+			close(stop)
+		}()
+
+		err = bindata.WatchAndTranslate(cfg, stop)
+	} else {
+		var s *bindata.Stats
+		s, err = bindata.TranslateStats(cfg)
+
+		// With -continue, the code was written without the files which
+		// could not be read, which are reported after the statistics.
+		failed, _ := err.(bindata.AssetErrors)
+		if len(failed) > 0 {
+			err = nil
+		}
+
+		if err == nil {
+			printWarnings(s)
+		}
+		if err == nil && stats {
+			printStats("", s)
+		}
+		if err == nil && report {
+			err = s.WriteReport(os.Stdout, 10)
+		}
+		if err == nil && len(failed) > 0 {
+			printFailures(failed)
+			os.Exit(1)
+		}
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "bindata: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// printFailures prints the files which could not be read,
+// followed by their number.
+func printFailures(failed bindata.AssetErrors) {
+	for _, e := range failed {
+		fmt.Fprintf(os.Stderr, "bindata: %v\n", e)
+	}
+	fmt.Fprintf(os.Stderr, "bindata: %d files or directories could not be read\n", len(failed))
+}
+
+// parseArgs creates a new, filled configuration instance
+// by parsing the given command line options.
+//
+// This function exits the program with an error, if
+// any of the command line options are incorrect.
+func parseArgs(flags *flag.FlagSet, args []string) (*bindata.Config, bool, bool, bool) {
+	var ignore, include, minify, compression, collisions, lookup, funcnames, tags, exclude, filelist, header string
+	var watch, stats, report bool
+	var filters, rewrites, prologue, epilogue stringList
+
+	c := bindata.NewConfig()
+
+	// The configuration file provides the defaults of the other flags,
+	// so it is read before they are defined.
+	var config string
+	flags.StringVar(&config, "config", "", "Optional YAML or TOML file to read the configuration from. Other options override its settings.")
+	if name := configArg(args); len(name) > 0 {
+		var err error
+		c, err = bindata.NewConfigFromFile(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	flags.BoolVar(&c.Debug, "debug", c.Debug, "Do not embed the assets, but provide the embedding API. Contents will still be loaded from disk.")
+	flags.StringVar(&tags, "tags", "", "Optional comma separated list of build constraints to include.")
+	flags.StringVar(&c.Prefix, "prefix", c.Prefix, "Optional path prefix to strip off asset names.")
+	flags.Var(&rewrites, "rewrite", "Rename assets by replacing the parts of their names matching a regex, given as 'pattern=replacement'. May be repeated.")
+	flags.StringVar(&c.Package, "pkg", c.Package, "Package name to use in the generated code.")
+	flags.BoolVar(&c.Compat, "compat", c.Compat, "Generate a drop-in replacement for go-bindata output, without importing grate.")
+	flags.StringVar(&c.GrateImport, "grate", c.GrateImport, "Import path of the grate package to register the assets with. Empty disables this.")
+	flags.StringVar(&c.Output, "o", c.Output, "Optional name of the output file to be generated.")
+	flags.BoolVar(&c.NoMemCopy, "nomemcopy", c.NoMemCopy, "Use a .rodata hack to get rid of unnecessary memcopies. Refer to the documentation to see what implications this carries.")
+	flags.BoolVar(&c.NoUnsafe, "nounsafe", c.NoUnsafe, "Keep assets in string constants like -nomemcopy, but copy them on first use instead of using unsafe.")
+	flags.Int64Var(&c.MaxLiteralSize, "maxliteral", c.MaxLiteralSize, "Maximum number of bytes of asset data in a single literal. Larger assets are split into several variables. Zero disables the limit.")
+	flags.BoolVar(&c.Assembly, "asm", c.Assembly, "Write the asset data into an assembly file next to the output, with only thin Go accessors.")
+	flags.IntVar(&c.LineLength, "linelength", c.LineLength, "Maximum length of lines holding asset data. Zero selects 16 KB, a negative value disables the limit.")
+	flags.BoolVar(&c.NoCompress, "nocompress", c.NoCompress, "Assets will *not* be GZIP compressed when this flag is specified.")
+	flags.StringVar(&compression, "compression", c.Compression.String(), "Compression codec to use: gzip, zstd, brotli or none.")
+	flags.IntVar(&c.CompressionLevel, "level", c.CompressionLevel, "Compression level to use. Zero selects the codec's default.")
+	flags.IntVar(&c.Jobs, "jobs", c.Jobs, "Number of assets to encode concurrently. Zero uses one per CPU.")
+	flags.Int64Var(&c.ParallelSize, "parallel", c.ParallelSize, "Compress assets of at least this many bytes in chunks, using several workers. Zero disables this.")
+	flags.IntVar(&c.ParallelWorkers, "parallelworkers", c.ParallelWorkers, "Number of chunks of an asset to compress concurrently. Zero uses one per CPU.")
+	flags.IntVar(&c.ParallelChunkSize, "parallelchunk", c.ParallelChunkSize, "Size of the chunks compressed by each worker. Zero selects 1 MB.")
+	flags.StringVar(&c.EncryptKeyEnv, "encrypt", c.EncryptKeyEnv, "Optional environment variable holding a hex encoded AES key to encrypt the assets with.")
+	flags.BoolVar(&c.Checksums, "checksums", c.Checksums, "Embed a CRC-32 checksum of each asset, which is checked whenever it is loaded.")
+	flags.StringVar(&c.HMACKeyEnv, "hmac", c.HMACKeyEnv, "Optional environment variable holding a hex encoded key to compute an HMAC of each asset with.")
+	flags.BoolVar(&c.PoolReaders, "pool", c.PoolReaders, "Reuse gzip readers to decompress assets with fewer allocations.")
+	flags.BoolVar(&c.CacheDecompressed, "cache", c.CacheDecompressed, "Keep decompressed assets in memory after their first use.")
+	flags.Int64Var(&c.CacheSize, "cachesize", c.CacheSize, "Maximum number of bytes of decompressed assets kept with -cache. Zero disables the limit.")
+	flags.BoolVar(&c.ForceCompress, "forcecompress", c.ForceCompress, "Compress all assets, even those which do not benefit from it.")
+	flags.BoolVar(&c.SharedDictionary, "dict", c.SharedDictionary, "Compress all assets with a shared dictionary built from their contents. Requires gzip compression.")
+	flags.BoolVar(&c.Recursive, "r", c.Recursive, "Recursively include all assets in the input directories.")
+	flags.StringVar(&filelist, "filelist", "", "Optional file listing the files to embed, one per line as 'path' or 'path => name'. Use - for standard input.")
+	flags.StringVar(&ignore, "ignore", "", "Comma separated list of regex patterns for file names to ignore.")
+	flags.BoolVar(&c.IgnoreHidden, "ignorehidden", c.IgnoreHidden, "Ignore files and directories whose name starts with a dot.")
+	flags.BoolVar(&c.IgnoreVCS, "ignorevcs", c.IgnoreVCS, "Ignore version control metadata like .git, and node_modules and bower_components directories.")
+	flags.StringVar(&include, "include", "", "Comma separated list of glob patterns for file names to include.")
+	flags.StringVar(&minify, "minify", "", "Comma separated list of file extensions to minify: css, js, json, html or htm.")
+	flags.Var(&filters, "filter", "Pass assets matching a glob pattern through a command, given as 'pattern=command args'. May be repeated.")
+	flags.BoolVar(&c.FS, "fs", c.FS, "Generate an AssetFS function returning an fs.FS.")
+	flags.BoolVar(&c.Overlay, "overlay", c.Overlay, "Generate an OverlayFS function preferring files on disk over the embedded assets. Requires -fs.")
+	flags.BoolVar(&c.TypedNames, "typednames", c.TypedNames, "Generate an AssetName type with a constant per asset, accepted by the lookup functions.")
+	flags.BoolVar(&c.RootCAs, "rootcas", c.RootCAs, "Generate a RootCAs function, returning a pool of the certificates in the PEM encoded assets.")
+	flags.StringVar(&c.TimeZones, "timezones", c.TimeZones, "Register the given zoneinfo.zip asset with the time package, like importing time/tzdata.")
+	flags.BoolVar(&c.Extract, "extract", c.Extract, "Generate an ExtractAll function, writing the assets to a directory named after their hash.")
+	flags.BoolVar(&c.Accessors, "accessors", c.Accessors, "Generate an exported function returning the contents of each asset, like IndexHTML.")
+	flags.StringVar(&c.NameSalt, "salt", c.NameSalt, "Optional salt to replace asset names by hashes, which are only available through generated constants.")
+	flags.BoolVar(&c.Handler, "handler", c.Handler, "Generate an AssetHandler function returning an http.Handler.")
+	flags.StringVar(&c.IndexFallback, "fallback", c.IndexFallback, "Asset served by the handler for paths matching no asset, like index.html.")
+	flags.StringVar(&c.FallbackAsset, "fallbackasset", c.FallbackAsset, "Asset returned by Asset and AssetInfo for names matching no asset, like 404.html.")
+	flags.BoolVar(&c.PanicOnMissing, "panicmissing", c.PanicOnMissing, "Panic in Asset and AssetInfo for names matching no asset.")
+	flags.BoolVar(&c.IgnoreCase, "ignorecase", c.IgnoreCase, "Match asset names regardless of case in Asset and AssetInfo.")
+	flags.StringVar(&exclude, "fallbackexclude", "", "Comma separated list of path prefixes, which do not fall back to the index.")
+	flags.BoolVar(&c.Precompressed, "precompressed", c.Precompressed, "Generate AssetGzip and serve compressed assets as they are from the handler.")
+	flags.BoolVar(&c.Digests, "digests", c.Digests, "Generate AssetDigest and Digests functions.")
+	flags.Var(&prologue, "prologue", "Optional file holding Go code to insert after the imports. Files ending in .tmpl are executed as templates. May be repeated.")
+	flags.Var(&epilogue, "epilogue", "Optional file holding Go code to append to the output. Files ending in .tmpl are executed as templates. May be repeated.")
+	flags.StringVar(&c.GoVersion, "goversion", c.GoVersion, "Go version the generated code targets, like 1.21, or mod for the version in the go.mod file of the output.")
+	flags.StringVar(&c.TemplateDir, "templatedir", c.TemplateDir, "Optional directory of templates replacing those the code of release builds is generated from.")
+	flags.StringVar(&header, "header", "", "Optional file holding a text/template for the header of generated files, like a license block.")
+	flags.BoolVar(&c.Provenance, "provenance", c.Provenance, "Add a comment with the source file, size, SHA-256 sum and modification time of every asset.")
+	flags.BoolVar(&c.Metadata, "metadata", c.Metadata, "Generate GeneratedAt, GeneratorVersion and SourceHash constants and an AssetsVersion function.")
+	flags.StringVar(&c.Locales, "locales", c.Locales, "Generate an AssetLocalized function for the locale directories in the given asset directory.")
+	flags.StringVar(&c.DefaultLocale, "defaultlocale", c.DefaultLocale, "Locale which AssetLocalized falls back to.")
+	flags.StringVar(&c.Register, "register", c.Register, "Generate a Register function, adding the assets to a registry under the given package name.")
+	flags.StringVar(&c.Migrations, "migrations", c.Migrations, "Generate a Migrations function for the SQL migrations in the given asset directory.")
+	flags.BoolVar(&c.Templates, "templates", c.Templates, "Generate ParseTemplates and ParseTextTemplates functions.")
+	flags.BoolVar(&c.Fingerprints, "fingerprints", c.Fingerprints, "Generate HashedName and UnhashedName functions for content hashed asset names.")
+	flags.BoolVar(&c.ContentTypes, "contenttypes", c.ContentTypes, "Generate a ContentType function, with the MIME types determined during generation.")
+	flags.BoolVar(&c.SingleBlob, "blob", c.SingleBlob, "Embed all assets in a single string constant with an index.")
+	flags.StringVar(&c.DataFile, "datafile", c.DataFile, "Optional name of a data file to write the assets to with -blob, which is read at runtime.")
+	flags.BoolVar(&c.SplitOutput, "split", c.SplitOutput, "Write one file per asset into the directory of the output file.")
+	flags.BoolVar(&c.Merge, "merge", c.Merge, "Keep the assets of the existing split output which are not covered by the inputs.")
+	flags.BoolVar(&c.SyncOutput, "sync", c.SyncOutput, "Flush generated files to disk before replacing the previous ones.")
+	flags.StringVar(&collisions, "collisions", c.Collisions.String(), "Handling of assets with the same name: error, first, last or rename.")
+	flags.StringVar(&lookup, "lookup", c.Lookup.String(), "Lookup of assets by name in release builds: map, sorted or switch.")
+	flags.StringVar(&funcnames, "funcnames", c.FuncNaming.String(), "Naming of the functions generated for the assets: snake or camel.")
+	flags.BoolVar(&c.Format, "format", c.Format, "Format the generated code with go/format, and report code which does not parse.")
+	flags.StringVar(&c.IncrementalCache, "incremental", c.IncrementalCache, "Optional cache file recording the generated code, so later runs only encode changed assets.")
+	flags.Int64Var(&c.MaxAssetSize, "maxsize", c.MaxAssetSize, "Optional maximum size of a single asset in bytes, before compression.")
+	flags.Int64Var(&c.MaxTotalSize, "maxtotal", c.MaxTotalSize, "Optional maximum size of all assets together in bytes, before compression.")
+	flags.BoolVar(&c.SizeLimitWarn, "sizewarn", c.SizeLimitWarn, "Only warn about assets exceeding -maxsize or -maxtotal, instead of failing.")
+	flags.BoolVar(&c.ContinueOnError, "continue", c.ContinueOnError, "Leave out files which cannot be read and report all of them at the end, instead of failing at the first.")
+	flags.BoolVar(&c.SelfTest, "selftest", c.SelfTest, "Write a test file next to the output, checking the generated code with go test.")
+	flags.StringVar(&c.ManifestPath, "manifest", c.ManifestPath, "Optional JSON file to write a manifest of the assets to.")
+	flags.BoolVar(&watch, "watch", false, "Keep running and regenerate the output whenever assets change.")
+	flags.BoolVar(&stats, "stats", false, "Print the number of assets, the bytes saved by sharing the data of duplicates, and the number of name collisions.")
+	flags.BoolVar(&report, "report", false, "Print the size, embedded size and compression ratio of every asset, the largest assets, and the totals.")
+	flags.Parse(args)
+
+	if flags.NArg() == 0 && len(filelist) == 0 && len(c.Input) == 0 && len(c.Bundles) == 0 {
+		fmt.Fprintf(os.Stderr, "Missing <input dir>\n\n")
+		flags.Usage()
+		os.Exit(1)
+	}
+
+	var err error
+	c.Compression, err = bindata.ParseCompression(compression)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	c.Collisions, err = bindata.ParseCollision(collisions)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	c.Lookup, err = bindata.ParseLookup(lookup)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	c.FuncNaming, err = bindata.ParseFuncNaming(funcnames)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if len(tags) > 0 {
+		c.Tags = strings.Split(tags, ",")
+	}
+
+	if len(ignore) > 0 {
+		for _, pattern := range strings.Split(ignore, ",") {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid ignore pattern %q: %v\n", pattern, err)
+				os.Exit(1)
+			}
+			c.Ignore = append(c.Ignore, re)
+		}
+	}
+
+	if len(include) > 0 {
+		c.Include = strings.Split(include, ",")
+	}
+
+	if len(minify) > 0 {
+		c.Minify = strings.Split(minify, ",")
+	}
+
+	if len(exclude) > 0 {
+		c.FallbackExclude = strings.Split(exclude, ",")
+	}
+
+	for _, filter := range filters {
+		n := strings.Index(filter, "=")
+		args := strings.Fields(filter[n+1:])
+		if n <= 0 || len(args) == 0 || !validPattern(filter[:n]) {
+			fmt.Fprintf(os.Stderr, "Invalid filter %q, want 'pattern=command args'\n", filter)
+			os.Exit(1)
+		}
+		c.Transforms = append(c.Transforms, bindata.CommandTransform(filter[:n], args[0], args[1:]...))
+	}
+
+	for _, rewrite := range rewrites {
+		n := strings.Index(rewrite, "=")
+		if n <= 0 {
+			fmt.Fprintf(os.Stderr, "Invalid rewrite %q, want 'pattern=replacement'\n", rewrite)
+			os.Exit(1)
+		}
+
+		re, err := regexp.Compile(rewrite[:n])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid rewrite pattern %q: %v\n", rewrite[:n], err)
+			os.Exit(1)
+		}
+		c.Rewrites = append(c.Rewrites, bindata.Rewrite{Pattern: re, Replace: rewrite[n+1:]})
+	}
+
+	// Inputs given as arguments follow those of the configuration file.
+	for i := 0; i < flags.NArg(); i++ {
+		c.Input = append(c.Input, bindata.InputConfig{
+			Path:      flags.Arg(i),
+			Recursive: c.Recursive,
+		})
+
+		// Remote inputs carry their checksum in the fragment,
+		// as in https://example.com/file.dat#sha256=<hex>. Inputs
+		// for some platforms only list them in the same way, as in
+		// tools/linux#platforms=linux/amd64,linux/arm64.
+		input := &c.Input[len(c.Input)-1]
+		if n := strings.Index(input.Path, "#platforms="); n >= 0 {
+			input.Platforms = strings.Split(input.Path[n+len("#platforms="):], ",")
+			input.Path = input.Path[:n]
+		}
+		if n := strings.Index(input.Path, "#sha256="); n >= 0 {
+			input.SHA256 = input.Path[n+len("#sha256="):]
+			input.Path = input.Path[:n]
+		}
+
+		// Directories given as dir/... are recursive, regardless of -r.
+		var recursive bool
+		input.Path, recursive = bindata.ParseInputPath(input.Path)
+		input.Recursive = input.Recursive || recursive
+	}
+
+	// Listed files follow the inputs given as arguments.
+	if len(filelist) > 0 {
+		inputs, err := readFileList(filelist)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid file list: %v\n", err)
+			os.Exit(1)
+		}
+		c.Input = append(c.Input, inputs...)
+	}
+
+	if len(header) > 0 {
+		data, err := ioutil.ReadFile(header)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid header: %v\n", err)
+			os.Exit(1)
+		}
+		c.HeaderTemplate = string(data)
+	}
+
+	c.Prologue = append(c.Prologue, readSnippets(prologue)...)
+	c.Epilogue = append(c.Epilogue, readSnippets(epilogue)...)
+
+	return c, watch, stats, report
+}
+
+// printWarnings prints the warnings collected during generation,
+// including those of the bundles.
+func printWarnings(s *bindata.Stats) {
+	for _, msg := range s.Warnings {
+		fmt.Fprintf(os.Stderr, "bindata: warning: %s\n", msg)
+	}
+
+	for _, name := range bundleNames(s) {
+		for _, msg := range s.Bundles[name].Warnings {
+			fmt.Fprintf(os.Stderr, "bindata: %s: warning: %s\n", name, msg)
+		}
+	}
+}
+
+// printStats prints a summary of the generation, followed
+// by one for each bundle.
+func printStats(prefix string, s *bindata.Stats) {
+	if len(s.Bundles) == 0 || s.Assets > 0 {
+		fmt.Fprintf(os.Stderr, "bindata: %s%d assets, %d duplicates, %d bytes saved, %d name collisions\n",
+			prefix, s.Assets, s.Duplicates, s.Saved, s.Collisions)
+	}
+
+	for _, name := range bundleNames(s) {
+		printStats(name+": ", s.Bundles[name])
+	}
+}
+
+// bundleNames returns the names of the bundles in the statistics, sorted.
+func bundleNames(s *bindata.Stats) []string {
+	names := make([]string, 0, len(s.Bundles))
+	for name := range s.Bundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// configArg returns the value of the -config flag in the given
+// arguments, or an empty string if there is none.
+func configArg(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+
+		name := strings.TrimPrefix(strings.TrimPrefix(arg, "-"), "-")
+		if name == "config" && i+1 < len(args) {
+			return args[i+1]
+		}
+		if strings.HasPrefix(name, "config=") {
+			return name[len("config="):]
+		}
+	}
+	return ""
+}
+
+// stringList collects the values of a repeatable flag, like -filter.
+type stringList []string
+
+func (f *stringList) String() string {
+	return strings.Join(*f, ", ")
+}
+
+func (f *stringList) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// validPattern reports whether the given glob pattern is well formed.
+func validPattern(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+// readSnippets reads the snippets of code in the named files. Those
+// whose name ends in .tmpl are templates. It exits on failure.
+func readSnippets(names []string) []bindata.Snippet {
+	var list []bindata.Snippet
+	for _, name := range names {
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid snippet: %v\n", err)
+			os.Exit(1)
+		}
+
+		s, err := bindata.ParseSnippet(string(data))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid snippet %s: %v\n", name, err)
+			os.Exit(1)
+		}
+
+		s.Template = strings.HasSuffix(name, ".tmpl")
+		list = append(list, s)
+	}
+	return list
+}
+
+// readFileList reads the inputs of the file list with the given name,
+// or of standard input for "-".
+func readFileList(name string) ([]bindata.InputConfig, error) {
+	if name == "-" {
+		return bindata.ReadFileList(os.Stdin)
+	}
+
+	fd, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+	return bindata.ReadFileList(fd)
+}
@@ -0,0 +1,333 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// codeTemplateText holds the text/templates for the code of release builds,
+// by the names of the files in Config.TemplateDir overriding them. Each
+// is executed with a codeData.
+var codeTemplateText = map[string]string{
+	// bindata_read for compressed assets kept in strings.
+	"read_string.tmpl": `func bindata_read(data {{.DataType}}, name string) ([]byte, error) {
+	b, err := bindata_read_raw(data, name)
+	if err != nil {
+		return nil, err
+	}
+
+{{.Decrypt}}	return bindata_decompress(b, name)
+}
+
+`,
+
+	// bindata_read for compressed assets kept in byte slices.
+	"read_bytes.tmpl": `{{if .Encrypt}}func bindata_read(data []byte, name string) ([]byte, error) {
+	b, err := bindata_decrypt(data, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return bindata_decompress(b, name)
+}
+{{else}}func bindata_read(data []byte, name string) ([]byte, error) {
+	return bindata_decompress(data, name)
+}
+{{end}}
+`,
+
+	// bindata_read_raw, returning the contents of a string
+	// constant without copying, using unsafe.
+	"read_raw_unsafe.tmpl": `{{if .StringData}}func bindata_read_raw(data, name string) ([]byte, error) {
+	return unsafe.Slice(unsafe.StringData(data), len(data)), nil
+}
+{{else}}func bindata_read_raw(data, name string) ([]byte, error) {
+	var empty [0]byte
+	sx := (*reflect.StringHeader)(unsafe.Pointer(&data))
+	b := empty[:]
+	bx := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+	bx.Data = sx.Data
+	bx.Len = len(data)
+	bx.Cap = bx.Len
+	return b, nil
+}
+{{end}}
+`,
+
+	// bindata_read_raw for NoUnsafe, converting the string once.
+	"read_raw_string.tmpl": `// bindata_string holds asset data in a string, which is
+// converted to a byte slice on first use.
+type bindata_string struct {
+	once  sync.Once
+	data  string
+	bytes []byte
+}
+
+func bindata_read_raw(data *bindata_string, name string) ([]byte, error) {
+	data.once.Do(func() {
+		data.bytes = []byte(data.data)
+	})
+	return data.bytes, nil
+}
+
+`,
+
+	// The asset type and the file info of the assets.
+	"asset_types.tmpl": `type asset struct {
+	bytes  []byte
+	info   os.FileInfo
+	shared bool   // bytes are kept by the package
+	text   string // bytes as a string constant, if they are kept in one
+}
+
+type bindata_file_info struct {
+	name string
+	size int64
+	mode os.FileMode
+	modTime time.Time
+}
+
+func (fi bindata_file_info) Name() string {
+	return fi.name
+}
+func (fi bindata_file_info) Size() int64 {
+	return fi.size
+}
+func (fi bindata_file_info) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindata_file_info) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindata_file_info) IsDir() bool {
+	return false
+}
+func (fi bindata_file_info) Sys() {{.Any}} {
+	return nil
+}
+
+`,
+
+	// The accessors for the table of contents.
+	"lookup.tmpl": `{{if eq .Lookup "map"}}// bindata_lookup returns the generator for the asset with the given name.
+func bindata_lookup(name string) (func() (*asset, error), bool) {
+	f, ok := _bindata[name]
+	return f, ok
+}
+
+// bindata_toc returns the table of contents.
+func bindata_toc() map[string]func() (*asset, error) {
+	return _bindata
+}
+{{else}}{{if eq .Lookup "sorted"}}// bindata_lookup returns the generator for the asset with the given name,
+// searching the sorted names.
+func bindata_lookup(name string) (func() (*asset, error), bool) {
+	i := sort.SearchStrings(_bindata_names, name)
+	if i < len(_bindata_names) && _bindata_names[i] == name {
+		return _bindata_funcs[i], true
+	}
+	return nil, false
+}
+
+{{end}}var (
+	_bindata_toc_once sync.Once
+	_bindata_toc      map[string]func() (*asset, error)
+)
+
+// bindata_toc returns the table of contents,
+// which is built on first use.
+func bindata_toc() map[string]func() (*asset, error) {
+	_bindata_toc_once.Do(func() {
+		_bindata_toc = make(map[string]func() (*asset, error), len(_bindata_names))
+		for i, name := range _bindata_names {
+			_bindata_toc[name] = _bindata_funcs[i]
+		}
+	})
+	return _bindata_toc
+}
+{{end}}
+// bindata_tree returns the root of the asset tree.
+func bindata_tree() *_bintree_t {
+	return _bintree
+}
+
+`,
+
+	// The bytes function of an uncompressed asset kept in a string.
+	"bytes_string.tmpl": `func {{.Func}}_bytes() ([]byte, error) {
+	return {{.Read}}(
+		{{.Data}},
+		{{printf "%q" .Name}},
+	)
+}
+
+`,
+
+	// The end of the data of an uncompressed asset kept in a byte
+	// slice, following the data, and its bytes function.
+	"bytes_slice.tmpl": `{{.Quote}})
+
+func {{.Func}}_bytes() ([]byte, error) {
+	return {{.Read}}
+}
+
+`,
+
+	// The function returning an asset.
+	"asset.tmpl": `{{.Doc}}func {{.Func}}() (*asset, error) {
+	bytes, err := {{.Func}}_bytes()
+	if err != nil {
+		return nil, err
+	}
+
+{{.Checksum}}{{.Verify}}	info := {{.Info}}
+	a := &asset{bytes: bytes, info:  info{{.Fields}}}
+	return a, nil
+}
+
+`,
+}
+
+// defaultTemplates holds the parsed built-in code templates.
+var defaultTemplates = parseCodeTemplates()
+
+// parseCodeTemplates parses the built-in code templates.
+func parseCodeTemplates() *template.Template {
+	t := template.New("")
+	for name, text := range codeTemplateText {
+		template.Must(t.New(name).Parse(text))
+	}
+	return t
+}
+
+// codeData holds the variables of the code templates. The strings hold
+// code, like expressions, or statements ending in a newline. Only those
+// applying to a template are set.
+type codeData struct {
+	Func     string // The identifier of the asset's function, like index_html.
+	Doc      string // The doc comment of the asset's function, if any.
+	Name     string // The name of the asset.
+	Data     string // The expression holding the embedded data.
+	DataType string // The type of asset data kept in strings.
+	Read     string // The function or expression reading the data.
+	Quote    string // The quote ending the literal holding the data.
+	Decrypt  string // The statements decrypting b, if encrypted.
+	Checksum string // The statements checking the checksum of bytes.
+	Verify   string // The statements checking the HMAC of bytes.
+	Info     string // The file info literal of the asset.
+	Fields   string // Further fields of the asset literal.
+	Any      string // The empty interface type, any or interface{}.
+	Lookup   string // The lookup method, like map.
+	Encrypt  bool   // Whether the assets are encrypted.
+
+	// StringData is set if unsafe.Slice and unsafe.StringData
+	// are available, which is the case from Go 1.20 on.
+	StringData bool
+}
+
+// loadCodeTemplates returns the code templates, with those of the
+// given directory replacing the built-in ones. Every file ending in
+// .tmpl must override one of them. The templates are executed once,
+// so references to unknown variables are found before generation.
+// The returned sum identifies the texts of the templates replaced.
+func loadCodeTemplates(dir string) (*template.Template, string, error) {
+	if len(dir) == 0 {
+		return defaultTemplates, "", nil
+	}
+
+	fi, err := os.Stat(dir)
+	if err != nil {
+		return nil, "", err
+	}
+	if !fi.IsDir() {
+		return nil, "", fmt.Errorf("Template directory %s is not a directory", dir)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return nil, "", err
+	}
+
+	t := template.Must(defaultTemplates.Clone())
+	h := sha256.New()
+	for _, file := range files {
+		name := filepath.Base(file)
+		if _, ok := codeTemplateText[name]; !ok {
+			return nil, "", fmt.Errorf("Unknown code template %s", file)
+		}
+
+		text, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, "", err
+		}
+
+		_, err = t.New(name).Parse(string(text))
+		if err != nil {
+			return nil, "", fmt.Errorf("Invalid code template %s: %v", file, err)
+		}
+
+		err = t.ExecuteTemplate(ioutil.Discard, name, &codeData{})
+		if err != nil {
+			return nil, "", fmt.Errorf("Invalid code template %s: %v", file, err)
+		}
+
+		fmt.Fprintf(h, "%s\x00%d\x00%s", name, len(text), text)
+	}
+
+	return t, hex.EncodeToString(h.Sum(nil)[:8]), nil
+}
+
+// executeCode writes the code of the named template.
+func executeCode(w io.Writer, c *Config, name string, data *codeData) error {
+	t := c.codeTemplates
+	if t == nil {
+		t = defaultTemplates
+	}
+	return t.ExecuteTemplate(w, name, data)
+}
+
+// WriteCodeTemplates writes the built-in templates for the code of
+// release builds into the given directory, as a starting point for
+// those in Config.TemplateDir. Existing files are not overwritten.
+func WriteCodeTemplates(dir string) error {
+	names := make([]string, 0, len(codeTemplateText))
+	for name := range codeTemplateText {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		file := filepath.Join(dir, name)
+		fd, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.Copy(fd, strings.NewReader(codeTemplateText[name]))
+		if cerr := fd.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Collision selects how assets with the same name are handled.
+type Collision int
+
+// Known collision policies.
+const (
+	CollisionError     Collision = iota // Fail the generation. This is the default.
+	CollisionFirstWins                  // Keep the asset of the earliest input.
+	CollisionLastWins                   // Keep the asset of the latest input.
+	CollisionRename                     // Add a numeric suffix to the names of later assets.
+)
+
+func (v Collision) String() string {
+	switch v {
+	case CollisionError:
+		return "error"
+	case CollisionFirstWins:
+		return "first"
+	case CollisionLastWins:
+		return "last"
+	case CollisionRename:
+		return "rename"
+	}
+	return fmt.Sprintf("Collision(%d)", int(v))
+}
+
+// ParseCollision returns the collision policy with the given name,
+// as returned by its String method.
+func ParseCollision(name string) (Collision, error) {
+	for _, v := range []Collision{CollisionError, CollisionFirstWins, CollisionLastWins, CollisionRename} {
+		if v.String() == name {
+			return v, nil
+		}
+	}
+	return CollisionError, fmt.Errorf("Unknown collision policy %q", name)
+}
+
+// resolveCollisions applies the configured policy to assets sharing the
+// same name. The assets must be sorted by name, keeping the order of the
+// inputs for equal names. The remaining assets are returned, still sorted.
+// Kept or renamed assets count the collisions they were involved in.
+func resolveCollisions(c *Config, toc []Asset) ([]Asset, error) {
+	names := make(map[string]bool, len(toc))
+	for i := range toc {
+		names[toc[i].Name] = true
+	}
+
+	out := toc[:0]
+	renamed := false
+	for i := 0; i < len(toc); {
+		j := i + 1
+		for j < len(toc) && toc[j].Name == toc[i].Name {
+			j++
+		}
+
+		switch {
+		case j == i+1:
+			out = append(out, toc[i])
+
+		case !platformsCollide(toc[i:j]):
+			out = append(out, toc[i:j]...)
+
+		case c.Collisions == CollisionFirstWins:
+			toc[i].collisions = j - i - 1
+			out = append(out, toc[i])
+
+		case c.Collisions == CollisionLastWins:
+			toc[j-1].collisions = j - i - 1
+			out = append(out, toc[j-1])
+
+		case c.Collisions == CollisionRename:
+			out = append(out, toc[i])
+			for k := i + 1; k < j; k++ {
+				asset := toc[k]
+				asset.Name = renameAsset(asset.Name, names)
+				asset.Func = safeFunctionName(asset.Name, make(map[string]int))
+				asset.collisions = 1
+				out = append(out, asset)
+			}
+			renamed = true
+
+		default:
+			return nil, fmt.Errorf("Asset name %q is used by both %s and %s",
+				toc[i].Name, toc[i].origin(), toc[i+1].origin())
+		}
+
+		i = j
+	}
+
+	if renamed {
+		sort.Stable(assetsByName(out))
+	}
+
+	return out, nil
+}
+
+// renameAsset returns the given name with the lowest numeric suffix,
+// which is not used yet, and records it as used. The suffix is added
+// before the extension, as in "app-2.css".
+func renameAsset(name string, names map[string]bool) string {
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d%s", base, n, ext)
+		if !names[candidate] {
+			names[candidate] = true
+			return candidate
+		}
+	}
+}
+
+// uniqueFuncs ensures every asset has a function name of its own.
+// Names are only unique within the inputs they were derived for, so
+// later assets with a name taken already are given a new one.
+func uniqueFuncs(toc []Asset) {
+	knownFuncs := make(map[string]int, len(toc))
+	for i := range toc {
+		if _, ok := knownFuncs[toc[i].Func]; !ok {
+			knownFuncs[toc[i].Func] = 2
+		}
+	}
+
+	seen := make(map[string]bool, len(toc))
+	for i := range toc {
+		if seen[toc[i].Func] {
+			toc[i].Func = safeFunctionName(toc[i].Func, knownFuncs)
+		}
+		seen[toc[i].Func] = true
+	}
+}
@@ -0,0 +1,39 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// validateCompat ensures the options do not change the
+// signatures of the classic go-bindata API.
+func validateCompat(c *Config) error {
+	if c.Compat && c.typedNames() {
+		return fmt.Errorf("Compatibility mode cannot be combined with typed or obfuscated asset names")
+	}
+
+	return nil
+}
+
+// writeCompat writes assertions of the signatures of the classic
+// go-bindata API, so any deviation fails to compile.
+func writeCompat(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `// The API of go-bindata, which this file is a drop-in replacement for.
+var (
+	_ func(name string) ([]byte, error)      = Asset
+	_ func(name string) []byte               = MustAsset
+	_ func(name string) (os.FileInfo, error) = AssetInfo
+	_ func() []string                        = AssetNames
+	_ func(name string) ([]string, error)    = AssetDir
+	_ func(dir, name string) error           = RestoreAsset
+	_ func(dir, name string) error           = RestoreAssets
+	_ map[string]func() (*asset, error)      = _bindata
+)
+
+`)
+	return err
+}
@@ -0,0 +1,94 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// writeAssetCompressed writes the AssetCompressed function, which returns
+// the data of an asset as it is embedded, if it is compressed. In debug
+// builds, without compression and for encrypted assets, there is no
+// compressed data to return.
+func writeAssetCompressed(w io.Writer, c *Config, toc []Asset) error {
+	_, err := fmt.Fprintf(w, `// AssetCompressed returns the data of the named asset, compressed with the
+// given content coding, like "gzip" or "br". It can be sent as it is, along
+// with a matching Content-Encoding header. An error is returned if the asset
+// is not embedded in this encoding, in which case Asset should be used.
+// The data is kept by the package, and shared between all callers, so it
+// must never be altered.
+func AssetCompressed(name %s, encoding string) ([]byte, error) {
+`, c.nameType())
+	if err != nil {
+		return err
+	}
+
+	if c.Debug || c.compression() == CompressNone || c.encrypt() || c.sharedDictionary() {
+		_, err = fmt.Fprintf(w, `	return nil, fmt.Errorf("Asset %%s not available in %%s encoding", name, encoding)
+}
+
+`)
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `	cannonicalName := bindata_clean(%s)
+	if f, ok := _bindata_compressed[cannonicalName]; ok && encoding == %q {
+		return f()
+	}
+	return nil, fmt.Errorf("Asset %%s not available in %%s encoding", name, encoding)
+}
+
+var _bindata_compressed = map[string]func() ([]byte, error){
+`, c.stringArg("name"), c.compression().encoding())
+	if err != nil {
+		return err
+	}
+
+	for i := range toc {
+		asset := &toc[i]
+		if !asset.Compressed {
+			continue
+		}
+
+		_, err = fmt.Fprintf(w, "\t%q: %s,\n", asset.Name, compressedFunc(c, asset, i))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
+
+// writeAssetGzip writes the AssetGzip function.
+func writeAssetGzip(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// AssetGzip returns the gzip compressed data of the named asset, as it
+// is embedded. An error is returned if the asset is not embedded gzip
+// compressed, in which case Asset should be used.
+func AssetGzip(name %s) ([]byte, error) {
+	return AssetCompressed(name, "gzip")
+}
+
+`, c.nameType())
+	return err
+}
+
+// compressedFunc returns an expression for a function, which returns
+// the compressed data of the given asset. The index locates the asset
+// in the single blob layout.
+func compressedFunc(c *Config, asset *Asset, index int) string {
+	if asset.original != nil && !c.SingleBlob {
+		asset = asset.original
+	}
+
+	switch {
+	case c.SingleBlob:
+		return fmt.Sprintf("_bindata_entries[%d].raw", index)
+	case c.assetStringData(asset):
+		return fmt.Sprintf("func() ([]byte, error) { return bindata_read_raw(%s, %q) }", dataExpr(c, asset), asset.Name)
+	}
+	return fmt.Sprintf("func() ([]byte, error) { return %s, nil }", dataExpr(c, asset))
+}
@@ -0,0 +1,329 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// Compression selects the codec used to compress asset data.
+type Compression int
+
+// Known compression codecs.
+const (
+	CompressGzip   Compression = iota // Compress using gzip. This is the default.
+	CompressZstd                      // Compress using Zstandard.
+	CompressNone                      // Do not compress at all.
+	CompressBrotli                    // Compress using brotli.
+)
+
+func (v Compression) String() string {
+	switch v {
+	case CompressGzip:
+		return "gzip"
+	case CompressZstd:
+		return "zstd"
+	case CompressNone:
+		return "none"
+	case CompressBrotli:
+		return "brotli"
+	}
+	return fmt.Sprintf("Compression(%d)", int(v))
+}
+
+// ParseCompression returns the codec with the given name,
+// as returned by its String method.
+func ParseCompression(name string) (Compression, error) {
+	for _, v := range []Compression{CompressGzip, CompressZstd, CompressNone, CompressBrotli} {
+		if v.String() == name {
+			return v, nil
+		}
+	}
+	return CompressGzip, fmt.Errorf("Unknown compression %q", name)
+}
+
+// encoding returns the HTTP content coding of the codec,
+// as used in the Content-Encoding header.
+func (v Compression) encoding() string {
+	switch v {
+	case CompressGzip:
+		return "gzip"
+	case CompressZstd:
+		return "zstd"
+	case CompressBrotli:
+		return "br"
+	}
+	return ""
+}
+
+// encoders maps each codec to a function, which creates an encoder
+// writing compressed data to w. Codecs which depend on packages outside
+// the standard library register themselves from files guarded by build
+// tags, so the generator builds without them.
+var encoders = map[Compression]func(w io.Writer, c *Config) (io.WriteCloser, error){
+	CompressGzip: func(w io.Writer, c *Config) (io.WriteCloser, error) {
+		level := c.CompressionLevel
+		// With a shared dictionary, raw deflate data is written, as gzip
+		// does not support preset dictionaries. The faster levels make
+		// little use of the dictionary for small assets.
+		if c.sharedDictionary() {
+			if level == 0 {
+				level = flate.BestCompression
+			}
+			return flate.NewWriterDict(w, level, c.dictionary)
+		}
+		if level == 0 {
+			level = gzip.DefaultCompression
+		}
+		// The header is left empty. Without a name or timestamp,
+		// the output only depends on the asset contents.
+		return gzip.NewWriterLevel(w, level)
+	},
+}
+
+// decoders maps each codec to a function decompressing data.
+// They are used to read assets from generated code. Like encoders,
+// codecs outside the standard library register themselves.
+var decoders = map[Compression]func(data []byte) ([]byte, error){
+	CompressGzip: func(data []byte) ([]byte, error) {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+
+		defer gz.Close()
+		return ioutil.ReadAll(gz)
+	},
+}
+
+// buildTags maps codecs to the build tag enabling their encoder.
+var buildTags = map[Compression]string{
+	CompressZstd:   "zstd",
+	CompressBrotli: "brotli",
+}
+
+// newEncoder returns an encoder for the configured codec.
+func newEncoder(w io.Writer, c *Config) (io.WriteCloser, error) {
+	fn, ok := encoders[c.compression()]
+	if !ok {
+		return nil, fmt.Errorf("No encoder for %s compression", c.compression())
+	}
+	return fn(w, c)
+}
+
+// validateCompression ensures the configured codec is known,
+// an encoder for it has been compiled in and the level is valid.
+func validateCompression(v Compression, level int) error {
+	switch v {
+	case CompressNone:
+		return nil
+	case CompressGzip, CompressZstd, CompressBrotli:
+	default:
+		return fmt.Errorf("Unknown compression %s", v)
+	}
+
+	if v == CompressGzip && (level < gzip.HuffmanOnly || level > gzip.BestCompression) {
+		return fmt.Errorf("Invalid gzip compression level %d", level)
+	}
+
+	// Brotli qualities range from 0 to 11.
+	if v == CompressBrotli && (level < 0 || level > 11) {
+		return fmt.Errorf("Invalid brotli compression level %d", level)
+	}
+
+	if _, ok := encoders[v]; !ok {
+		return fmt.Errorf("Compression %s is not supported by this build; rebuild with -tags %s", v, buildTags[v])
+	}
+
+	return nil
+}
+
+// compressionImports returns the packages required by the generated
+// decompression code.
+func compressionImports(v Compression) []string {
+	switch v {
+	case CompressGzip:
+		return []string{"bytes", "compress/gzip", "fmt", "io"}
+	case CompressZstd:
+		return []string{"fmt", "github.com/klauspost/compress/zstd"}
+	case CompressBrotli:
+		return []string{"bytes", "fmt", "github.com/andybalholm/brotli", "io"}
+	}
+	return nil
+}
+
+// writeDecompress writes the bindata_decompress function
+// for the configured codec.
+func writeDecompress(w io.Writer, c *Config) error {
+	if c.sharedDictionary() {
+		return writeDictionary(w, c)
+	}
+
+	var err error
+	switch c.compression() {
+	case CompressGzip:
+		if c.PoolReaders {
+			return writePooledGzip(w, c)
+		}
+
+		_, err = fmt.Fprintf(w, `func bindata_decompress(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %%q: %%w", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %%q: %%w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+`)
+	case CompressZstd:
+		_, err = fmt.Fprintf(w, `// _bindata_zstd is shared by all assets. DecodeAll is safe
+// for concurrent use.
+var _bindata_zstd, _ = zstd.NewReader(nil)
+
+func bindata_decompress(data []byte, name string) ([]byte, error) {
+	buf, err := _bindata_zstd.DecodeAll(data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Read %%q: %%w", name, err)
+	}
+
+	return buf, nil
+}
+
+`)
+	case CompressBrotli:
+		_, err = fmt.Fprintf(w, `func bindata_decompress(data []byte, name string) ([]byte, error) {
+	var buf bytes.Buffer
+	_, err := io.Copy(&buf, brotli.NewReader(bytes.NewReader(data)))
+	if err != nil {
+		return nil, fmt.Errorf("Read %%q: %%w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+`)
+	}
+	return err
+}
+
+// writePooledGzip writes bindata_decompress for gzip, reusing readers
+// kept in pools. The output buffer is sized from the gzip trailer, which
+// holds the size of the data, modulo 4 GB, so it hardly ever grows.
+func writePooledGzip(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `var (
+	_bindata_gzip_pool  sync.Pool
+	_bindata_bytes_pool = sync.Pool{New: func() %s { return new(bytes.Reader) }}
+)
+
+func bindata_decompress(data []byte, name string) ([]byte, error) {
+	r := _bindata_bytes_pool.Get().(*bytes.Reader)
+	r.Reset(data)
+	defer func() {
+		r.Reset(nil)
+		_bindata_bytes_pool.Put(r)
+	}()
+
+	var err error
+	gz, _ := _bindata_gzip_pool.Get().(*gzip.Reader)
+	if gz == nil {
+		gz, err = gzip.NewReader(r)
+	} else {
+		err = gz.Reset(r)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Read %%q: %%w", name, err)
+	}
+	defer _bindata_gzip_pool.Put(gz)
+
+	size := 0
+	if n := len(data); n >= 4 {
+		size = int(binary.LittleEndian.Uint32(data[n-4:]))
+	}
+
+	buf := bytes.NewBuffer(make([]byte, 0, size+bytes.MinRead))
+	_, err = io.Copy(buf, gz)
+	if err != nil {
+		return nil, fmt.Errorf("Read %%q: %%w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+`, c.anyType())
+	return err
+}
+
+// incompressible holds extensions of file formats, which are
+// compressed already.
+var incompressible = map[string]bool{
+	".7z": true, ".avif": true, ".br": true, ".bz2": true, ".gif": true,
+	".gz": true, ".jar": true, ".jpeg": true, ".jpg": true, ".mp3": true,
+	".mp4": true, ".ogg": true, ".png": true, ".tgz": true, ".webm": true,
+	".webp": true, ".woff": true, ".woff2": true, ".xz": true, ".zip": true,
+	".zst": true,
+}
+
+// sampleSize is the amount of data compressed to
+// estimate whether an asset is worth compressing.
+const sampleSize = 64 * 1024
+
+// worthCompressing reports whether the given asset should be compressed.
+// Assets with a known compressed format are skipped right away. Otherwise
+// a sample from the start of the file is compressed, and the asset is only
+// compressed if this saves at least 5%. The file is rewound afterwards.
+func worthCompressing(c *Config, asset *Asset, fd io.ReadSeeker) (bool, error) {
+	if incompressible[strings.ToLower(filepath.Ext(asset.Path))] {
+		return false, nil
+	}
+
+	var counter countWriter
+	enc, err := newEncoder(&counter, c)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := io.Copy(enc, io.LimitReader(fd, sampleSize))
+	if err != nil {
+		return false, err
+	}
+
+	err = enc.Close()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = fd.Seek(0, io.SeekStart)
+	if err != nil {
+		return false, err
+	}
+
+	return counter.n < n-n/20, nil
+}
+
+// countWriter counts the bytes written to it and discards them.
+type countWriter struct {
+	n int64
+}
+
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
@@ -9,17 +9,55 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"strings"
+	"text/template"
 )
 
 // InputConfig defines options on a asset directory to be convert.
 type InputConfig struct {
 	// Path defines a directory containing asset files to be included
-	// in the generated output.
+	// in the generated output. It may also name a .zip, .tar, .tar.gz
+	// or .tgz archive, whose entries are included as if the archive
+	// were a directory of the same name.
 	Path string
 
 	// Recusive defines whether subdirectories of Path
 	// should be recursively included in the conversion.
+	// ParseInputPath derives it from paths like dir/...
 	Recursive bool
+
+	// Prefix defines a path prefix which should be stripped from the
+	// names of assets found below Path. If set, it is used instead of
+	// Config.Prefix. This allows inputs like web/static and
+	// configs/defaults to both yield short names.
+	Prefix string
+
+	// Ignore holds additional patterns of files to ignore below Path.
+	// They apply in addition to Config.Ignore.
+	Ignore []*regexp.Regexp
+
+	// Include holds additional glob patterns of files to include
+	// below Path. They apply in addition to Config.Include.
+	Include []string
+
+	// SHA256 holds the hex encoded SHA-256 checksum of the file
+	// downloaded for a remote input. It is required if Path is an
+	// HTTP or HTTPS URL, which is embedded under the last element
+	// of its path.
+	SHA256 string
+
+	// Platforms limits the assets found below Path to builds for the
+	// given target platforms, each given as "goos" or "goos/goarch",
+	// like "linux" or "windows/amd64". The output is then written once
+	// for each platform, with the platform appended to its name and
+	// a matching build constraint, and once for all other platforms.
+	Platforms []string
+
+	// Name overrides the name of the asset, if Path names a single file.
+	// The prefix is not stripped from it. ReadFileList sets it for lines
+	// like "build/app.3f2a.js => js/app.js".
+	Name string
 }
 
 // Config defines a set of options for the asset conversion.
@@ -27,11 +65,13 @@ type Config struct {
 	// Name of the package to use. Defaults to 'main'.
 	Package string
 
-	// Tags specify a set of optional build tags, which should be
-	// included in the generated output. The tags are appended to a
-	// `// +build` line in the beginning of the output file
-	// and must follow the build tags syntax specified by the go tool.
-	Tags string
+	// Tags specify a set of optional build constraints, which should be
+	// included in the generated output. The output is only built if all
+	// of them are satisfied. Each entry follows either the //go:build
+	// syntax, like "dev && !windows", or the older // +build syntax.
+	// The output begins with a //go:build line and the equivalent
+	// // +build lines for older Go versions.
+	Tags []string
 
 	// Input defines the directory path, containing all asset files as
 	// well as whether to recursively process assets in any sub directories.
@@ -53,8 +93,16 @@ type Config struct {
 	//
 	// 	$ go-bindata -prefix "/path/to/" /path/to/templates/foo.html
 	// 	go_bindata["templates/foo.html"] = templates_foo_html
+	//
+	// Inputs may override this with their own prefix.
 	Prefix string
 
+	// Rewrites are applied to the asset names in turn, once the prefix
+	// is stripped. They rename assets without renaming their files, like
+	// flattening dist/assets/js/app.js to js/app.js, or dropping hashes
+	// from names like app.3f2a9c.js.
+	Rewrites []Rewrite
+
 	// NoMemCopy will alter the way the output file is generated.
 	//
 	// It will employ a hack that allows us to read the file data directly from
@@ -101,11 +149,247 @@ type Config struct {
 	// 	}
 	NoMemCopy bool
 
+	// NoUnsafe keeps the asset data in string constants, like NoMemCopy,
+	// but does not depend on `reflect` and `unsafe`. The data of each asset
+	// is copied into a byte slice on first use, which is kept and returned
+	// on later calls. Asset returns a copy of it, AssetUnsafe the slice
+	// shared between all callers. This takes precedence over NoMemCopy.
+	NoUnsafe bool
+
+	// LineLength limits the length of the lines holding asset data in
+	// the output, in bytes. Longer data is split into string literals
+	// joined with +, as some editors, code review tools and CI systems
+	// do not cope with very long lines. Zero selects 16 KB, a negative
+	// value writes every literal on a single line.
+	LineLength int
+
+	// MaxLiteralSize limits the number of bytes of asset data held by a
+	// single literal. The data of larger assets is split into several
+	// variables, which are joined when the asset is read. The compiler
+	// needs a lot of memory for huge literals, and may fail on assets of
+	// several GB, which this avoids. Zero does not limit literals. This
+	// cannot be combined with SingleBlob.
+	MaxLiteralSize int64
+
+	// Assembly writes the data of the assets into an assembly file next
+	// to the output, named like it with a .s extension, rather than into
+	// Go literals. The Go code only declares the arrays holding the data,
+	// which keeps the compiler from parsing huge literals. The data is
+	// placed in read only memory, so the bytes returned by AssetUnsafe
+	// must not be altered. The output must end in .go, and this cannot be combined
+	// with NoMemCopy, NoUnsafe, MaxLiteralSize, SingleBlob, SplitOutput,
+	// IncrementalCache or platform specific inputs.
+	Assembly bool
+
 	// NoCompress means the assets are /not/ GZIP compressed before being turned
 	// into Go code. The generated function will automatically unzip
 	// the file data when called. Defaults to false.
+	//
+	// Setting this is equivalent to setting Compression to CompressNone.
 	NoCompress bool
 
+	// Compression selects the codec used to compress the assets.
+	// The generated code picks the matching decoder. Defaults to CompressGzip.
+	//
+	// CompressZstd generates code depending on github.com/klauspost/compress/zstd.
+	// The generator itself only supports it when built with the zstd build tag.
+	// Likewise, CompressBrotli depends on github.com/andybalholm/brotli and
+	// the brotli build tag.
+	Compression Compression
+
+	// CompressionLevel is passed to the encoder of the selected codec.
+	// For gzip, this is one of the levels defined in compress/gzip,
+	// such as gzip.BestSpeed or gzip.BestCompression. For zstd, it is
+	// a zstd level, which is mapped to the closest encoder speed.
+	// For brotli, it is a quality between 1 and 11. Zero selects the codec's default level.
+	CompressionLevel int
+
+	// ForceCompress compresses every asset. By default, assets which do
+	// not benefit from compression are embedded uncompressed. These are
+	// files with extensions of well known compressed formats, like .png or
+	// .woff2, and files for which compressing a sample of their contents
+	// does not save at least 5%.
+	ForceCompress bool
+
+	// SharedDictionary compresses all assets with a dictionary, which is
+	// built from the byte sequences they have in common and embedded
+	// once. This greatly improves the compression of many small, similar
+	// files, like JSON documents or templates, at the cost of embedding
+	// the dictionary of up to 32 KiB. Unless CompressionLevel is set, the
+	// best compression is used. The data is then raw deflate data
+	// rather than gzip, so it is not available through AssetCompressed,
+	// and not served precompressed. This requires gzip compression, and
+	// cannot be combined with IncrementalCache.
+	SharedDictionary bool
+
+	// Jobs is the number of assets encoded concurrently in release
+	// builds. The output does not depend on it. Zero uses one job per
+	// CPU, while one encodes all assets in turn, streaming them directly
+	// to the output file.
+	Jobs int
+
+	// ParallelSize enables compressing large assets in chunks, using
+	// several workers per asset, like pigz does. Assets of at least this
+	// many bytes are split into chunks of ParallelChunkSize bytes, 1 MiB
+	// unless set, of which ParallelWorkers are compressed at once, one
+	// per CPU unless set. The output only depends on the chunk size, and
+	// is still a single gzip stream, slightly larger than one compressed
+	// in one go. Zero disables this. This requires gzip compression, and
+	// cannot be combined with SharedDictionary.
+	ParallelSize      int64
+	ParallelWorkers   int
+	ParallelChunkSize int
+
+	// PoolReaders reuses the gzip readers decompressing the assets, which
+	// are kept in a sync.Pool, and sizes the decompressed data from the
+	// gzip trailer. This saves allocations when assets are read at high
+	// rates. It requires gzip compression without a shared dictionary.
+	// The buffer holding the decompressed data is not pooled, but
+	// allocated for every read, as the data is returned to the caller.
+	PoolReaders bool
+
+	// CacheDecompressed keeps the decompressed data of each asset after
+	// its first use, so later calls return it right away instead of
+	// decompressing it again. Asset returns a copy of the data, while
+	// AssetUnsafe returns the data shared between all callers, which
+	// must not be altered. This also generates a FlushAssetCache
+	// function, which drops the cached data.
+	CacheDecompressed bool
+
+	// CacheSize limits the total size of the decompressed data kept by
+	// CacheDecompressed, in bytes. Once it is exceeded, the data of the
+	// assets used least recently is dropped. Zero does not limit the
+	// size. The generated SetAssetCacheSize function changes the limit
+	// at runtime.
+	CacheSize int64
+
+	// SingleBlob embeds all assets in a single string constant, followed
+	// by an index of the offset and length of each asset within it. This
+	// replaces the variables and functions generated for every asset,
+	// which speeds up compiling and linking a large number of assets.
+	// It only applies to release builds and cannot be combined with
+	// SplitOutput.
+	SingleBlob bool
+
+	// DataFile names a file, which the data of the assets is written to
+	// with the SingleBlob layout, instead of the string constant. The
+	// generated code only holds the index, and reads the assets through
+	// an io.ReaderAt, which keeps the build fast for large assets. At
+	// runtime, the data is looked for at the end of the executable, so
+	// the file may be appended to it, then in a file of the same name
+	// next to it. SetAssetData sets the location of the data instead.
+	DataFile string
+
+	// IncrementalCache names a file recording the code generated for each
+	// asset. If set, later runs only encode the assets which changed since
+	// the previous run, and copy the code of all others from the previous
+	// output. Assets are considered unchanged if their size and modification
+	// time are the same. If the output or options were changed in between,
+	// all assets are encoded again. This applies to release builds written
+	// to a single file, without the SingleBlob layout.
+	IncrementalCache string
+
+	// SyncOutput flushes the generated files to disk before they replace
+	// the previous ones. Files are always written to a temporary file
+	// first, so a failed run does not leave a partial file behind. This
+	// additionally guards against crashes of the system.
+	SyncOutput bool
+
+	// Collisions selects how assets with the same name are handled, for
+	// instance when two inputs hold a file of the same name. By default,
+	// generation fails. Assets can also be dropped in favour of the one
+	// from the first or last input, or be renamed.
+	Collisions Collision
+
+	// Lookup selects how release builds find assets by name. By default,
+	// they are kept in a map, whose literal takes long to compile and to
+	// initialize with tens of thousands of assets. LookupSorted generates
+	// a sorted slice of the names instead, which is searched by binary
+	// search, and LookupSwitch a switch statement over them. Neither needs
+	// initialization at runtime, but functions going through all assets,
+	// like AssetGlob, build a map on first use. Debug builds always use
+	// a map.
+	Lookup Lookup
+
+	// FuncNaming selects how the identifiers of the functions generated
+	// for the assets are derived from their names: in snake case, like
+	// css_app_css, or in camel case, like cssAppCss. FuncNameFor, if set,
+	// derives them instead, and must return an unexported identifier.
+	// Names derived for more than one asset, or clashing with keywords,
+	// get numeric suffixes.
+	FuncNaming  FuncNaming
+	FuncNameFor func(name string) string
+
+	// Format passes the generated code through go/format before it is
+	// written. The code is then held in memory as a whole. If it does
+	// not parse, generation fails, and the code is written to a
+	// temporary file named in the error. This can not be combined
+	// with IncrementalCache.
+	Format bool
+
+	// ManifestPath, if set, names a JSON file which is written along with
+	// the generated code. It lists the name, source path, size, mode,
+	// modification time and SHA-256 sum of every asset, as well as the
+	// embedded size of compressed assets, for use by deployment tooling.
+	ManifestPath string
+
+	// SelfTest writes a test file next to the output, named like it with
+	// a _test suffix, or bindata_test.go in split mode. Its tests read
+	// every asset, compare the sizes with those reported by AssetInfo,
+	// and check that AssetDir agrees with AssetNames, so go test catches
+	// broken generated code.
+	SelfTest bool
+
+	// MaxAssetSize and MaxTotalSize limit the size of every single asset
+	// and of all assets together, counting the contents as they are read,
+	// before compression. Duplicates do not count towards the total, as
+	// their data is shared. Zero disables a limit. Exceeding a limit fails
+	// the generation, unless SizeLimitWarn is set, which only reports it
+	// in Stats.Warnings.
+	MaxAssetSize  int64
+	MaxTotalSize  int64
+	SizeLimitWarn bool
+
+	// ContinueOnError leaves out the files and directories which cannot
+	// be read, like those without permission or vanished since they were
+	// found, rather than failing at the first of them. The code is written
+	// for the remaining assets, and all failures are returned at the end,
+	// as AssetErrors, and in Stats.Failures.
+	ContinueOnError bool
+
+	// Events, if set, is called as the generation progresses: once the
+	// assets are found, after the code of each asset was written, for
+	// every warning and failure, and once an output is done. Calls come
+	// from the goroutine calling Translate, one at a time, and assets
+	// are reported in order even if they are encoded in parallel. The
+	// callback should return quickly, as the generation waits for it.
+	// Bundles without a callback of their own report to this one.
+	Events func(Event)
+
+	// EncryptKeyEnv names an environment variable holding a hex encoded
+	// AES key of 16, 24 or 32 bytes. If set, release builds encrypt the
+	// data of every asset with AES-GCM, using the key read from this
+	// variable during generation. The generated code reads the key from
+	// the same variable on first use, unless it is set with SetAssetKey.
+	// Encrypted assets are not available through AssetCompressed.
+	EncryptKeyEnv string
+
+	// HMACKeyEnv names an environment variable holding a hex encoded key
+	// of at least 16 bytes. If set, release builds embed an HMAC of every
+	// asset, computed with this key, and check the contents of an asset
+	// against it whenever it is loaded. A VerifyAssets function checks all
+	// assets at once. The key is embedded as well, so this detects data
+	// which was patched or corrupted in the binary, but not an attacker
+	// who also replaces the key.
+	HMACKeyEnv string
+
+	// Checksums embeds a CRC-32 checksum of every asset in release builds,
+	// and checks the contents of an asset against it whenever it is loaded,
+	// after decompression. Data which was truncated or overwritten in memory
+	// then fails with an error naming the asset, rather than being returned.
+	// A VerifyAssets function checks all assets at once.
+	Checksums bool
+
 	// Perform a debug build. This generates an asset file, which
 	// loads the asset contents directly from disk at their original
 	// location, instead of embedding the contents in the code.
@@ -117,6 +401,248 @@ type Config struct {
 	// in the code. The default behaviour is Release mode.
 	Debug bool
 
+	// FS generates an AssetFS function, which returns the embedded
+	// assets as an fs.FS. The returned value also implements fs.ReadDirFS,
+	// fs.StatFS and fs.GlobFS, so it can be passed directly to http.FS,
+	// template.ParseFS and other consumers of the io/fs interfaces.
+	// This requires Go 1.17 or newer to compile the generated code.
+	FS bool
+
+	// Overlay generates an OverlayFS function, which returns a file
+	// system reading files from a directory on disk first, and falling
+	// back to the embedded assets. Operators can then replace assets
+	// of a deployed binary without rebuilding it. This requires FS.
+	Overlay bool
+
+	// TypedNames generates an AssetName string type, along with a constant
+	// for the name of every asset, like AssetCssAppCss for "css/app.css".
+	// The lookup functions accept this type instead of a plain string, so
+	// misspelled constants are caught by the compiler. String variables
+	// must be converted to AssetName to be passed to them.
+	TypedNames bool
+
+	// RootCAs generates a RootCAs function, which returns an x509.CertPool
+	// holding the certificates of the PEM encoded bundles among the assets,
+	// those with a .pem, .crt or .cer extension holding certificates.
+	// Programs running without the certificates of an operating system,
+	// like those in containers built from scratch, can verify TLS peers
+	// with it. Generation fails if there are no certificates.
+	RootCAs bool
+
+	// TimeZones names the asset holding a time zone database, like the
+	// zoneinfo.zip of Go. The generated code registers it with the time
+	// package, as importing time/tzdata does, so time.LoadLocation works
+	// on systems without /usr/share/zoneinfo. Generation fails if there
+	// is no such asset, or it is not a zip archive.
+	TimeZones string
+
+	// Extract generates an ExtractAll function, which writes all assets
+	// to a directory on disk, named after a hash of their contents, and
+	// returns its path. Once written, the directory is reused by later
+	// calls and runs. This suits helper executables and native libraries,
+	// which must exist as files to be used.
+	Extract bool
+
+	// Accessors generates an exported function for every asset, which
+	// returns its contents, like IndexHTML for "index.html". Call sites
+	// then refer to assets by identifiers the compiler checks. This
+	// cannot be combined with NameSalt, as the names of the functions
+	// would reveal those of the assets.
+	Accessors bool
+
+	// NameSalt, if set, replaces the names of the assets by salted hashes,
+	// so the layout of the asset tree can not be read from a binary. The
+	// names are only available through the constants generated as with
+	// TypedNames, whose values are the hashes. All assets are placed at
+	// the root of the tree. The salt should be kept secret, and must stay
+	// the same for the constants to keep their values.
+	NameSalt string
+
+	// Handler generates an AssetHandler function, which returns an
+	// http.Handler serving the embedded assets. Responses carry a
+	// Content-Type derived from the asset name, a Last-Modified header
+	// taken from the recorded modification time and a strong ETag
+	// computed from the asset contents.
+	Handler bool
+
+	// IndexFallback, along with Handler, makes AssetHandler serve the
+	// named asset, usually "index.html", for request paths matching no
+	// asset, as single page applications route these on the client.
+	// Requests for a directory serve its index.html, if there is one.
+	// Paths starting with one of the prefixes in FallbackExclude, like
+	// "api/", are answered with 404 Not Found instead.
+	IndexFallback   string
+	FallbackExclude []string
+
+	// FallbackAsset names an asset, like "404.html", which Asset and
+	// AssetInfo return for names matching no asset, rather than an error.
+	FallbackAsset string
+
+	// PanicOnMissing makes Asset and AssetInfo panic for names matching
+	// no asset, rather than returning an error. This suits packages whose
+	// assets are all known at compile time, so a missing one is a bug.
+	// It cannot be combined with FallbackAsset.
+	PanicOnMissing bool
+
+	// IgnoreCase makes the lookup functions match names regardless of
+	// case, so "Templates/Index.HTML" finds templates/index.html. An
+	// exact match is preferred. Generation fails if two assets differ
+	// only by case.
+	IgnoreCase bool
+
+	// Precompressed generates an AssetGzip function, which returns the
+	// gzip compressed data of an asset as it is embedded. Along with
+	// Handler, it makes AssetHandler send the compressed data of assets
+	// as they are, to clients accepting their encoding. This only applies
+	// to assets with a Content-Type known from their extension. Such
+	// responses carry no Last-Modified header, but their own ETag.
+	Precompressed bool
+
+	// Digests generates an AssetDigest function and a Digests function,
+	// which return the SHA-256 digests of the assets. In release builds,
+	// these are computed during generation, so nothing is hashed at runtime.
+	Digests bool
+
+	// Metadata generates the GeneratedAt, GeneratorVersion and SourceHash
+	// constants, and an AssetsVersion function, so deployed binaries can
+	// report which snapshot of the assets they embed. SourceHash covers
+	// the names and contents of all assets. GeneratedAt is taken from
+	// ModTime, if set, to keep the output reproducible.
+	Metadata bool
+
+	// Provenance adds a comment to the function of every asset in release
+	// builds, or to its entry with SingleBlob, stating the file it was read
+	// from, its size, the SHA-256 sum of its contents and its modification
+	// time, which is taken from ModTime if set. Reviews of regenerated code
+	// can then attribute every change of the data, and tools can parse
+	// these lines. It cannot be combined with name obfuscation.
+	Provenance bool
+
+	// HeaderTemplate is a text/template executed at the top of every
+	// generated file, before the marker of generated code, like a license
+	// block. It may refer to {{.Package}}, {{.Output}}, {{.Timestamp}},
+	// {{.CommandLine}} and {{.Version}}. The timestamp is taken from
+	// ModTime, if set. The result is written as it is, so it must consist
+	// of comments, and of line comments only if there are build tags.
+	HeaderTemplate string
+
+	// Prologue and Epilogue hold code inserted into the generated file,
+	// following the imports and at the end, respectively. In split mode,
+	// they go into the file holding the table of contents. The init
+	// function of release builds hooking up grate is the first snippet
+	// of the prologue.
+	Prologue []Snippet
+	Epilogue []Snippet
+
+	// TemplateDir names a directory of text/templates replacing those
+	// the code of release builds is generated from, like asset.tmpl for
+	// the function returning an asset. Files are named after the built-in
+	// templates, which WriteCodeTemplates writes as a starting point.
+	// Templates which are not replaced keep their built-in version.
+	TemplateDir string
+
+	// GoVersion is the version of Go the generated code targets, like
+	// 1.21, so it is idiomatic and passes go vet there: from Go 1.16 on,
+	// the functions of os and io replace those of io/ioutil, from 1.17 on
+	// build constraints are written as //go:build lines only, from 1.18 on
+	// any replaces interface{}, and from 1.20 on unsafe.Slice replaces
+	// reflect.SliceHeader. The value "mod" takes the version from the go
+	// directive of the go.mod file governing the output. By default, the
+	// code builds with any version of Go.
+	GoVersion string
+
+	// ContentTypes generates a ContentType function, which returns the
+	// MIME type of an asset. In release builds, the types are determined
+	// during generation, from the file extension, or by sniffing the
+	// contents of files with a missing or unknown extension. AssetHandler
+	// then uses these types as well, so serving assets does not depend on
+	// the MIME types known to the system at runtime.
+	ContentTypes bool
+
+	// Fingerprints generates a HashedName function, which returns the name
+	// of an asset with a hash of its contents inserted, like
+	// "css/app.3f9ab2c1.css", and an UnhashedName function, which maps such
+	// names back. AssetHandler serves the assets under their hashed names
+	// as well, with a Cache-Control header allowing clients to keep them
+	// for good. Templates can so refer to assets by URLs, which change
+	// whenever their contents do.
+	Fingerprints bool
+
+	// Templates generates a ParseTemplates function, which parses the
+	// assets matching a pattern as html/template templates, along with a
+	// ParseTextTemplates function doing the same with text/template.
+	Templates bool
+
+	// Migrations names the directory of the assets holding database
+	// migrations, like "migrations", or "." for the root. If set, a
+	// Migrations function lists the migrations found there, ordered by
+	// version. Their files are named as golang-migrate expects, like
+	// 0001_create_users.up.sql and 0001_create_users.down.sql. The
+	// MigrationNames and MigrationAsset functions serve as a source for
+	// the go_bindata driver of golang-migrate.
+	Migrations string
+
+	// Locales names the directory of the assets holding a directory for
+	// each locale, named after its BCP 47 language tag, like
+	// "locales/en-US" or "locales/de". If set, an AssetLocalized function
+	// looks up an asset for a given locale, falling back to the less
+	// specific tags, like "de" for "de-AT", and then to DefaultLocale.
+	Locales       string
+	DefaultLocale string
+
+	// Register names the package in a Registry, if set. A Register
+	// function then adds the assets to a registry shared by several
+	// generated packages, like those of plugins, which fails if another
+	// package registered an asset of the same name. The import path of
+	// the package makes a good name.
+	Register string
+
+	// SplitOutput writes one file per asset, instead of a single file
+	// holding all of them. The table of contents and all shared code go
+	// into a separate bindata_toc.go. All files belong to the same package.
+	//
+	// In this mode, Output names the directory to write to. If it names
+	// a .go file instead, its parent directory is used.
+	SplitOutput bool
+
+	// Merge keeps the assets of the existing split output, which are
+	// not covered by the inputs, so several runs with different inputs
+	// add up to one package. Assets below the directories searched by
+	// the inputs are regenerated, or removed if they are gone. Only
+	// release builds with SplitOutput support this.
+	Merge bool
+
+	// ModTime, if non-zero, is recorded as the modification time of every
+	// asset, in seconds since the Unix epoch. By default the modification
+	// time of the input file is used, which differs between checkouts of
+	// the same tree. Set this to get reproducible output.
+	ModTime int64
+
+	// GrateImport is the import path of the grate package. The generated
+	// init function assigns to the variables of this package, named by the
+	// last element of the path. NewConfig sets it to "grate". If empty, no
+	// init function is generated and nothing is imported, so the output
+	// does not depend on grate at all.
+	GrateImport string
+
+	// Compat generates a drop-in replacement for the output of the classic
+	// go-bindata tool. Asset, MustAsset, AssetInfo, AssetNames, AssetDir,
+	// RestoreAsset and RestoreAssets keep their exact signatures, which the
+	// generated code asserts, and the grate package is not imported. This
+	// cannot be combined with TypedNames or NameSalt.
+	Compat bool
+
+	// GrateHooks maps generated functions to the variables of the grate
+	// package they are assigned to in the generated init function. For
+	// example, an entry "MustAsset": "MustAsset" results in:
+	//
+	// 	grate.MustAsset = MustAsset
+	//
+	// NewConfig wires Asset, AssetDir and AssetNames. If the map is empty,
+	// no init function is generated and the grate package is not imported.
+	// Hooks only apply to release builds.
+	GrateHooks map[string]string
+
 	// Recursively process all assets in the input directory and its
 	// sub directories. This defaults to false, so only files in the
 	// input directory itself are read.
@@ -128,6 +654,93 @@ type Config struct {
 	//
 	// This parameter can be provided multiple times.
 	Ignore []*regexp.Regexp
+
+	// IgnoreHidden ignores files and directories whose name starts
+	// with a dot, like .DS_Store or .env.
+	IgnoreHidden bool
+
+	// IgnoreVCS ignores the metadata of version control systems, like
+	// .git, .gitignore, .hg and .svn, and the directories dependencies
+	// are installed to, node_modules and bower_components.
+	IgnoreVCS bool
+
+	// Include restricts the assets to files matching any of the given
+	// glob patterns, using the syntax of path.Match. Patterns without a
+	// slash, like "*.css", are matched against the file name. Patterns
+	// with a slash, like "css/*.css", are matched against the slash
+	// separated path relative to the input directory. Directories are
+	// always searched. If empty, all files are included.
+	Include []string
+
+	// Transforms are applied to the contents of every asset in turn,
+	// before it is encoded. Debug builds read the assets from disk,
+	// and are not affected. The incremental cache does not notice
+	// changes to the transforms, and must be removed after them.
+	Transforms []TransformFunc
+
+	// Minify lists the file extensions of assets to minify in release
+	// builds, after the transforms were applied. Supported are .css,
+	// .js, .json, .html and .htm. Minification is conservative: it
+	// removes comments and insignificant whitespace, but does not
+	// rename identifiers or rewrite values.
+	Minify []string
+
+	// PerExtension overrides settings for the assets with the given file
+	// extensions, like ".png" or ".css". Extensions are compared without
+	// regard to case. For instance, images can be embedded uncompressed,
+	// or stylesheets be minified, whatever the settings for all other
+	// assets are. Debug builds are not affected.
+	PerExtension map[string]AssetOptions
+
+	// Bundles defines further sets of assets by name, each with its own
+	// inputs, output, package and other settings. They are generated by
+	// the same call to Translate, after the configuration itself, which
+	// is left out if it has no inputs. Directories read by several
+	// bundles are only listed once. Bundles cannot have bundles of their
+	// own, and all of them need an output path of their own.
+	Bundles map[string]*Config
+
+	// dictionary is the shared dictionary built for the current
+	// assets, if SharedDictionary is set.
+	dictionary []byte
+
+	// codeTemplates holds the code templates, with those
+	// of TemplateDir, once the configuration was validated.
+	codeTemplates *template.Template
+
+	// templatesSum identifies the templates of TemplateDir,
+	// so the incremental cache is not used once they change.
+	templatesSum string
+
+	// goMinor is the minor version of GoVersion, once the
+	// configuration was validated, or 0 if it is not set.
+	goMinor int
+
+	// walked holds the directory listings shared by the bundles
+	// generated in the current run.
+	walked walkCache
+}
+
+// ParseInputPath strips the /... suffix from an input path, which selects
+// the directory and everything below it, like the package patterns of the
+// go tool. It reports whether the input is recursive, which is only the
+// case with the suffix. A path of just ... stands for the current directory.
+func ParseInputPath(path string) (string, bool) {
+	if path == "..." {
+		return ".", true
+	}
+
+	for _, suffix := range []string{"/...", string(filepath.Separator) + "..."} {
+		if strings.HasSuffix(path, suffix) {
+			path = strings.TrimSuffix(path, suffix)
+			if len(path) == 0 {
+				path = "/"
+			}
+			return path, true
+		}
+	}
+
+	return path, false
 }
 
 // NewConfig returns a default configuration struct.
@@ -136,27 +749,239 @@ func NewConfig() *Config {
 	c.Package = "main"
 	c.NoMemCopy = false
 	c.NoCompress = false
+	c.Compression = CompressGzip
 	c.Debug = false
+	c.FS = false
+	c.Handler = false
+	c.Digests = false
+	c.SplitOutput = false
 	c.Recursive = false
 	c.Output = "./bindata.go"
+	c.GrateImport = "grate"
+	c.GrateHooks = map[string]string{
+		"Asset":      "Asset",
+		"AssetDir":   "AssetDir",
+		"AssetNames": "AssetNames",
+	}
 	c.Ignore = make([]*regexp.Regexp, 0)
 	return c
 }
 
 // validate ensures the config has sane values.
 // Part of which means checking if certain file/directory paths exist.
+// The directory of the output is created if needed.
 func (c *Config) validate() error {
+	err := c.validateSettings()
+	if err != nil {
+		return err
+	}
+
+	return c.prepareOutput()
+}
+
+// validateSettings ensures the config has sane values,
+// without touching the output.
+func (c *Config) validateSettings() error {
 	if len(c.Package) == 0 {
 		return fmt.Errorf("Missing package name")
 	}
 
-	for _, input := range c.Input {
+	err := validateCompression(c.compression(), c.CompressionLevel)
+	if err != nil {
+		return err
+	}
+
+	_, err = buildConstraint(c.Tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.parseHeader()
+	if err != nil {
+		return err
+	}
+
+	err = validateSnippets(c)
+	if err != nil {
+		return err
+	}
+
+	c.codeTemplates, c.templatesSum, err = loadCodeTemplates(c.TemplateDir)
+	if err != nil {
+		return err
+	}
+
+	err = validateGoVersion(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateLookupMethod(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateProvenance(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateMerge(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateGrate(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateInclude(c.Include)
+	if err != nil {
+		return err
+	}
+
+	err = validateFormat(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateMinify(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateExtensions(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateCompat(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateParallel(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateLookup(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateDataFile(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateAssembly(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateDictionary(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateEncryption(c)
+	if err != nil {
+		return err
+	}
+
+	err = validateHMAC(c)
+	if err != nil {
+		return err
+	}
+
+	for i := range c.Input {
+		input := &c.Input[i]
+		if isRemote(input.Path) {
+			err := validateRemote(c, input)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		_, err := os.Lstat(input.Path)
 		if err != nil {
 			return fmt.Errorf("Failed to stat input path '%s': %v", input.Path, err)
 		}
+
+		err = validateInclude(input.Include)
+		if err != nil {
+			return err
+		}
+
+		_, err = readIgnoreFile(input.Path)
+		if err != nil {
+			return err
+		}
+
+		err = validateName(input)
+		if err != nil {
+			return err
+		}
+
+		if c.Debug && isArchive(input.Path) {
+			return fmt.Errorf("Archive input '%s' cannot be used in debug builds", input.Path)
+		}
+	}
+
+	err = validatePlatforms(c)
+	if err != nil {
+		return err
+	}
+
+	if c.Accessors && c.obfuscate() {
+		return fmt.Errorf("Accessors cannot be combined with name obfuscation")
+	}
+
+	if c.Overlay && !c.FS {
+		return fmt.Errorf("Overlay file system requires the FS option")
+	}
+
+	if len(c.DefaultLocale) > 0 && len(c.Locales) == 0 {
+		return fmt.Errorf("Default locale requires the Locales option")
 	}
 
+	if len(c.IndexFallback) > 0 && !c.Handler {
+		return fmt.Errorf("Index fallback requires the Handler option")
+	}
+
+	if c.PoolReaders && !c.Debug && (c.compression() != CompressGzip || c.SharedDictionary) {
+		return fmt.Errorf("Reader pooling requires gzip compression without a shared dictionary")
+	}
+
+	if c.CacheSize < 0 {
+		return fmt.Errorf("Cache size must not be negative")
+	}
+
+	if c.CacheSize > 0 && !c.CacheDecompressed {
+		return fmt.Errorf("Cache size requires the CacheDecompressed option")
+	}
+
+	if c.MaxLiteralSize < 0 {
+		return fmt.Errorf("Maximum literal size must not be negative")
+	}
+
+	if c.MaxLiteralSize > 0 && c.SingleBlob && !c.Debug {
+		return fmt.Errorf("Single blob layout cannot be combined with a maximum literal size")
+	}
+
+	if c.SplitOutput && c.SingleBlob && !c.Debug {
+		return fmt.Errorf("Single blob layout cannot be combined with split output")
+	}
+
+	return nil
+}
+
+// prepareOutput defaults the output path if it is empty, and creates
+// the directory it is to be written to.
+func (c *Config) prepareOutput() error {
 	if len(c.Output) == 0 {
 		cwd, err := os.Getwd()
 		if err != nil {
@@ -166,6 +991,15 @@ func (c *Config) validate() error {
 		c.Output = filepath.Join(cwd, "bindata.go")
 	}
 
+	if c.SplitOutput {
+		err := os.MkdirAll(c.splitDir(), 0744)
+		if err != nil {
+			return fmt.Errorf("Create output directory: %v", err)
+		}
+
+		return nil
+	}
+
 	stat, err := os.Lstat(c.Output)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -190,3 +1024,110 @@ func (c *Config) validate() error {
 
 	return nil
 }
+
+// splitDir returns the directory to write to in split mode.
+func (c *Config) splitDir() string {
+	if strings.HasSuffix(c.Output, ".go") {
+		stat, err := os.Stat(c.Output)
+		if err != nil || !stat.IsDir() {
+			return filepath.Dir(c.Output)
+		}
+	}
+
+	return c.Output
+}
+
+// prefix returns the prefix to strip from asset names below the given input.
+func (c *Config) prefix(input *InputConfig) string {
+	if len(input.Prefix) > 0 {
+		return input.Prefix
+	}
+
+	return c.Prefix
+}
+
+// stringData reports whether the asset data is kept in string constants.
+func (c *Config) stringData() bool {
+	return c.NoMemCopy || c.NoUnsafe
+}
+
+// jobs returns the number of assets to encode concurrently.
+func (c *Config) jobs() int {
+	if c.Jobs > 0 {
+		return c.Jobs
+	}
+
+	return runtime.NumCPU()
+}
+
+// lineLength returns the maximum length of lines holding asset data,
+// or zero if they are not limited.
+func (c *Config) lineLength() int {
+	if c.LineLength < 0 {
+		return 0
+	}
+	if c.LineLength == 0 {
+		return 16 * 1024
+	}
+	return c.LineLength
+}
+
+// compression returns the codec to use, taking NoCompress into account.
+func (c *Config) compression() Compression {
+	if c.NoCompress {
+		return CompressNone
+	}
+
+	return c.Compression
+}
+
+// isOutput reports whether the given path is a file written by Translate.
+func (c *Config) isOutput(path string) bool {
+	path, _ = filepath.Abs(path)
+
+	if len(c.ManifestPath) > 0 {
+		manifest, _ := filepath.Abs(c.ManifestPath)
+		if path == manifest {
+			return true
+		}
+	}
+
+	if c.SelfTest {
+		test, _ := filepath.Abs(c.selfTestPath())
+		if path == test {
+			return true
+		}
+	}
+
+	if len(c.DataFile) > 0 {
+		data, _ := filepath.Abs(c.DataFile)
+		if path == data {
+			return true
+		}
+	}
+
+	if c.Assembly {
+		asm, _ := filepath.Abs(c.asmPath())
+		if path == asm {
+			return true
+		}
+	}
+
+	if len(c.IncrementalCache) > 0 {
+		cache, _ := filepath.Abs(c.IncrementalCache)
+		temp, _ := filepath.Abs(c.Output + ".tmp")
+		if path == cache || path == temp {
+			return true
+		}
+	}
+
+	if !c.SplitOutput {
+		output, _ := filepath.Abs(c.Output)
+		return path == output
+	}
+
+	dir, _ := filepath.Abs(c.splitDir())
+	name := filepath.Base(path)
+	return filepath.Dir(path) == dir && (name == splitTOCFile ||
+		strings.HasPrefix(name, "bindata_") && strings.HasSuffix(name, "_asset.go"))
+}
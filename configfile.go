@@ -0,0 +1,533 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// fileConfig holds the settings of a configuration file. Its keys
+// are named after the command line flags where possible.
+type fileConfig struct {
+	Package         string                   `json:"package"`
+	Tags            []string                 `json:"tags"`
+	Inputs          []fileInput              `json:"inputs"`
+	FileList        string                   `json:"filelist"`
+	Output          string                   `json:"output"`
+	Prefix          string                   `json:"prefix"`
+	Rewrites        []fileRewrite            `json:"rewrites"`
+	Recursive       bool                     `json:"recursive"`
+	Ignore          []string                 `json:"ignore"`
+	IgnoreHidden    bool                     `json:"ignorehidden"`
+	IgnoreVCS       bool                     `json:"ignorevcs"`
+	Include         []string                 `json:"include"`
+	Minify          []string                 `json:"minify"`
+	Extensions      map[string]fileExtension `json:"extensions"`
+	NoMemCopy       bool                     `json:"nomemcopy"`
+	NoUnsafe        bool                     `json:"nounsafe"`
+	LineLength      int                      `json:"linelength"`
+	MaxLiteral      int64                    `json:"maxliteral"`
+	Asm             bool                     `json:"asm"`
+	NoCompress      bool                     `json:"nocompress"`
+	Compression     string                   `json:"compression"`
+	Level           int                      `json:"level"`
+	ForceCompress   bool                     `json:"forcecompress"`
+	Dict            bool                     `json:"dict"`
+	Jobs            int                      `json:"jobs"`
+	Parallel        int64                    `json:"parallel"`
+	ParallelWorkers int                      `json:"parallelworkers"`
+	ParallelChunk   int                      `json:"parallelchunk"`
+	Pool            bool                     `json:"pool"`
+	Cache           bool                     `json:"cache"`
+	CacheSize       int64                    `json:"cachesize"`
+	Blob            bool                     `json:"blob"`
+	DataFile        string                   `json:"datafile"`
+	Incremental     string                   `json:"incremental"`
+	Sync            bool                     `json:"sync"`
+	Collisions      string                   `json:"collisions"`
+	Lookup          string                   `json:"lookup"`
+	FuncNames       string                   `json:"funcnames"`
+	Format          bool                     `json:"format"`
+	Manifest        string                   `json:"manifest"`
+	SelfTest        bool                     `json:"selftest"`
+	MaxSize         int64                    `json:"maxsize"`
+	MaxTotal        int64                    `json:"maxtotal"`
+	SizeWarn        bool                     `json:"sizewarn"`
+	Continue        bool                     `json:"continue"`
+	Encrypt         string                   `json:"encrypt"`
+	HMAC            string                   `json:"hmac"`
+	Checksums       bool                     `json:"checksums"`
+	Debug           bool                     `json:"debug"`
+	FS              bool                     `json:"fs"`
+	Overlay         bool                     `json:"overlay"`
+	TypedNames      bool                     `json:"typednames"`
+	Accessors       bool                     `json:"accessors"`
+	Extract         bool                     `json:"extract"`
+	RootCAs         bool                     `json:"rootcas"`
+	TimeZones       string                   `json:"timezones"`
+	Salt            string                   `json:"salt"`
+	Handler         bool                     `json:"handler"`
+	Fallback        string                   `json:"fallback"`
+	FallbackExclude []string                 `json:"fallbackexclude"`
+	FallbackAsset   string                   `json:"fallbackasset"`
+	PanicMissing    bool                     `json:"panicmissing"`
+	IgnoreCase      bool                     `json:"ignorecase"`
+	Precompressed   bool                     `json:"precompressed"`
+	Digests         bool                     `json:"digests"`
+	Metadata        bool                     `json:"metadata"`
+	Provenance      bool                     `json:"provenance"`
+	Header          string                   `json:"header"`
+	TemplateDir     string                   `json:"templatedir"`
+	GoVersion       string                   `json:"goversion"`
+	Prologue        []fileSnippet            `json:"prologue"`
+	Epilogue        []fileSnippet            `json:"epilogue"`
+	ContentTypes    bool                     `json:"contenttypes"`
+	Fingerprints    bool                     `json:"fingerprints"`
+	Templates       bool                     `json:"templates"`
+	Migrations      string                   `json:"migrations"`
+	Locales         string                   `json:"locales"`
+	DefaultLocale   string                   `json:"defaultlocale"`
+	Register        string                   `json:"register"`
+	Split           bool                     `json:"split"`
+	Merge           bool                     `json:"merge"`
+	ModTime         int64                    `json:"modtime"`
+	Grate           string                   `json:"grate"`
+	GrateHooks      map[string]string        `json:"gratehooks"`
+	Compat          bool                     `json:"compat"`
+}
+
+// fileExtension holds the settings for a file extension
+// in a configuration file.
+type fileExtension struct {
+	NoCompress    bool `json:"nocompress"`
+	ForceCompress bool `json:"forcecompress"`
+	NoMemCopy     bool `json:"nomemcopy"`
+	Minify        bool `json:"minify"`
+}
+
+// fileRewrite holds a rewrite of asset names in a configuration file.
+type fileRewrite struct {
+	Pattern string `json:"pattern"`
+	Replace string `json:"replace"`
+}
+
+// fileSnippet holds a snippet of code in a configuration file.
+// It is given either as the code, or as a table of settings.
+type fileSnippet struct {
+	Code     string   `json:"code"`
+	Imports  []string `json:"imports"`
+	Template bool     `json:"template"`
+}
+
+func (s *fileSnippet) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &s.Code)
+	}
+
+	type snippet fileSnippet
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode((*snippet)(s))
+}
+
+// snippets converts the snippets of a configuration file. Import
+// declarations at the start of the code are moved to the imports.
+func snippets(list []fileSnippet) ([]Snippet, error) {
+	var result []Snippet
+	for _, fs := range list {
+		s, err := ParseSnippet(fs.Code)
+		if err != nil {
+			return nil, fmt.Errorf("invalid snippet: %v", err)
+		}
+
+		s.Imports = append(s.Imports, fs.Imports...)
+		s.Template = fs.Template
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+// fileInput holds the settings of an input in a configuration file.
+// It is given either as a path, or as a table of settings.
+type fileInput struct {
+	Path      string   `json:"path"`
+	Recursive *bool    `json:"recursive"`
+	Prefix    string   `json:"prefix"`
+	Ignore    []string `json:"ignore"`
+	Include   []string `json:"include"`
+	SHA256    string   `json:"sha256"`
+	Platforms []string `json:"platforms"`
+	Name      string   `json:"name"`
+}
+
+func (in *fileInput) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &in.Path)
+	}
+
+	type input fileInput
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode((*input)(in))
+}
+
+// NewConfigFromFile returns a configuration holding the settings of
+// the named YAML or TOML file, which is told apart by its extension:
+// .yaml, .yml or .toml. Settings missing from the file keep the values
+// set by NewConfig. Paths are used as they are given, relative to the
+// working directory.
+//
+// Keys are named after the command line flags, like "pkg" being
+// "package", and "o" being "output". Inputs are listed under "inputs",
+// either as plain paths or as tables with the keys "path", "recursive",
+// "prefix", "ignore", "include", "sha256", "platforms" and "name". The
+// files of a list read by ReadFileList follow them, if "filelist" names
+// one. Settings for file extensions are listed under "extensions", as
+// tables with the keys "nocompress", "forcecompress", "nomemcopy" and
+// "minify". Rewrites of asset names are listed under "rewrites", as
+// tables with the keys "pattern" and "replace". Snippets of code are
+// listed under "prologue" and "epilogue", either as the code or as
+// tables with the keys "code", "imports" and "template". For example:
+//
+//	package: assets
+//	output: assets/bindata.go
+//	compression: zstd
+//	tags: [embed]
+//	recursive: true
+//	inputs:
+//	  - web/static
+//	  - path: configs/defaults
+//	    prefix: configs
+//	    ignore: ['\.orig$']
+//	extensions:
+//	  .png:
+//	    nocompress: true
+//	  .css:
+//	    minify: true
+//
+// Several bundles, each with its own inputs and output, are listed under
+// "bundles" by name. They share the settings of the file, which they can
+// override in turn:
+//
+//	compression: zstd
+//	bundles:
+//	  web:
+//	    package: web
+//	    output: web/bindata.go
+//	    inputs: [web/static]
+//	  db:
+//	    package: db
+//	    output: db/bindata.go
+//	    prefix: db
+//	    migrations: migrations
+//	    inputs: [db/migrations]
+//
+// Transforms and FuncNameFor cannot be configured in files.
+func NewConfigFromFile(name string) (*Config, error) {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc interface{}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		doc, err = parseYAML(data)
+	case ".toml":
+		doc, err = parseTOML(data)
+	default:
+		return nil, fmt.Errorf("Unsupported config file %s, want .yaml, .yml or .toml", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Invalid config file %s: %v", name, err)
+	}
+
+	c := NewConfig()
+	err = c.applyFile(doc)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid config file %s: %v", name, err)
+	}
+
+	return c, nil
+}
+
+// applyFile applies the settings of the decoded configuration file.
+func (c *Config) applyFile(doc interface{}) error {
+	table, ok := doc.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("expected a table of settings")
+	}
+
+	// Bundles are configured by tables of settings of their own.
+	settings := make(map[string]interface{}, len(table))
+	for key, value := range table {
+		if key != "bundles" {
+			settings[key] = value
+		}
+	}
+
+	// The decoded document is mapped to the settings through JSON,
+	// which rejects unknown keys and values of the wrong type.
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	f := fileConfig{
+		Package:     c.Package,
+		Output:      c.Output,
+		Compression: c.Compression.String(),
+		Collisions:  c.Collisions.String(),
+		Lookup:      c.Lookup.String(),
+		FuncNames:   c.FuncNaming.String(),
+		Grate:       c.GrateImport,
+		GrateHooks:  c.GrateHooks,
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	err = dec.Decode(&f)
+	if err != nil {
+		return fmt.Errorf("%s", strings.TrimPrefix(err.Error(), "json: "))
+	}
+
+	c.Compression, err = ParseCompression(f.Compression)
+	if err != nil {
+		return err
+	}
+
+	c.Collisions, err = ParseCollision(f.Collisions)
+	if err != nil {
+		return err
+	}
+
+	c.Lookup, err = ParseLookup(f.Lookup)
+	if err != nil {
+		return err
+	}
+
+	c.FuncNaming, err = ParseFuncNaming(f.FuncNames)
+	if err != nil {
+		return err
+	}
+
+	c.Ignore, err = compileIgnore(f.Ignore)
+	if err != nil {
+		return err
+	}
+
+	c.IgnoreHidden = f.IgnoreHidden
+	c.IgnoreVCS = f.IgnoreVCS
+	c.Package = f.Package
+	c.Tags = f.Tags
+	c.Output = f.Output
+	c.Prefix = f.Prefix
+	c.Recursive = f.Recursive
+	c.Include = f.Include
+	c.Minify = f.Minify
+	c.PerExtension = nil
+	for ext, e := range f.Extensions {
+		if c.PerExtension == nil {
+			c.PerExtension = make(map[string]AssetOptions)
+		}
+		c.PerExtension[ext] = AssetOptions{
+			NoCompress:    e.NoCompress,
+			ForceCompress: e.ForceCompress,
+			NoMemCopy:     e.NoMemCopy,
+			Minify:        e.Minify,
+		}
+	}
+	c.NoMemCopy = f.NoMemCopy
+	c.NoUnsafe = f.NoUnsafe
+	c.LineLength = f.LineLength
+	c.MaxLiteralSize = f.MaxLiteral
+	c.Assembly = f.Asm
+	c.NoCompress = f.NoCompress
+	c.CompressionLevel = f.Level
+	c.ForceCompress = f.ForceCompress
+	c.SharedDictionary = f.Dict
+	c.Jobs = f.Jobs
+	c.ParallelSize = f.Parallel
+	c.ParallelWorkers = f.ParallelWorkers
+	c.ParallelChunkSize = f.ParallelChunk
+	c.PoolReaders = f.Pool
+	c.CacheDecompressed = f.Cache
+	c.CacheSize = f.CacheSize
+	c.SingleBlob = f.Blob
+	c.DataFile = f.DataFile
+	c.IncrementalCache = f.Incremental
+	c.SyncOutput = f.Sync
+	c.Format = f.Format
+	c.ManifestPath = f.Manifest
+	c.SelfTest = f.SelfTest
+	c.MaxAssetSize = f.MaxSize
+	c.MaxTotalSize = f.MaxTotal
+	c.SizeLimitWarn = f.SizeWarn
+	c.ContinueOnError = f.Continue
+	c.EncryptKeyEnv = f.Encrypt
+	c.HMACKeyEnv = f.HMAC
+	c.Checksums = f.Checksums
+	c.Debug = f.Debug
+	c.FS = f.FS
+	c.Overlay = f.Overlay
+	c.TypedNames = f.TypedNames
+	c.Accessors = f.Accessors
+	c.Extract = f.Extract
+	c.RootCAs = f.RootCAs
+	c.TimeZones = f.TimeZones
+	c.NameSalt = f.Salt
+	c.Handler = f.Handler
+	c.IndexFallback = f.Fallback
+	c.FallbackExclude = f.FallbackExclude
+	c.FallbackAsset = f.FallbackAsset
+	c.PanicOnMissing = f.PanicMissing
+	c.IgnoreCase = f.IgnoreCase
+	c.Precompressed = f.Precompressed
+	c.Digests = f.Digests
+	c.Metadata = f.Metadata
+	c.Provenance = f.Provenance
+	c.HeaderTemplate = f.Header
+	c.TemplateDir = f.TemplateDir
+	c.GoVersion = f.GoVersion
+	c.ContentTypes = f.ContentTypes
+	c.Fingerprints = f.Fingerprints
+	c.Templates = f.Templates
+	c.Migrations = f.Migrations
+	c.Locales = f.Locales
+	c.DefaultLocale = f.DefaultLocale
+	c.Register = f.Register
+	c.SplitOutput = f.Split
+	c.Merge = f.Merge
+	c.ModTime = f.ModTime
+	c.GrateImport = f.Grate
+	c.GrateHooks = f.GrateHooks
+	c.Compat = f.Compat
+
+	for _, r := range f.Rewrites {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid rewrite pattern %q: %v", r.Pattern, err)
+		}
+		c.Rewrites = append(c.Rewrites, Rewrite{Pattern: re, Replace: r.Replace})
+	}
+
+	c.Prologue, err = snippets(f.Prologue)
+	if err != nil {
+		return err
+	}
+
+	c.Epilogue, err = snippets(f.Epilogue)
+	if err != nil {
+		return err
+	}
+
+	for _, in := range f.Inputs {
+		if len(in.Path) == 0 {
+			return fmt.Errorf("input without a path")
+		}
+
+		path, recursive := ParseInputPath(in.Path)
+		input := InputConfig{
+			Path:      path,
+			Recursive: c.Recursive && len(in.Name) == 0 || recursive,
+			Prefix:    in.Prefix,
+			Include:   in.Include,
+			SHA256:    in.SHA256,
+			Platforms: in.Platforms,
+			Name:      in.Name,
+		}
+		if in.Recursive != nil {
+			if recursive && !*in.Recursive {
+				return fmt.Errorf("input %s is not recursive", in.Path)
+			}
+			input.Recursive = *in.Recursive
+		}
+
+		input.Ignore, err = compileIgnore(in.Ignore)
+		if err != nil {
+			return err
+		}
+
+		c.Input = append(c.Input, input)
+	}
+
+	if len(f.FileList) > 0 {
+		inputs, err := readFileList(f.FileList)
+		if err != nil {
+			return err
+		}
+		c.Input = append(c.Input, inputs...)
+	}
+
+	return c.applyBundles(table["bundles"], settings)
+}
+
+// applyBundles applies the settings of the bundles in a configuration
+// file. Each bundle starts out with the settings of the file, except
+// for its inputs, which are overridden by those of its own table.
+func (c *Config) applyBundles(doc interface{}, settings map[string]interface{}) error {
+	c.Bundles = nil
+	if doc == nil {
+		return nil
+	}
+
+	bundles, ok := doc.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("bundles: expected a table of bundles")
+	}
+
+	for name, value := range bundles {
+		table, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("bundle %s: expected a table of settings", name)
+		}
+
+		if _, ok := table["bundles"]; ok {
+			return fmt.Errorf("bundle %s: bundles cannot have bundles of their own", name)
+		}
+
+		merged := make(map[string]interface{}, len(settings)+len(table))
+		for key, value := range settings {
+			if key != "inputs" && key != "filelist" {
+				merged[key] = value
+			}
+		}
+		for key, value := range table {
+			merged[key] = value
+		}
+
+		b := NewConfig()
+		err := b.applyFile(merged)
+		if err != nil {
+			return fmt.Errorf("bundle %s: %v", name, err)
+		}
+
+		if c.Bundles == nil {
+			c.Bundles = make(map[string]*Config)
+		}
+		c.Bundles[name] = b
+	}
+
+	return nil
+}
+
+// compileIgnore compiles the given ignore patterns.
+func compileIgnore(patterns []string) ([]*regexp.Regexp, error) {
+	list := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %v", pattern, err)
+		}
+		list = append(list, re)
+	}
+	return list, nil
+}
@@ -0,0 +1,106 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+)
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+// contentType returns the MIME type of the given asset. It is derived
+// from the extension of the file, or sniffed from the start of its
+// contents if the extension is missing or unknown.
+func contentType(asset *Asset) (string, error) {
+	if ctype := mime.TypeByExtension(filepath.Ext(asset.Path)); len(ctype) > 0 {
+		return ctype, nil
+	}
+
+	fd, err := asset.open()
+	if err != nil {
+		return "", err
+	}
+
+	defer fd.Close()
+
+	buf := make([]byte, sniffLen)
+	n, err := io.ReadFull(fd, buf)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("Read %s: %v", asset.origin(), err)
+	}
+
+	return http.DetectContentType(buf[:n]), nil
+}
+
+// writeContentTypes writes the ContentType function. Release builds
+// embed the types determined during generation, debug builds determine
+// them when asked.
+func writeContentTypes(w io.Writer, c *Config, toc []Asset) error {
+	if c.Debug {
+		return writeDebugContentTypes(w, c)
+	}
+
+	_, err := fmt.Fprintf(w, `// ContentType returns the MIME type of the asset with the given name, as
+// determined during generation from its extension, or from its contents
+// if the extension is missing or unknown. It returns an empty string if
+// the asset does not exist.
+func ContentType(name %s) string {
+	cannonicalName := bindata_clean(%s)
+	return _bindata_types[cannonicalName]
+}
+
+// _bindata_types holds the MIME type of each asset, mapped to its name.
+var _bindata_types = map[string]string{
+`, c.nameType(), c.stringArg("name"))
+	if err != nil {
+		return err
+	}
+
+	for i := range toc {
+		ctype, err := contentType(&toc[i])
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "\t%q: %q,\n", toc[i].Name, ctype)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
+
+// writeDebugContentTypes writes a ContentType function,
+// which reads the assets from disk if needed.
+func writeDebugContentTypes(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// ContentType returns the MIME type of the asset with the given name,
+// determined from its extension, or from its contents if the extension
+// is missing or unknown. It returns an empty string if the asset does
+// not exist. In debug builds, the asset may be read from disk.
+func ContentType(name %s) string {
+	if ctype := mime.TypeByExtension(path.Ext(%s)); ctype != "" {
+		if _, err := AssetInfo(name); err == nil {
+			return ctype
+		}
+		return ""
+	}
+
+	data, err := AssetUnsafe(name)
+	if err != nil {
+		return ""
+	}
+	return http.DetectContentType(data)
+}
+
+`, c.nameType(), c.stringArg("name"))
+	return err
+}
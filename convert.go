@@ -7,11 +7,14 @@ package bindata
 import (
 	"bufio"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -19,71 +22,692 @@ import (
 // to Go code and writes new files to the output specified
 // in the given configuration.
 func Translate(c *Config) error {
-	var toc []Asset
+	_, err := TranslateStats(c)
+	return err
+}
+
+// TranslateStats is like Translate, but also returns
+// statistics about the assets which were written. With
+// ContinueOnError, they are returned along with AssetErrors
+// if some assets could not be read.
+func TranslateStats(c *Config) (*Stats, error) {
+	if len(c.Bundles) > 0 {
+		return translateBundles(c)
+	}
+
+	start := time.Now()
 
 	// Ensure our configuration has sane values.
 	err := c.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Locate all the assets. Those which cannot be read are
+	// left out with ContinueOnError, and reported at the end.
+	toc, dirs, cleanup, err := findAssets(c)
+	defer cleanup()
+	failed, _ := err.(AssetErrors)
+	if err != nil && failed == nil {
+		return nil, err
+	}
+
+	for _, f := range failed {
+		c.event(Event{Kind: EventFailure, Err: f})
+	}
+	c.event(Event{Kind: EventFound, Total: len(toc)})
+
+	// Assets of earlier runs are kept, unless the inputs cover them.
+	if c.Merge {
+		toc, err = mergeAssets(c, toc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Identical assets share their data. Platform specific
+	// outputs look for them on their own.
+	if c.dedupe() && len(c.platforms()) == 0 {
+		err = findDuplicates(toc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	warnings, err := checkSizes(c, toc)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, warning := range warnings {
+		c.event(Event{Kind: EventWarning, Message: warning})
+	}
+
+	err = trainDictionary(c, toc)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.SplitOutput {
+		err = writeSplit(c, toc, dirs)
+	} else if len(c.platforms()) > 0 {
+		err = writePlatforms(c, toc, dirs)
+	} else {
+		err = writeOutput(c, toc, dirs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Write the self test, if applicable.
+	if c.SelfTest {
+		err = writeSelfTest(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Write the manifest, if applicable.
+	if len(c.ManifestPath) > 0 {
+		err = writeManifest(c, toc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stats := newStats(toc)
+	stats.Duration = time.Since(start)
+	stats.Warnings = warnings
+	stats.Failures = failed
+	c.event(Event{Kind: EventDone, Total: len(toc), Stats: stats})
+	if len(failed) > 0 {
+		return stats, failed
+	}
+	return stats, nil
+}
+
+// writeOutput writes all assets into the single configured output file.
+func writeOutput(c *Config, toc, dirs []Asset) error {
+	inc, err := openIncremental(c)
 	if err != nil {
 		return err
 	}
 
-	var knownFuncs = make(map[string]int)
-	// Locate all the assets.
-	for _, input := range c.Input {
-		err = findFiles(input.Path, c.Prefix, input.Recursive, &toc, c.Ignore, knownFuncs)
+	output := c.Output
+	if inc != nil {
+		output = inc.tempName()
+	}
+
+	err = writeSource(c, output, func(w io.Writer) error {
+		if inc != nil {
+			w = inc.wrap(w)
+		}
+
+		return writeCode(w, c, toc, dirs, inc)
+	})
+
+	if inc != nil {
+		return inc.finish(err)
+	}
+
+	return err
+}
+
+// writeCode writes the complete code for the given assets and
+// directories to w.
+func writeCode(w io.Writer, c *Config, toc, dirs []Asset, inc *incremental) error {
+	err := writeHeader(w, c)
+	if err != nil {
+		return err
+	}
+
+	// Write imports.
+	err = writeImports(w, c)
+	if err != nil {
+		return err
+	}
+
+	// Write the code to insert before the assets.
+	err = writeSnippets(w, c, toc, c.prologue())
+	if err != nil {
+		return err
+	}
+
+	// Write assets.
+	if c.Debug {
+		err = writeDebug(w, c, toc)
+		if err == nil && c.Assembly {
+			err = writeAssembly(c, nil)
+		}
+	} else {
+		err = writeRelease(w, c, toc, inc)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return writeAPI(w, c, toc, dirs)
+}
+
+// TranslateTo writes the code for the given assets to w, instead of the
+// configured output. The inputs of the configuration are not used, so
+// the assets can be chosen freely. Each of them needs a Path and a Name.
+// If Func is empty, it is derived from the name. AssetsFromFS and
+// AssetsFromMap return assets which are not read from disk. The code is written as
+// a single file; Debug, SplitOutput and IncrementalCache are not
+// supported.
+func TranslateTo(w io.Writer, c *Config, toc []Asset) error {
+	err := c.validate()
+	if err != nil {
+		return err
+	}
+
+	if c.SplitOutput {
+		return fmt.Errorf("Split output cannot be written to a single writer")
+	}
+
+	if len(c.platforms()) > 0 {
+		return fmt.Errorf("Platform specific inputs cannot be written to a single writer")
+	}
+
+	if c.SelfTest {
+		return fmt.Errorf("Self tests cannot be written to a single writer")
+	}
+
+	if c.Assembly {
+		return fmt.Errorf("Assembly output cannot be written to a single writer")
+	}
+
+	// Debug builds locate the assets in the inputs at runtime.
+	if c.Debug {
+		return fmt.Errorf("Debug builds cannot be written for a list of assets")
+	}
+
+	// Work on a copy, as the assets are sorted and modified.
+	toc = append([]Asset(nil), toc...)
+
+	knownFuncs := make(map[string]int)
+	for i := range toc {
+		asset := &toc[i]
+		asset.Name = assetName(asset.Name)
+		if len(asset.Name) == 0 {
+			return fmt.Errorf("Missing name of asset %s", asset.Path)
+		}
+		if len(asset.Func) == 0 {
+			asset.Func = safeFunctionName(asset.Name, knownFuncs)
+		}
+	}
+
+	toc, dir, err := prepareAssets(c, toc)
+	if len(dir) > 0 {
+		defer os.RemoveAll(dir)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.dedupe() {
+		err = findDuplicates(toc)
 		if err != nil {
 			return err
 		}
 	}
 
-	// Create output file.
-	fd, err := os.Create(c.Output)
+	warnings, err := checkSizes(c, toc)
+	if err != nil {
+		return err
+	}
+
+	c.event(Event{Kind: EventFound, Total: len(toc)})
+	for _, warning := range warnings {
+		c.event(Event{Kind: EventWarning, Message: warning})
+	}
+
+	err = trainDictionary(c, toc)
+	if err != nil {
+		return err
+	}
+
+	fn := func(w io.Writer) error {
+		return writeCode(w, c, toc, nil, nil)
+	}
+
+	if c.Format {
+		src, err := formatSource("output", fn)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(src)
+	} else {
+		// Create a buffered writer for better performance.
+		bw := bufio.NewWriter(w)
+		err = fn(bw)
+		if err == nil {
+			err = bw.Flush()
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	c.event(Event{Kind: EventDone, Total: len(toc), Stats: newStats(toc)})
+	return nil
+}
+
+// findAssets locates all assets in the configured inputs.
+// They are sorted by name. The directories found below directory
+// inputs are returned as well, so empty ones can be recorded. With
+// ContinueOnError, the files and directories which cannot be read
+// are returned as AssetErrors, along with the remaining assets.
+//
+// The returned function removes the temporary directories archive and
+// remote inputs were unpacked into, and transformed assets written to.
+// It must be called once the assets have been read, even if an error
+// is returned.
+func findAssets(c *Config) ([]Asset, []Asset, func(), error) {
+	var toc, found []Asset
+	var dirs []string
+
+	cleanup := func() {
+		for _, dir := range dirs {
+			os.RemoveAll(dir)
+		}
+	}
+
+	// With ContinueOnError, files and directories which cannot be
+	// read are recorded, and returned as the error once all inputs
+	// were searched.
+	var failed AssetErrors
+
+	var knownFuncs = make(map[string]int)
+	for i := range c.Input {
+		input := &c.Input[i]
+		first, firstDir := len(toc), len(found)
+
+		var err error
+		var dir string
+		if isRemote(input.Path) {
+			dir, err = findRemoteFiles(c, input, &toc, knownFuncs)
+		} else if isArchive(input.Path) {
+			dir, err = findArchiveFiles(c, input, &toc, knownFuncs)
+		} else if len(input.Name) > 0 {
+			err = findFile(c, input, &toc, knownFuncs)
+		} else {
+			filter := newFilter(c, input)
+			if c.ContinueOnError {
+				filter.failures = &failed
+			}
+
+			err = findFiles(input.Path, c.prefix(input), input.Recursive, &toc, filter, knownFuncs)
+			if err == nil && input.Recursive {
+				err = findDirs(input.Path, c.prefix(input), &found, filter)
+			}
+		}
+		if len(dir) > 0 {
+			dirs = append(dirs, dir)
+		}
+		if err != nil {
+			return nil, nil, cleanup, err
+		}
+
+		for j := first; j < len(toc); j++ {
+			toc[j].platforms = input.Platforms
+		}
+		for j := firstDir; j < len(found); j++ {
+			found[j].platforms = input.Platforms
+		}
+	}
+
+	if c.ContinueOnError {
+		toc = readableAssets(toc, &failed)
+	}
+
+	found, err := rewriteNames(c, toc, found, knownFuncs)
+	if err != nil {
+		return nil, nil, cleanup, err
+	}
+
+	toc, dir, err := prepareAssets(c, toc)
+	if len(dir) > 0 {
+		dirs = append(dirs, dir)
+	}
+	if err != nil {
+		return nil, nil, cleanup, err
+	}
+
+	// Directory names would reveal the obfuscated asset names.
+	if c.obfuscate() {
+		found = nil
+	}
+
+	if len(failed) > 0 {
+		return toc, found, cleanup, failed
+	}
+
+	return toc, found, cleanup, nil
+}
+
+// prepareAssets sorts the assets by name, resolves name collisions, and
+// applies the transforms and name obfuscation. It returns the remaining
+// assets, and the temporary directory transformed assets were written
+// to, if any, which must be removed by the caller.
+func prepareAssets(c *Config, toc []Asset) ([]Asset, string, error) {
+	// Sort to make output stable, regardless of the order of the inputs.
+	sort.Stable(assetsByName(toc))
+
+	toc, err := resolveCollisions(c, toc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if c.customFuncs() {
+		err = nameFuncs(c, toc)
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
+		uniqueFuncs(toc)
+	}
+
+	var dir string
+	if len(c.transforms()) > 0 && !c.Debug {
+		dir, err = transformAssets(c, toc)
+		if err != nil {
+			return nil, dir, err
+		}
+	}
+
+	if c.obfuscate() {
+		err = obfuscateNames(c, toc)
+		if err != nil {
+			return nil, dir, err
+		}
+	}
+
+	return toc, dir, nil
+}
+
+// writeFile creates the named file and passes a buffered writer for it
+// to the given function. The data is written to a temporary file in the
+// same directory, which only replaces the named file once the function
+// succeeded, so a failed run never leaves a partially written file.
+// With SyncOutput, the data is flushed to disk before that.
+func writeFile(c *Config, name string, fn func(w io.Writer) error) error {
+	fd, err := ioutil.TempFile(filepath.Dir(name), "."+filepath.Base(name)+".tmp")
 	if err != nil {
 		return err
 	}
 
+	temp := fd.Name()
+	defer os.Remove(temp)
 	defer fd.Close()
 
 	// Create a buffered writer for better performance.
 	bfd := bufio.NewWriter(fd)
-	defer bfd.Flush()
 
-	// Write build tags, if applicable.
-	if len(c.Tags) > 0 {
-		_, err = fmt.Fprintf(bfd, "// +build %s\n\n", c.Tags)
+	err = fn(bfd)
+	if err != nil {
+		return err
+	}
+
+	err = bfd.Flush()
+	if err != nil {
+		return err
+	}
+
+	// Keep the mode of an existing file, as temporary
+	// files are only accessible by their owner.
+	mode := os.FileMode(0644)
+	if fi, err := os.Stat(name); err == nil {
+		mode = fi.Mode().Perm()
+	}
+
+	err = fd.Chmod(mode)
+	if err != nil {
+		return err
+	}
+
+	if c.SyncOutput {
+		err = fd.Sync()
 		if err != nil {
 			return err
 		}
 	}
 
-	// Write package declaration.
-	_, err = fmt.Fprintf(bfd, "package %s\n\n", c.Package)
+	err = fd.Close()
 	if err != nil {
 		return err
 	}
 
-	// Write assets.
-	if c.Debug {
-		err = writeDebug(bfd, toc)
-	} else {
-		err = writeRelease(bfd, c, toc)
+	err = os.Rename(temp, name)
+	if err != nil || !c.SyncOutput {
+		return err
+	}
+
+	return syncDir(filepath.Dir(name))
+}
+
+// syncDir flushes the entries of the named directory to disk, so a
+// renamed file persists. Platforms which do not support this for
+// directories are ignored.
+func syncDir(name string) error {
+	d, err := os.Open(name)
+	if err != nil {
+		return err
 	}
 
+	defer d.Close()
+
+	d.Sync()
+	return nil
+}
+
+// writeHeader writes the custom header, the marker of generated
+// code, the build tags and the package declaration.
+func writeHeader(w io.Writer, c *Config) error {
+	// Write the custom header and the marker of generated code.
+	err := writeFileHeader(w, c)
 	if err != nil {
 		return err
 	}
 
+	// Write build tags, if applicable.
+	if len(c.Tags) > 0 {
+		err = writeBuildConstraint(w, c.Tags, !c.goAtLeast(17))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write package declaration.
+	_, err = fmt.Fprintf(w, "package %s\n\n", c.Package)
+	return err
+}
+
+// writeAPI writes the table of contents and all functions
+// operating on it.
+func writeAPI(w io.Writer, c *Config, toc, dirs []Asset) error {
 	// Write table of contents
-	if err := writeTOC(bfd, toc); err != nil {
+	if err := writeTOC(w, c, toc); err != nil {
+		return err
+	}
+	// Write the number and total size of the assets
+	if err := writeInventory(w, c, toc); err != nil {
 		return err
 	}
 	// Write hierarchical tree of assets
-	if err := writeTOCTree(bfd, toc); err != nil {
+	if err := writeTOCTree(w, toc, dirs); err != nil {
 		return err
 	}
 
+	// Write tree traversal
+	if err := writeWalk(w, c); err != nil {
+		return err
+	}
+	if err := writeAssetTree(w, c, toc); err != nil {
+		return err
+	}
+
+	// Write accessor for compressed data
+	if err := writeAssetCompressed(w, c, toc); err != nil {
+		return err
+	}
+	if c.Precompressed {
+		if err := writeAssetGzip(w, c); err != nil {
+			return err
+		}
+	}
+
 	// Write restore procedure
-	return writeRestore(bfd)
+	if err := writeRestore(w, c); err != nil {
+		return err
+	}
+
+	// Write the compatibility assertions, if applicable.
+	if c.Compat {
+		if err := writeCompat(w); err != nil {
+			return err
+		}
+	}
+
+	// Write file system implementation, if applicable.
+	if c.FS {
+		if err := writeFS(w); err != nil {
+			return err
+		}
+	}
+	if c.Overlay {
+		if err := writeOverlay(w); err != nil {
+			return err
+		}
+	}
+
+	// Write accessors, if applicable.
+	if c.Accessors {
+		if err := writeAccessors(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write content types, if applicable.
+	if c.ContentTypes {
+		if err := writeContentTypes(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write fingerprinted names, if applicable.
+	if c.Fingerprints {
+		if err := writeFingerprints(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write template helpers, if applicable.
+	if c.Templates {
+		if err := writeTemplates(w, c); err != nil {
+			return err
+		}
+	}
+
+	// Write migrations, if applicable.
+	if len(c.Migrations) > 0 {
+		if err := writeMigrations(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write localized lookup, if applicable.
+	if len(c.Locales) > 0 {
+		if err := writeLocales(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write certificate pool, if applicable.
+	if c.RootCAs {
+		if err := writeRootCAs(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write time zone registration, if applicable.
+	if len(c.TimeZones) > 0 {
+		if err := writeTimeZones(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write extraction, if applicable.
+	if c.Extract {
+		if err := writeExtract(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write registration, if applicable.
+	if len(c.Register) > 0 {
+		if err := writeRegister(w, c); err != nil {
+			return err
+		}
+	}
+
+	// Write http handler, if applicable.
+	if c.Handler {
+		if err := writeHandler(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write cache control, if applicable.
+	if c.CacheDecompressed {
+		if err := writeFlushCache(w, c, toc); err != nil {
+			return err
+		}
+		if err := writeCacheSize(w, c); err != nil {
+			return err
+		}
+	}
+
+	// Write data file loader, if applicable.
+	if len(c.DataFile) > 0 {
+		if err := writeDataLoader(w, c); err != nil {
+			return err
+		}
+	}
+
+	// Write verification, if applicable.
+	if c.authenticate() || c.Checksums {
+		if err := writeVerifyAssets(w, c); err != nil {
+			return err
+		}
+	}
+
+	// Write digest table, if applicable.
+	if c.Digests {
+		if err := writeDigests(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write generation metadata, if applicable.
+	if c.Metadata {
+		if err := writeMetadata(w, c, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write the sums of the source files, for Outdated.
+	if !c.Debug {
+		if err := writeSources(w, toc); err != nil {
+			return err
+		}
+	}
+
+	// Write the code to insert after everything else.
+	return writeSnippets(w, c, toc, c.Epilogue)
 }
 
 // Implement sort.Interface for []os.FileInfo based on Name()
@@ -93,15 +717,18 @@ func (v ByName) Len() int           { return len(v) }
 func (v ByName) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
 func (v ByName) Less(i, j int) bool { return v[i].Name() < v[j].Name() }
 
+// Implement sort.Interface for []Asset based on Name
+type assetsByName []Asset
+
+func (v assetsByName) Len() int           { return len(v) }
+func (v assetsByName) Swap(i, j int)      { v[i], v[j] = v[j], v[i] }
+func (v assetsByName) Less(i, j int) bool { return v[i].Name < v[j].Name }
+
 // findFiles recursively finds all the file paths in the given directory tree.
 // They are added to the given map as keys. Values will be safe function names
 // for each file, which will be used when generating the output code.
-func findFiles(dir, prefix string, recursive bool, toc *[]Asset, ignore []*regexp.Regexp, knownFuncs map[string]int) error {
-	if len(prefix) > 0 {
-		dir, _ = filepath.Abs(dir)
-		prefix, _ = filepath.Abs(prefix)
-		prefix = filepath.ToSlash(prefix)
-	}
+func findFiles(dir, prefix string, recursive bool, toc *[]Asset, filter *fileFilter, knownFuncs map[string]int) error {
+	dir, prefix = resolvePrefix(dir, prefix)
 
 	fi, err := os.Stat(dir)
 	if err != nil {
@@ -111,23 +738,19 @@ func findFiles(dir, prefix string, recursive bool, toc *[]Asset, ignore []*regex
 	var list []os.FileInfo
 
 	if !fi.IsDir() {
-		dir = ""
+		// A single file keeps its directory, so it is
+		// named by its path, like files below directories.
+		dir = filepath.Dir(dir)
 		list = []os.FileInfo{fi}
 	} else {
-		fd, err := os.Open(dir)
-		if err != nil {
-			return err
+		if filter.root == "" {
+			filter.root = dir
 		}
 
-		defer fd.Close()
-
-		list, err = fd.Readdir(0)
+		list, err = filter.readDir(dir)
 		if err != nil {
 			return err
 		}
-
-		// Sort to make output stable between invocations
-		sort.Sort(ByName(list))
 	}
 
 	for _, file := range list {
@@ -135,24 +758,24 @@ func findFiles(dir, prefix string, recursive bool, toc *[]Asset, ignore []*regex
 		asset.Path = filepath.Join(dir, file.Name())
 		asset.Name = filepath.ToSlash(asset.Path)
 
-		ignoring := false
-		for _, re := range ignore {
-			if re.MatchString(asset.Path) {
-				ignoring = true
-				break
-			}
-		}
-		if ignoring {
+		if filter.ignored(asset.Path, file.IsDir()) {
 			continue
 		}
 
 		if file.IsDir() {
 			if recursive {
-				findFiles(asset.Path, prefix, recursive, toc, ignore, knownFuncs)
+				err = findFiles(asset.Path, prefix, recursive, toc, filter, knownFuncs)
+				if err != nil && filter.failures != nil {
+					filter.failures.add(asset.Path, err)
+				}
 			}
 			continue
 		}
 
+		if !filter.included(asset.Path) {
+			continue
+		}
+
 		if strings.HasPrefix(asset.Name, prefix) {
 			asset.Name = asset.Name[len(prefix):]
 		}
@@ -175,6 +798,62 @@ func findFiles(dir, prefix string, recursive bool, toc *[]Asset, ignore []*regex
 	return nil
 }
 
+// findDirs adds the directories below dir to the given list, named like
+// the files in them. Ignored directories are skipped, and so is dir
+// itself. With include patterns, directories are only recorded through
+// the assets they hold, so none are added.
+func findDirs(dir, prefix string, dirs *[]Asset, filter *fileFilter) error {
+	if len(filter.include) > 0 {
+		return nil
+	}
+
+	dir, prefix = resolvePrefix(dir, prefix)
+
+	return filepath.Walk(dir, func(file string, fi os.FileInfo, err error) error {
+		// Directories which cannot be read were recorded by findFiles.
+		if err != nil && filter.failures != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if file == dir || !fi.IsDir() {
+			return nil
+		}
+
+		if filter.ignored(file, true) {
+			return filepath.SkipDir
+		}
+
+		name := filepath.ToSlash(file)
+		if strings.HasPrefix(name, prefix) {
+			name = name[len(prefix):]
+		}
+
+		name = strings.TrimPrefix(name, "/")
+		if len(name) == 0 {
+			return nil
+		}
+
+		abs, _ := filepath.Abs(file)
+		*dirs = append(*dirs, Asset{Path: abs, Name: name})
+		return nil
+	})
+}
+
+// resolvePrefix returns the directory and prefix as they are used for
+// matching file names. If a prefix is set, both are made absolute,
+// so the prefix can be stripped regardless of how the paths were given.
+func resolvePrefix(dir, prefix string) (string, string) {
+	if len(prefix) > 0 {
+		dir, _ = filepath.Abs(dir)
+		prefix, _ = filepath.Abs(prefix)
+		prefix = filepath.ToSlash(prefix)
+	}
+	return dir, prefix
+}
+
 var regFuncName = regexp.MustCompile(`[^a-zA-Z0-9_]`)
 
 // safeFunctionName converts the given name into a name
@@ -182,6 +861,12 @@ var regFuncName = regexp.MustCompile(`[^a-zA-Z0-9_]`)
 // also compares against a known list of functions to
 // prevent conflict based on name translation.
 func safeFunctionName(name string, knownFuncs map[string]int) string {
+	return uniqueFuncName(snakeCaseName(name), knownFuncs)
+}
+
+// snakeCaseName converts the given asset name into a lower case
+// identifier, separating its words with underscores.
+func snakeCaseName(name string) string {
 	name = strings.ToLower(name)
 	name = regFuncName.ReplaceAllString(name, "_")
 
@@ -200,12 +885,32 @@ func safeFunctionName(name string, knownFuncs map[string]int) string {
 		name = "_" + name
 	}
 
-	if num, ok := knownFuncs[name]; ok {
-		knownFuncs[name] = num + 1
-		name = fmt.Sprintf("%s%d", name, num)
-	} else {
+	return name
+}
+
+// uniqueFuncName returns the given identifier, or the identifier with the
+// lowest numeric suffix, which is not taken by another function yet, and
+// records it as taken. Keywords and the identifiers of the generated code
+// are taken from the start.
+func uniqueFuncName(name string, knownFuncs map[string]int) string {
+	if _, ok := knownFuncs[name]; !ok && reservedFuncName(name) {
 		knownFuncs[name] = 2
 	}
 
-	return name
+	if _, ok := knownFuncs[name]; !ok {
+		knownFuncs[name] = 2
+		return name
+	}
+
+	// Skip suffixed names, which are taken by other assets already.
+	for {
+		num := knownFuncs[name]
+		knownFuncs[name] = num + 1
+
+		candidate := fmt.Sprintf("%s%d", name, num)
+		if _, ok := knownFuncs[candidate]; !ok {
+			knownFuncs[candidate] = 2
+			return candidate
+		}
+	}
 }
@@ -1,8 +1,37 @@
 package bindata
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSafeFunctionName(t *testing.T) {
@@ -14,10 +43,111 @@ func TestSafeFunctionName(t *testing.T) {
 	}
 }
 
+func TestFuncNaming(t *testing.T) {
+	c := NewConfig()
+	c.FuncNaming = FuncCamelCase
+	toc := []Asset{{Name: "web/static/css/app.min.css"}, {Name: "2x/Icon.PNG"}, {Name: "web-static/css/app.min.css"}, {Name: "string"}}
+	err := nameFuncs(c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"webStaticCssAppMinCss", "_2xIconPng", "webStaticCssAppMinCss2", "string2"}
+	for i := range toc {
+		if toc[i].Func != want[i] {
+			t.Errorf("%s: got %s, want %s", toc[i].Name, toc[i].Func, want[i])
+		}
+	}
+
+	c.FuncNameFor = func(name string) string {
+		return "Asset"
+	}
+	if nameFuncs(c, toc) == nil {
+		t.Errorf("expected an error for an exported function name")
+	}
+}
+
+func TestAccessorName(t *testing.T) {
+	known := map[string]bool{"MustAsset": true}
+	names := []string{
+		accessorName("index.html", known),
+		accessorName("css/app.css", known),
+		accessorName("migrations/v001.up.sql", known),
+		accessorName("2x/icon.png", known),
+		accessorName("must/asset", known),
+		accessorName("index-html", known),
+	}
+	want := []string{"IndexHTML", "CSSAppCSS", "MigrationsV001UpSQL", "Asset2xIconPng", "MustAsset2", "IndexHTML2"}
+	for i := range names {
+		if names[i] != want[i] {
+			t.Errorf("accessor %d: got %s, want %s", i, names[i], want[i])
+		}
+	}
+}
+
+func TestConstantName(t *testing.T) {
+	known := map[string]bool{"AssetDir": true}
+	names := []string{
+		constantName("css/app.css", known),
+		constantName("css/app-css", known),
+		constantName("dir", known),
+	}
+	want := []string{"AssetCssAppCss", "AssetCssAppCss2", "AssetDir2"}
+	for i := range names {
+		if names[i] != want[i] {
+			t.Errorf("constant %d: got %s, want %s", i, names[i], want[i])
+		}
+	}
+
+	if constantName("unsafe", reservedFuncs()) != "AssetUnsafe2" || constantName("string", reservedFuncs()) != "AssetString2" {
+		t.Errorf("constants clash with AssetUnsafe and AssetString")
+	}
+
+	// Every exported identifier of the generated code must be reserved.
+	reserved := reservedFuncs()
+	t.Setenv("BINDATA_TEST_KEY", "000102030405060708090a0b0c0d0e0f")
+	for _, layout := range []string{"features", "blob"} {
+		c := NewConfig()
+		if layout == "features" {
+			c.FS, c.Overlay, c.Handler, c.Templates = true, true, true, true
+			c.Fingerprints, c.ContentTypes, c.Digests, c.Precompressed = true, true, true, true
+			c.CacheDecompressed, c.Extract, c.Metadata = true, true, true
+			c.Register, c.Locales, c.Migrations = "test", "locales", "db"
+		} else {
+			c.SingleBlob = true
+			c.DataFile = filepath.Join(t.TempDir(), "assets.dat")
+			c.EncryptKeyEnv = "BINDATA_TEST_KEY"
+		}
+
+		toc := AssetsFromMap(map[string][]byte{
+			"locales/de/a.txt": []byte("a"),
+			"db/1_init.up.sql": []byte("create"),
+			"templates/a.tmpl": []byte("{{.}}"),
+		})
+
+		var buf bytes.Buffer
+		err := TranslateTo(&buf, c, toc)
+		if err != nil {
+			t.Fatalf("%s: expected to be no error: %+v", layout, err)
+		}
+
+		f, err := parser.ParseFile(token.NewFileSet(), "bindata.go", buf.Bytes(), 0)
+		if err != nil {
+			t.Fatalf("%s: %v", layout, err)
+		}
+
+		for name, obj := range f.Scope.Objects {
+			if ast.IsExported(name) && !reserved[name] {
+				t.Errorf("%s: %s %s is not reserved", layout, obj.Kind, name)
+			}
+		}
+	}
+}
+
 func TestFindFiles(t *testing.T) {
 	var toc []Asset
 	var knownFuncs = make(map[string]int)
-	err := findFiles("testdata/dupname", "testdata/dupname", true, &toc, []*regexp.Regexp{}, knownFuncs)
+	err := findFiles("testdata/dupname", "testdata/dupname", true, &toc, &fileFilter{}, knownFuncs)
 	if err != nil {
 		t.Errorf("expected to be no error: %+v", err)
 	}
@@ -25,3 +155,2279 @@ func TestFindFiles(t *testing.T) {
 		t.Errorf("name collision")
 	}
 }
+
+func TestFindDirs(t *testing.T) {
+	var dirs []Asset
+	err := findDirs("testdata", "testdata", &dirs, &fileFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dirs) != 2 || dirs[0].Name != "dupname" || dirs[1].Name != "dupname/foo" {
+		t.Errorf("unexpected directories %+v", dirs)
+	}
+
+	var tree bytes.Buffer
+	err = writeTOCTree(&tree, nil, dirs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(tree.Bytes(), []byte(`"dupname/foo": 0`)) {
+		t.Errorf("directory mode not written:\n%s", tree.Bytes())
+	}
+}
+
+func TestExtractEntry(t *testing.T) {
+	dir := t.TempDir()
+	fi := (&tar.Header{Typeflag: tar.TypeReg, Mode: 0644}).FileInfo()
+	for _, name := range []string{"../x", "a/../../x", "/etc/x"} {
+		err := extractEntry(dir, name, fi, bytes.NewReader(nil))
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	err := extractEntry(dir, "./a/b.txt", fi, bytes.NewReader([]byte("data")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "a", "b.txt"))
+	if err != nil || string(data) != "data" {
+		t.Errorf("unexpected contents %q: %v", data, err)
+	}
+}
+
+func TestTransformAssets(t *testing.T) {
+	c := NewConfig()
+	c.Transforms = []TransformFunc{
+		func(name string, r io.Reader) (io.Reader, error) {
+			b, err := ioutil.ReadAll(r)
+			return bytes.NewReader(bytes.ToUpper(b)), err
+		},
+	}
+
+	toc := []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}}
+	dir, err := transformAssets(c, toc)
+	defer os.RemoveAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, _ := ioutil.ReadFile("testdata/dupname/foo_bar")
+	got, err := ioutil.ReadFile(toc[0].Path)
+	if err != nil || !bytes.Equal(got, bytes.ToUpper(want)) {
+		t.Errorf("unexpected contents %q: %v", got, err)
+	}
+	if toc[0].origin() != "testdata/dupname/foo_bar" {
+		t.Errorf("unexpected origin %q", toc[0].origin())
+	}
+}
+
+func TestMinify(t *testing.T) {
+	tests := []struct {
+		fn       func([]byte) ([]byte, error)
+		in, want string
+	}{
+		{minifyCSS, "a :hover , b > i {\n  color: red; /* x */\n  content: \"a  b\";\n}\n", `a :hover,b>i{color:red;content:"a  b"}`},
+		{minifyCSS, "@media (min-width: 10px) { p { margin: 0 auto } }", "@media (min-width:10px){p{margin:0 auto}}"},
+		{minifyJS, "// c\nvar a = 1; /* b */\n\n  var s = '// no';\nvar r = /\\/\\//g; // d\n", "var a = 1;\nvar s = '// no';\nvar r = /\\/\\//g;\n"},
+		{minifyJS, "x = a / b // c\n", "x = a / b\n"},
+		{minifyHTML, "<p>\n  a  <!-- c -->  b\n</p>\n<pre> x\n  y </pre>", "<p>\na b\n</p>\n<pre> x\n  y </pre>"},
+		{minifyJSON, "{ \"a\": [1, 2] }", `{"a":[1,2]}`},
+	}
+
+	for _, test := range tests {
+		got, err := test.fn([]byte(test.in))
+		if err != nil || string(got) != test.want {
+			t.Errorf("%q: got %q, want %q: %v", test.in, got, test.want, err)
+		}
+	}
+}
+
+func TestContinueOnError(t *testing.T) {
+	dir := t.TempDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("a"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "gone.txt"))
+	if err != nil {
+		t.Skip(err)
+	}
+
+	c := NewConfig()
+	c.Input = []InputConfig{{Path: dir}}
+	c.Prefix = dir
+	c.Output = filepath.Join(t.TempDir(), "bindata.go")
+
+	_, err = TranslateStats(c)
+	if err == nil {
+		t.Fatalf("expected an error for a vanished file")
+	}
+
+	c.ContinueOnError = true
+	stats, err := TranslateStats(c)
+	var failed AssetErrors
+	if !errors.As(err, &failed) || len(failed) != 1 || !errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if failed[0].Path != filepath.Join(dir, "gone.txt") || stats.Assets != 1 || len(stats.Failures) != 1 {
+		t.Errorf("unexpected failures %v, %d assets", failed, stats.Assets)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt", "d.txt"} {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := NewConfig()
+	c.Input = []InputConfig{{Path: dir}}
+	c.Prefix = dir
+	c.Output = filepath.Join(t.TempDir(), "bindata.go")
+	c.Jobs = 4
+	c.MaxAssetSize = 3
+	c.SizeLimitWarn = true
+
+	var kinds []EventKind
+	var names []string
+	c.Events = func(e Event) {
+		kinds = append(kinds, e.Kind)
+		if e.Kind == EventAsset {
+			names = append(names, e.Asset.Name)
+			if e.Done != len(names) || e.Total != 4 {
+				t.Errorf("asset %s reported as %d of %d", e.Asset.Name, e.Done, e.Total)
+			}
+		}
+		if e.Output != c.Output {
+			t.Errorf("unexpected output %s", e.Output)
+		}
+	}
+
+	_, err := TranslateStats(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []EventKind{EventFound, EventWarning, EventWarning, EventWarning, EventWarning, EventAsset, EventAsset, EventAsset, EventAsset, EventDone}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Errorf("got events %v, want %v", kinds, want)
+	}
+	if !reflect.DeepEqual(names, []string{"a.txt", "b.txt", "c.txt", "d.txt"}) {
+		t.Errorf("assets reported out of order: %v", names)
+	}
+}
+
+func TestOutdated(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b`c.txt"} {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := NewConfig()
+	c.Input = []InputConfig{{Path: dir}}
+	c.Prefix = dir
+	c.Output = filepath.Join(t.TempDir(), "bindata.go")
+
+	err := Translate(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale, names, err := Outdated(c, "")
+	if err != nil || stale || len(names) > 0 {
+		t.Fatalf("fresh output reported as outdated: %v, %v", names, err)
+	}
+
+	err = ioutil.WriteFile(filepath.Join(dir, "a.txt"), []byte("changed"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.Remove(filepath.Join(dir, "b`c.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ioutil.WriteFile(filepath.Join(dir, "d.txt"), nil, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale, names, err = Outdated(c, c.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stale || !reflect.DeepEqual(names, []string{"a.txt", "b`c.txt", "d.txt"}) {
+		t.Errorf("unexpected outdated assets %v", names)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	c := NewConfig()
+	c.Metadata = true
+	c.ModTime = 1700000000
+	toc := []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}}
+
+	var buf bytes.Buffer
+	err := TranslateTo(&buf, c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `GeneratedAt = "2023-11-14T22:13:20Z"`) {
+		t.Errorf("missing generation time in output")
+	}
+
+	data, err := ioutil.ReadFile(toc[0].Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	toc[0].Digest = sha256.Sum256(data)
+
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(sourceList(toc))))
+	if !strings.Contains(out, fmt.Sprintf("SourceHash = %q", hash)) {
+		t.Errorf("missing source hash in output")
+	}
+}
+
+func TestAssetOptions(t *testing.T) {
+	dir := t.TempDir()
+	text := bytes.Repeat([]byte("all work and no play "), 100)
+	for _, name := range []string{"a.txt", "b.TXT", "c.png", "d.css"} {
+		err := ioutil.WriteFile(filepath.Join(dir, name), text, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := NewConfig()
+	c.PerExtension = map[string]AssetOptions{
+		"txt":  {NoCompress: true},
+		".PNG": {ForceCompress: true},
+		".css": {NoMemCopy: true},
+	}
+	want := map[string]bool{"a.txt": false, "b.TXT": false, "c.png": true, "d.css": true}
+
+	for name, compressed := range want {
+		asset := Asset{Path: filepath.Join(dir, name)}
+		fd, err := openAsset(c, &asset)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fd.Close()
+
+		if asset.Compressed != compressed {
+			t.Errorf("%s: compressed = %v, want %v", name, asset.Compressed, compressed)
+		}
+		if got := c.assetStringData(&asset); got != (name == "d.css") {
+			t.Errorf("%s: string data = %v", name, got)
+		}
+	}
+
+	c.PerExtension["txt"] = AssetOptions{NoCompress: true, ForceCompress: true}
+	if validateExtensions(c) == nil {
+		t.Errorf("expected an error for contradicting options")
+	}
+}
+
+func TestContentType(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"app.css":      "body{}",
+		"logo":         "\x89PNG\r\n\x1a\n",
+		"page.unknown": "<!DOCTYPE html><p>x</p>",
+	}
+	want := map[string]string{
+		"app.css":      "text/css; charset=utf-8",
+		"logo":         "image/png",
+		"page.unknown": "text/html; charset=utf-8",
+	}
+
+	for name, data := range files {
+		asset := Asset{Path: filepath.Join(dir, name)}
+		err := ioutil.WriteFile(asset.Path, []byte(data), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := contentType(&asset)
+		if err != nil || got != want[name] {
+			t.Errorf("%s: contentType = %q, %v; want %q", name, got, err, want[name])
+		}
+	}
+}
+
+func TestCertBundle(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "test"}}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"ca.pem":  append([]byte("# bundle\n"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})...),
+		"key.pem": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}),
+		"ca.txt":  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		"bad.crt": pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("bad")}),
+	}
+	want := map[string]bool{"ca.pem": true, "key.pem": false, "ca.txt": false}
+
+	for name, data := range files {
+		asset := Asset{Path: filepath.Join(dir, name)}
+		err := ioutil.WriteFile(asset.Path, data, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found, err := certBundle(&asset)
+		if name == "bad.crt" {
+			if err == nil {
+				t.Errorf("expected an error for an invalid certificate")
+			}
+			continue
+		}
+		if err != nil || found != want[name] {
+			t.Errorf("%s: certBundle = %v, %v; want %v", name, found, err, want[name])
+		}
+	}
+}
+
+func TestFindTimeZones(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	if _, err := zw.Create("Europe/Berlin"); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	toc := []Asset{
+		{Path: filepath.Join(dir, "zoneinfo.zip"), Name: "zoneinfo.zip"},
+		{Path: filepath.Join(dir, "zones.txt"), Name: "zones.txt"},
+	}
+	if err := ioutil.WriteFile(toc[0].Path, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(toc[1].Path, []byte("Europe/Berlin"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	asset, err := findTimeZones(&Config{TimeZones: "zoneinfo.zip"}, toc)
+	if err != nil || asset != &toc[0] {
+		t.Errorf("findTimeZones = %v, %v; want the zoneinfo.zip asset", asset, err)
+	}
+
+	for _, name := range []string{"zones.txt", "missing.zip"} {
+		if _, err := findTimeZones(&Config{TimeZones: name}, toc); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestSelfTestPath(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		c    Config
+		want string
+	}{
+		{Config{Output: "bindata.go"}, "bindata_test.go"},
+		{Config{Output: "assets/gen.go"}, "assets/gen_test.go"},
+		{Config{Output: dir, SplitOutput: true}, filepath.Join(dir, "bindata_test.go")},
+	}
+
+	for _, test := range tests {
+		if got := test.c.selfTestPath(); got != test.want {
+			t.Errorf("%s: selfTestPath = %s, want %s", test.c.Output, got, test.want)
+		}
+	}
+}
+
+func TestChecksumStep(t *testing.T) {
+	asset := Asset{Path: filepath.Join(t.TempDir(), "a.txt"), Name: "a.txt"}
+	if err := ioutil.WriteFile(asset.Path, []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	step, err := checksumStep(&Config{}, &asset)
+	if err != nil || step != "" {
+		t.Errorf("checksumStep without Checksums = %q, %v; want nothing", step, err)
+	}
+
+	step, err = checksumStep(&Config{Checksums: true}, &asset)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := fmt.Sprintf("bindata_checksum(\"a.txt\", bytes, 0x%08x)", crc32.ChecksumIEEE([]byte("hello")))
+	if !strings.Contains(step, want) {
+		t.Errorf("checksumStep = %q, want a call like %s", step, want)
+	}
+}
+
+func TestHashedName(t *testing.T) {
+	digest := []byte{0x3f, 0x9a, 0xb2, 0xc1, 0xff}
+	tests := map[string]string{
+		"css/app.css":    "css/app.3f9ab2c1.css",
+		"app.min.js":     "app.min.3f9ab2c1.js",
+		"LICENSE":        "LICENSE.3f9ab2c1",
+		"conf/.htaccess": "conf/.htaccess.3f9ab2c1",
+	}
+
+	for name, want := range tests {
+		if got := hashedName(name, digest); got != want {
+			t.Errorf("hashedName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestFindMigrations(t *testing.T) {
+	var toc []Asset
+	for _, name := range []string{"db/10_b.up.sql", "db/2_a.down.sql", "db/2_a.up.sql", "db/README.md", "db/old/1_x.up.sql"} {
+		toc = append(toc, Asset{Name: name})
+	}
+
+	c := NewConfig()
+	c.Migrations = "db/"
+	list, err := findMigrations(c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []migration{
+		{version: 2, title: "a", up: "db/2_a.up.sql", down: "db/2_a.down.sql"},
+		{version: 10, title: "b", up: "db/10_b.up.sql"},
+	}
+	if fmt.Sprint(list) != fmt.Sprint(want) {
+		t.Errorf("got migrations %v, want %v", list, want)
+	}
+
+	toc = append(toc, Asset{Name: "db/2_other.up.sql"})
+	_, err = findMigrations(c, toc)
+	if err == nil {
+		t.Errorf("expected an error for a duplicate version")
+	}
+}
+
+func TestLocales(t *testing.T) {
+	tests := map[string]string{
+		"de_AT":              "[de-at de]",
+		"zh-Hant-TW":         "[zh-hant-tw zh-hant zh]",
+		"de-DE-u-co-phonebk": "[de-de-u-co-phonebk de-de-u-co de-de de]",
+		"":                   "[]",
+	}
+	for locale, want := range tests {
+		if got := fmt.Sprint(localeChain(locale)); got != want {
+			t.Errorf("localeChain(%q) = %s, want %s", locale, got, want)
+		}
+	}
+
+	c := NewConfig()
+	c.Locales = "locales"
+	toc := []Asset{{Name: "locales/de_AT/a.txt"}, {Name: "locales/en/a.txt"}, {Name: "locales/b.txt"}, {Name: "other/fr/a.txt"}}
+	locales, err := findLocales(c, toc)
+	if err != nil || fmt.Sprint(locales) != "map[de-at:de_AT en:en]" {
+		t.Errorf("findLocales = %v, %v", locales, err)
+	}
+
+	toc = append(toc, Asset{Name: "locales/de-at/a.txt"})
+	_, err = findLocales(c, toc)
+	if err == nil {
+		t.Errorf("expected an error for directories of the same locale")
+	}
+}
+
+func TestPlatforms(t *testing.T) {
+	list := []string{"darwin", "linux", "linux/amd64"}
+	tests := map[string]string{
+		"":            "!darwin && !linux",
+		"linux":       "linux && !(linux && amd64)",
+		"linux/amd64": "linux && amd64",
+	}
+	for p, want := range tests {
+		if got := platformConstraint(p, list); got != want {
+			t.Errorf("platformConstraint(%q) = %s, want %s", p, got, want)
+		}
+	}
+
+	if got := platformOutput("assets/bindata.go", "linux/amd64"); got != "assets/bindata_linux_amd64.go" {
+		t.Errorf("platformOutput = %s", got)
+	}
+
+	// Assets for different platforms may share a name.
+	c := NewConfig()
+	toc := []Asset{
+		{Name: "helper", platforms: []string{"linux/amd64"}},
+		{Name: "helper", platforms: []string{"windows"}},
+	}
+	toc, err := resolveCollisions(c, toc)
+	if err != nil || len(toc) != 2 {
+		t.Errorf("resolveCollisions = %d assets, %v", len(toc), err)
+	}
+
+	toc = append(toc, Asset{Name: "helper", platforms: []string{"linux"}})
+	_, err = resolveCollisions(c, toc)
+	if err == nil {
+		t.Errorf("expected an error for assets of overlapping platforms")
+	}
+}
+
+func TestExtractKey(t *testing.T) {
+	toc := []Asset{
+		{Path: "testdata/dupname/foo_bar", Name: "b"},
+		{Path: "testdata/dupname/foo/bar", Name: "a"},
+	}
+	toc[0].Digest[0] = 1
+
+	key, err := extractKey(toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(key) != 2*extractKeyLen {
+		t.Errorf("unexpected key %q", key)
+	}
+
+	toc[0], toc[1] = toc[1], toc[0]
+	if other, _ := extractKey(toc); other != key {
+		t.Errorf("key depends on the order of the assets: %s, %s", key, other)
+	}
+
+	toc[1].Digest[0] = 2
+	if other, _ := extractKey(toc); other == key {
+		t.Errorf("key does not change with the contents")
+	}
+}
+
+func TestWriteSourceInvalid(t *testing.T) {
+	c := NewConfig()
+	c.Format = true
+
+	// The invalid code is dumped to the temporary directory.
+	t.Setenv("TMPDIR", t.TempDir())
+
+	name := filepath.Join(t.TempDir(), "bindata.go")
+	err := writeSource(c, name, func(w io.Writer) error {
+		_, err := io.WriteString(w, "package main\n\nfunc {\n")
+		return err
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, serr := os.Stat(name); serr == nil {
+		t.Errorf("invalid code was written to %s", name)
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "bindata.go")
+	err := ioutil.WriteFile(name, []byte("old"), 0640)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writeFile(NewConfig(), name, func(w io.Writer) error {
+		io.WriteString(w, "partial")
+		return fmt.Errorf("failed")
+	})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	data, _ := ioutil.ReadFile(name)
+	files, _ := ioutil.ReadDir(dir)
+	if string(data) != "old" || len(files) != 1 {
+		t.Errorf("failed write left %q and %d files", data, len(files))
+	}
+
+	c := NewConfig()
+	c.SyncOutput = true
+	err = writeFile(c, name, func(w io.Writer) error {
+		_, err := io.WriteString(w, "new")
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fi, err := os.Stat(name)
+	data, _ = ioutil.ReadFile(name)
+	if err != nil || string(data) != "new" || fi.Mode().Perm() != 0640 {
+		t.Errorf("unexpected file %q: %v", data, fi.Mode())
+	}
+}
+
+func TestTranslateTo(t *testing.T) {
+	c := NewConfig()
+	c.Package = "assets"
+	c.CompressionLevel = 9
+
+	toc := []Asset{
+		{Path: "testdata/dupname/foo_bar", Name: "b.txt"},
+		{Path: "testdata/dupname/foo/bar", Name: "a.txt"},
+	}
+
+	var buf bytes.Buffer
+	err := TranslateTo(&buf, c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{"package assets", `"a.txt": a_txt`, `"b.txt": b_txt`} {
+		if !bytes.Contains(buf.Bytes(), []byte(want)) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+	if len(toc[0].Func) > 0 {
+		t.Errorf("the given assets were modified")
+	}
+}
+
+func TestAssetsFromMap(t *testing.T) {
+	toc := AssetsFromMap(map[string][]byte{"b/c.txt": []byte("c"), "a.txt": []byte("a")})
+	if len(toc) != 2 || toc[0].Name != "a.txt" || toc[1].Func != "b_c_txt" {
+		t.Fatalf("unexpected assets %+v", toc)
+	}
+
+	digest, err := assetDigest(&toc[1])
+	if err != nil || digest != sha256.Sum256([]byte("c")) {
+		t.Errorf("unexpected digest %x: %v", digest, err)
+	}
+}
+
+func TestPlan(t *testing.T) {
+	c := NewConfig()
+	c.Input = []InputConfig{{Path: "testdata/dupname", Recursive: true}}
+	c.Prefix = "testdata/dupname"
+	c.Output = filepath.Join(t.TempDir(), "missing", "bindata.go")
+
+	toc, stats, err := Plan(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Assets != 2 || toc[0].Name != "foo/bar" || toc[0].Size == 0 || toc[0].EmbeddedSize == 0 {
+		t.Errorf("unexpected assets %+v", toc)
+	}
+	if _, err := os.Stat(filepath.Dir(c.Output)); err == nil {
+		t.Errorf("the output directory was created")
+	}
+
+	largest := stats.Largest(1)
+	if stats.Size != toc[0].Size+toc[1].Size || len(largest) != 1 || largest[0].EmbeddedSize < toc[1].EmbeddedSize {
+		t.Errorf("unexpected statistics %+v", stats)
+	}
+}
+
+func TestCheckSizes(t *testing.T) {
+	toc := []Asset{{Path: "testdata/dupname/foo_bar"}, {Path: "testdata/dupname/foo/bar"}}
+	toc[1].original = &toc[0]
+
+	c := NewConfig()
+	c.MaxTotalSize = 2
+	_, err := checkSizes(c, toc)
+	if err != nil {
+		t.Errorf("duplicates were counted: %v", err)
+	}
+
+	c.MaxAssetSize = 1
+	_, err = checkSizes(c, toc)
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+
+	c.SizeLimitWarn = true
+	warnings, err := checkSizes(c, toc)
+	if err != nil || len(warnings) != 2 {
+		t.Errorf("unexpected warnings %q: %v", warnings, err)
+	}
+}
+
+func TestResolveCollisions(t *testing.T) {
+	c := NewConfig()
+	c.Collisions = CollisionRename
+
+	toc := []Asset{
+		{Name: "a.txt", Func: "a_txt", Path: "1"},
+		{Name: "a.txt", Func: "a_txt2", Path: "2"},
+		{Name: "a-2.txt", Func: "a_txt", Path: "3"},
+	}
+	sort.Stable(assetsByName(toc))
+
+	toc, err := resolveCollisions(c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uniqueFuncs(toc)
+
+	var names, funcs []string
+	for _, asset := range toc {
+		names = append(names, asset.Name)
+		funcs = append(funcs, asset.Func)
+	}
+	if fmt.Sprint(names) != "[a-2.txt a-3.txt a.txt]" || fmt.Sprint(funcs) != "[a_txt a_3_txt a_txt2]" {
+		t.Errorf("unexpected names %v and functions %v", names, funcs)
+	}
+
+	c.Collisions = CollisionError
+	_, err = resolveCollisions(c, []Asset{{Name: "a"}, {Name: "a"}})
+	if err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestParseConfigFile(t *testing.T) {
+	yaml := `
+package: assets # comment
+recursive: true
+tags: [dev, "a # b"]
+inputs:
+  - path: web
+  - path: 'it''s'
+    recursive: false
+    ignore:
+    - \.orig$
+`
+	toml := `
+package = "assets" # comment
+recursive = true
+tags = [
+  "dev",
+  "a # b",
+]
+
+[[inputs]]
+path = "web"
+
+[[inputs]]
+path = "it's"
+recursive = false
+ignore = ['\.orig$']
+`
+	y, err := parseYAML([]byte(yaml))
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := parseTOML([]byte(toml))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(y) != fmt.Sprint(v) {
+		t.Errorf("documents differ:\n%v\n%v", y, v)
+	}
+
+	c := NewConfig()
+	err = c.applyFile(y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Package != "assets" || len(c.Input) != 2 || !c.Input[0].Recursive || c.Input[1].Recursive ||
+		c.Input[1].Path != "it's" || !c.Input[1].Ignore[0].MatchString("a.orig") {
+		t.Errorf("unexpected configuration %+v", c)
+	}
+
+	for _, doc := range []string{
+		"inputs: [a, , b]\n",
+		"k: [,0]\n",
+		"package: \"assets\n",
+		"tags: ['dev]\n",
+		"inputs:\n\t- path: web\n",
+	} {
+		_, err := parseYAML([]byte(doc))
+		if err == nil {
+			t.Errorf("expected an error for %q", doc)
+		}
+	}
+}
+
+func TestParseInputPath(t *testing.T) {
+	for _, tt := range []struct {
+		in        string
+		path      string
+		recursive bool
+	}{
+		{"web", "web", false},
+		{"web/", "web/", false},
+		{"web/...", "web", true},
+		{"...", ".", true},
+		{"/...", "/", true},
+		{"web...", "web...", false},
+	} {
+		path, recursive := ParseInputPath(tt.in)
+		if path != tt.path || recursive != tt.recursive {
+			t.Errorf("ParseInputPath(%q) = %q, %v; want %q, %v", tt.in, path, recursive, tt.path, tt.recursive)
+		}
+	}
+
+	c := NewConfig()
+	err := c.applyFile(map[string]interface{}{
+		"inputs": []interface{}{"web/..."},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Input[0].Path != "web" || !c.Input[0].Recursive {
+		t.Errorf("unexpected input %+v", c.Input[0])
+	}
+}
+
+func TestReadFileList(t *testing.T) {
+	list := `
+# build output
+dist/app.3f2a.js => js/app.js
+  dist/favicon.ico
+`
+	inputs, err := ReadFileList(strings.NewReader(list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) != 2 || inputs[0].Path != "dist/app.3f2a.js" || inputs[0].Name != "js/app.js" ||
+		inputs[1].Path != "dist/favicon.ico" || len(inputs[1].Name) > 0 {
+		t.Errorf("unexpected inputs %+v", inputs)
+	}
+
+	_, err = ReadFileList(strings.NewReader("dist/app.js =>\n"))
+	if err == nil {
+		t.Errorf("expected an error for a missing name")
+	}
+}
+
+func TestIgnoreHidden(t *testing.T) {
+	c := NewConfig()
+	c.IgnoreHidden = true
+	c.IgnoreVCS = true
+	f := newFilter(c, &InputConfig{})
+
+	for file, want := range map[string]bool{
+		"web/index.html":              false,
+		"../web/index.html":           false,
+		".github/workflows/ci.yml":    false,
+		"web/.DS_Store":               true,
+		`web\.cache`:                  true,
+		"web/.git":                    true,
+		"web/.gitignore":              true,
+		"web/CVS":                     true,
+		"web/node_modules":            true,
+		"web/node_modules_backup.txt": false,
+	} {
+		if f.ignored(file, false) != want {
+			t.Errorf("ignored(%q) = %v; want %v", file, !want, want)
+		}
+	}
+}
+
+func TestIgnoreFile(t *testing.T) {
+	rules := `
+# comment
+*.ts
+src/
+!keep.ts
+/dist/*.map
+**/testdata/**
+`
+	f, err := parseIgnoreFile("/root", strings.NewReader(rules))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		file    string
+		dir     bool
+		ignored bool
+	}{
+		{"/root/app.ts", false, true},
+		{"/root/js/lib/util.ts", false, true},
+		{"/root/js/keep.ts", false, false},
+		{"/root/src", true, true},
+		{"/root/src", false, false},
+		{"/root/web/src", true, true},
+		{"/root/dist/app.js.map", false, true},
+		{"/root/web/dist/app.js.map", false, false},
+		{"/root/a/testdata/b/c.txt", false, true},
+		{"/root/.bindataignore", false, true},
+		{"/root/index.html", false, false},
+		{"/other/app.ts", false, false},
+	} {
+		if f.ignored(filepath.FromSlash(tt.file), tt.dir) != tt.ignored {
+			t.Errorf("ignored(%q, %v) = %v; want %v", tt.file, tt.dir, !tt.ignored, tt.ignored)
+		}
+	}
+}
+
+func TestAssetName(t *testing.T) {
+	for in, want := range map[string]string{
+		"css/app.css":        "css/app.css",
+		`css\app.css`:        "css/app.css",
+		"/css//app.css":      "css/app.css",
+		"./img/../css/a.css": "css/a.css",
+		"../app.css":         "app.css",
+		"/":                  "",
+	} {
+		if name := assetName(in); name != want {
+			t.Errorf("assetName(%q) = %q; want %q", in, name, want)
+		}
+	}
+}
+
+func TestRewriteNames(t *testing.T) {
+	c := NewConfig()
+	c.Rewrites = []Rewrite{
+		{regexp.MustCompile(`^dist/assets/`), ""},
+		{regexp.MustCompile(`\.[0-9a-f]{8}(\.\w+)$`), "$1"},
+	}
+
+	toc := []Asset{
+		{Name: "dist/assets/js/app.3f2a9c1b.js", Func: "dist_assets_js_app_3f2a9c1b_js"},
+		{Name: "dist/index.html", Func: "dist_index_html"},
+	}
+	dirs := []Asset{{Name: "dist/assets/js"}, {Name: "dist/assets/js/vendor"}}
+
+	dirs, err := rewriteNames(c, toc, dirs, make(map[string]int))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if toc[0].Name != "js/app.js" || toc[0].Func != "js_app_js" || toc[1].Name != "dist/index.html" {
+		t.Errorf("unexpected assets %+v", toc)
+	}
+	if len(dirs) != 2 || dirs[0].Name != "js" || dirs[1].Name != "js/vendor" {
+		t.Errorf("unexpected directories %+v", dirs)
+	}
+
+	c.Rewrites = []Rewrite{{regexp.MustCompile(`.*`), ""}}
+	_, err = rewriteNames(c, toc, nil, make(map[string]int))
+	if err == nil {
+		t.Errorf("expected an error for an empty name")
+	}
+}
+
+func TestFoldedNames(t *testing.T) {
+	folded, err := foldedNames([]Asset{{Name: "Templates/Index.HTML"}, {Name: "css/app.css"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folded) != 1 || folded["templates/index.html"] != "Templates/Index.HTML" {
+		t.Errorf("unexpected folded names %v", folded)
+	}
+
+	_, err = foldedNames([]Asset{{Name: "README.md"}, {Name: "readme.md"}})
+	if err == nil {
+		t.Errorf("expected an error for names differing by case")
+	}
+}
+
+func TestBundles(t *testing.T) {
+	yaml := `
+package: assets
+compression: gzip
+inputs: [web]
+bundles:
+  db:
+    package: db
+    output: db/bindata.go
+    inputs: [migrations]
+  docs:
+    output: docs/bindata.go
+    nocompress: true
+`
+	doc, err := parseYAML([]byte(yaml))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewConfig()
+	c.Output = "bindata.go"
+	err = c.applyFile(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, docs := c.Bundles["db"], c.Bundles["docs"]
+	if len(c.Bundles) != 2 || db.Package != "db" || len(db.Input) != 1 || db.Input[0].Path != "migrations" ||
+		db.Compression != CompressGzip || docs.Package != "assets" || len(docs.Input) != 0 || !docs.NoCompress {
+		t.Errorf("unexpected bundles %+v", c.Bundles)
+	}
+
+	err = validateBundles(c)
+	if err != nil {
+		t.Errorf("expected to be no error: %+v", err)
+	}
+
+	docs.Output = "db/bindata.go"
+	err = validateBundles(c)
+	if err == nil {
+		t.Errorf("expected an error for bundles with the same output")
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	asset := func(name string) ([]byte, error) {
+		return []byte(name), nil
+	}
+	info := func(name string) (os.FileInfo, error) {
+		return nil, nil
+	}
+
+	var r Registry
+	err := r.AddPackage("a", []string{"x.txt", "y.txt"}, asset, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddPackage("b", []string{"z.txt", "y.txt"}, asset, info)
+	if err == nil {
+		t.Errorf("expected an error for a name registered already")
+	}
+	if got := fmt.Sprint(r.AssetNames()); got != "[x.txt y.txt]" {
+		t.Errorf("AssetNames = %s", got)
+	}
+
+	data, err := r.Asset("x.txt")
+	if err != nil || string(data) != "x.txt" {
+		t.Errorf("Asset = %q, %v", data, err)
+	}
+	if pkg, ok := r.Package("y.txt"); pkg != "a" || !ok {
+		t.Errorf("Package = %s, %v", pkg, ok)
+	}
+
+	_, err = r.Asset("z.txt")
+	if !errors.Is(err, ErrAssetNotFound) {
+		t.Errorf("expected ErrAssetNotFound, got %v", err)
+	}
+}
+
+func TestHeaderTemplate(t *testing.T) {
+	c := NewConfig()
+	c.Package = "assets"
+	c.ModTime = 1700000000
+	c.HeaderTemplate = "// Copyright {{.Timestamp.Year}} Example\n// Package {{.Package}}\n"
+	toc := []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}}
+
+	var buf bytes.Buffer
+	err := TranslateTo(&buf, c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "// Copyright 2023 Example\n// Package assets\n\n" + generatedMarker + "\n\npackage assets\n"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("unexpected start of output:\n%s", buf.String()[:len(want)])
+	}
+
+	c.HeaderTemplate = "/* Example */"
+	c.Tags = []string{"linux"}
+	err = TranslateTo(&buf, c, toc)
+	if err == nil {
+		t.Errorf("expected an error for a block comment before build constraints")
+	}
+}
+
+func TestSnippets(t *testing.T) {
+	s, err := ParseSnippet("import (\n\t\"log\"\n\ttt \"text/template\"\n)\n\nvar _ = tt.New\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s.Imports, []string{"log", "tt text/template"}) || s.Code != "var _ = tt.New\n" {
+		t.Errorf("unexpected snippet %+v", s)
+	}
+
+	c := NewConfig()
+	c.Prologue = []Snippet{s}
+	c.Epilogue = []Snippet{{Code: "const count = {{len .Assets}}", Template: true}}
+	toc := []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}}
+
+	var buf bytes.Buffer
+	err = TranslateTo(&buf, c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"\ttt \"text/template\"\n", "\n\nvar _ = tt.New\n\n", "\nconst count = 1\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in output", want)
+		}
+	}
+	if !strings.HasSuffix(out, "const count = 1\n\n") {
+		t.Errorf("epilogue is not at the end of the output")
+	}
+}
+
+func TestTemplateDir(t *testing.T) {
+	dir := t.TempDir()
+	err := WriteCodeTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	asset := filepath.Join(dir, "asset.tmpl")
+	err = ioutil.WriteFile(asset, []byte("// custom {{.Func}}\n"+codeTemplateText["asset.tmpl"]), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewConfig()
+	c.TemplateDir = dir
+	toc := []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}}
+
+	var buf bytes.Buffer
+	err = TranslateTo(&buf, c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "// custom foo_bar\nfunc foo_bar() (*asset, error) {") {
+		t.Errorf("template was not replaced")
+	}
+
+	err = ioutil.WriteFile(asset, []byte("func {{.Func}}() {\n{{.Missing}}\n"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = TranslateTo(&buf, c, toc)
+	if err == nil || !strings.Contains(err.Error(), "asset.tmpl:2") {
+		t.Errorf("expected an error naming the line, got %v", err)
+	}
+}
+
+func TestGoVersion(t *testing.T) {
+	toc := []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}}
+	old := []string{"io/ioutil", "interface{}", "reflect.SliceHeader", "// +build"}
+
+	for version, legacy := range map[string]bool{
+		"":        true,
+		"1.15":    true,
+		"go1.21":  false,
+		"1.21.3":  false,
+		"1.22rc1": false,
+	} {
+		c := NewConfig()
+		c.GoVersion = version
+		c.NoMemCopy = true
+		c.Tags = []string{"!dev"}
+
+		var buf bytes.Buffer
+		err := TranslateTo(&buf, c, toc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, s := range old {
+			if strings.Contains(buf.String(), s) != legacy {
+				t.Errorf("%q: output containing %s is %v", version, s, !legacy)
+			}
+		}
+	}
+
+	for _, version := range []string{"1", "2.0", "1.x", "1.21.x"} {
+		c := NewConfig()
+		c.GoVersion = version
+		err := TranslateTo(ioutil.Discard, c, toc)
+		if err == nil {
+			t.Errorf("%q: expected an error", version)
+		}
+	}
+}
+
+func TestRemoveStale(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"bindata_foo_asset.go":  generatedMarker + "\n\npackage main\n",
+		"bindata_bar_asset.go":  "// License\n\n" + generatedMarker + "\n\npackage main\n",
+		"bindata_user_asset.go": "package main\n",
+	}
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err := removeStale(dir, map[string]bool{"bindata_foo_asset.go": true})
+	if err != nil {
+		t.Fatalf("expected to be no error: %+v", err)
+	}
+
+	for name, want := range map[string]bool{
+		"bindata_foo_asset.go":  true,
+		"bindata_bar_asset.go":  false,
+		"bindata_user_asset.go": true,
+	} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if exists := err == nil; exists != want {
+			t.Errorf("%s: exists = %v, want %v", name, exists, want)
+		}
+	}
+}
+
+func TestWorthCompressing(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"text.txt":  bytes.Repeat([]byte("all work and no play "), 1000),
+		"image.png": bytes.Repeat([]byte("all work and no play "), 1000),
+		"tiny.txt":  []byte("x"),
+	}
+	want := map[string]bool{
+		"text.txt":  true,
+		"image.png": false,
+		"tiny.txt":  false,
+	}
+
+	c := NewConfig()
+	for name, data := range files {
+		asset := Asset{Path: filepath.Join(dir, name)}
+		err := ioutil.WriteFile(asset.Path, data, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fd, err := os.Open(asset.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := worthCompressing(c, &asset, fd)
+		fd.Close()
+		if err != nil {
+			t.Fatalf("%s: expected to be no error: %+v", name, err)
+		}
+		if got != want[name] {
+			t.Errorf("%s: worthCompressing = %v, want %v", name, got, want[name])
+		}
+	}
+}
+
+func TestTrainDictionary(t *testing.T) {
+	dir := t.TempDir()
+	var toc []Asset
+	for i := 0; i < 20; i++ {
+		data := fmt.Sprintf(`{"id": %d, "name": "user%d", "settings": {"theme": "dark", "notifications": true}}`, i, i)
+		asset := Asset{Path: filepath.Join(dir, fmt.Sprintf("%d.json", i))}
+		err := ioutil.WriteFile(asset.Path, []byte(data), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		toc = append(toc, asset)
+	}
+
+	c := NewConfig()
+	c.SharedDictionary = true
+	err := trainDictionary(c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(c.dictionary, []byte(`"notifications": true`)) {
+		t.Errorf("shared sequences missing from dictionary %q", c.dictionary)
+	}
+
+	data := []byte(`{"id": 99, "name": "user99", "settings": {"theme": "dark", "notifications": true}}`)
+	var buf bytes.Buffer
+	enc, err := newEncoder(&buf, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc.Write(data)
+	enc.Close()
+
+	if buf.Len() >= len(data)/2 {
+		t.Errorf("compressed to %d of %d bytes", buf.Len(), len(data))
+	}
+
+	got, err := inflateDict(buf.Bytes(), c.dictionary)
+	if err != nil || !bytes.Equal(got, data) {
+		t.Errorf("inflateDict = %q, %v; want %q", got, err, data)
+	}
+}
+
+func TestParallelGzip(t *testing.T) {
+	var data []byte
+	for i := 0; len(data) < 5*minParallelChunk/2; i++ {
+		data = append(data, fmt.Sprintf("line %d of a large asset, %d\n", i, i*i%1000)...)
+	}
+
+	var outputs [][]byte
+	for _, workers := range []int{1, 4} {
+		c := NewConfig()
+		c.ParallelWorkers = workers
+		c.ParallelChunkSize = minParallelChunk
+
+		var buf bytes.Buffer
+		enc, err := newParallelGzip(&buf, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Odd write sizes make chunks span several writes.
+		for rest := data; len(rest) > 0; {
+			n := len(rest)
+			if n > 1000 {
+				n = 1000
+			}
+			if _, err := enc.Write(rest[:n]); err != nil {
+				t.Fatal(err)
+			}
+			rest = rest[n:]
+		}
+		if err := enc.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := decoders[CompressGzip](buf.Bytes())
+		if err != nil || !bytes.Equal(got, data) {
+			t.Fatalf("%d workers: decompressed %d bytes, %v; want %d bytes", workers, len(got), err, len(data))
+		}
+		outputs = append(outputs, buf.Bytes())
+	}
+
+	if !bytes.Equal(outputs[0], outputs[1]) {
+		t.Errorf("output depends on the number of workers")
+	}
+}
+
+func TestWriteBuildConstraint(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeBuildConstraint(&buf, []string{"dev", "linux,386 darwin", "!windows && cgo"}, true)
+	if err != nil {
+		t.Fatalf("expected to be no error: %+v", err)
+	}
+
+	want := "//go:build dev && ((linux && 386) || darwin) && !windows && cgo\n" +
+		"// +build dev\n" +
+		"// +build linux,386 darwin\n" +
+		"// +build !windows\n" +
+		"// +build cgo\n\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	_, err = buildConstraint([]string{"dev &&"})
+	if err == nil {
+		t.Errorf("expected an error for an invalid constraint")
+	}
+}
+
+func TestWriteReleaseJobs(t *testing.T) {
+	dir := t.TempDir()
+	for i := 0; i < 20; i++ {
+		data := bytes.Repeat([]byte(fmt.Sprintf("asset %d ", i)), i*100)
+		err := ioutil.WriteFile(filepath.Join(dir, fmt.Sprintf("%02d.txt", i)), data, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var toc []Asset
+	err := findFiles(dir, dir, false, &toc, &fileFilter{}, make(map[string]int))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var want []byte
+	for _, jobs := range []int{1, 2, 8} {
+		c := NewConfig()
+		c.Jobs = jobs
+
+		var buf bytes.Buffer
+		err = writeRelease(&buf, c, toc, nil)
+		if err != nil {
+			t.Fatalf("jobs=%d: expected to be no error: %+v", jobs, err)
+		}
+
+		if want == nil {
+			want = buf.Bytes()
+		} else if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("jobs=%d: output differs from serial output", jobs)
+		}
+	}
+}
+
+func TestWriteRawString(t *testing.T) {
+	// Place a byte order mark and a backtick right at the chunk boundary.
+	data := append(bytes.Repeat([]byte("a"), chunkSize-1), "\xEF\xBB\xBF`b``\xEF\xBB\xBF`"...)
+
+	var buf bytes.Buffer
+	err := writeRawString(&buf, bytes.NewReader(data), 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := sanitize(data)
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("writeRawString wrote %q, want %q", buf.Bytes()[chunkSize-8:], want[chunkSize-8:])
+	}
+
+	// Raw strings cannot hold carriage returns or NUL bytes,
+	// so such data is written as an interpreted string instead.
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{"a`b\n", true},
+		{"a\r\nb\r\n", false},
+		{"a\x00b", false},
+		{"\xff", false},
+	}
+
+	for _, test := range tests {
+		got, err := rawText(strings.NewReader(test.data))
+		if err != nil || got != test.want {
+			t.Errorf("rawText(%q) = %v, %v; want %v", test.data, got, err, test.want)
+		}
+	}
+}
+
+func TestFallbackName(t *testing.T) {
+	toc := []Asset{{Name: "index.html"}, {Name: "5f2b", label: "404.html"}}
+	tests := []struct {
+		fallback string
+		want     string
+		err      bool
+	}{
+		{"index.html", "index.html", false},
+		{"/index.html", "index.html", false},
+		{"404.html", "5f2b", false},
+		{"500.html", "", true},
+	}
+
+	for _, test := range tests {
+		got, err := fallbackName(&Config{FallbackAsset: test.fallback}, toc)
+		if got != test.want || (err != nil) != test.err {
+			t.Errorf("fallbackName(%s) = %s, %v; want %s", test.fallback, got, err, test.want)
+		}
+	}
+}
+
+func TestSharedData(t *testing.T) {
+	original := &Asset{Compressed: true}
+	tests := []struct {
+		c     Config
+		asset Asset
+		want  bool
+	}{
+		{Config{}, Asset{}, true},
+		{Config{}, Asset{Compressed: true}, false},
+		{Config{CacheDecompressed: true}, Asset{Compressed: true}, true},
+		{Config{EncryptKeyEnv: "KEY"}, Asset{}, false},
+		{Config{}, Asset{original: original}, true},
+		{Config{EncryptKeyEnv: "KEY"}, Asset{original: original}, false},
+	}
+
+	for i, test := range tests {
+		if got := sharedData(&test.c, &test.asset); got != test.want {
+			t.Errorf("%d: sharedData = %v, want %v", i, got, test.want)
+		}
+	}
+}
+
+func TestTextData(t *testing.T) {
+	original := &Asset{Func: "a_txt"}
+	tests := []struct {
+		c     Config
+		asset Asset
+		want  string
+	}{
+		{Config{}, Asset{Func: "a_txt"}, ""},
+		{Config{NoMemCopy: true}, Asset{Func: "a_txt"}, "_a_txt"},
+		{Config{NoUnsafe: true}, Asset{Func: "a_txt"}, "_a_txt.data"},
+		{Config{NoMemCopy: true}, Asset{Func: "a_txt", Compressed: true}, ""},
+		{Config{NoMemCopy: true}, Asset{Func: "b_txt", original: original}, ""},
+		{Config{NoMemCopy: true, NoCompress: true}, Asset{Func: "b_txt", original: original}, "_a_txt"},
+	}
+
+	for i, test := range tests {
+		if got := textData(&test.c, &test.asset); got != test.want {
+			t.Errorf("%d: textData = %q, want %q", i, got, test.want)
+		}
+	}
+}
+
+func TestWriteDataFile(t *testing.T) {
+	c := &Config{DataFile: filepath.Join(t.TempDir(), "assets.dat")}
+	df, err := writeDataFile(c, func(w io.Writer) error {
+		_, err := io.WriteString(w, "hello")
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(c.DataFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256([]byte("hello"))
+	want := "hello\x05\x00\x00\x00\x00\x00\x00\x00" + string(sum[:8]) + dataFileMagic
+	if string(data) != want || df.size != 5 || string(df.id[:]) != string(sum[:8]) {
+		t.Errorf("writeDataFile wrote %q (%d bytes, id %x), want %q", data, df.size, df.id, want)
+	}
+}
+
+func TestAsmWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := &asmWriter{w: &buf, sym: "_a_txt_data"}
+	w.Write([]byte("ab\"\\"))
+	w.Write([]byte("\x00\n\xffcdefg"))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `DATA ·_a_txt_data+0(SB)/8, $"ab\"\\\x00\x0a\xffc"
+DATA ·_a_txt_data+8(SB)/4, $"defg"
+GLOBL ·_a_txt_data(SB), RODATA|NOPTR, $12
+
+`
+	if buf.String() != want {
+		t.Errorf("asmWriter wrote %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	w = &asmWriter{w: &buf, sym: "_empty_data"}
+	if err := w.Close(); err != nil || buf.Len() > 0 {
+		t.Errorf("asmWriter wrote %q, %v for no data, want nothing", buf.String(), err)
+	}
+}
+
+func TestWriteRawStringLimit(t *testing.T) {
+	var buf bytes.Buffer
+	err := writeRawString(&buf, bytes.NewReader([]byte("abcäöü\nxy`z")), 4, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "abcä` +\n\t`öü\nxy`+\"`\"+`z"
+	if buf.String() != want {
+		t.Errorf("writeRawString wrote %q, want %q", buf.String(), want)
+	}
+}
+
+// BenchmarkWriteReleaseAsset shows that memory usage does not depend on
+// the size of the asset, as its contents are streamed to the output.
+func BenchmarkWriteReleaseAsset(b *testing.B) {
+	for _, size := range []int{1 << 20, 16 << 20, 64 << 20} {
+		path := filepath.Join(b.TempDir(), "asset.txt")
+		data := bytes.Repeat([]byte("all work and no play "), size/21)
+		err := ioutil.WriteFile(path, data, 0644)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		for _, compress := range []bool{false, true} {
+			c := NewConfig()
+			c.NoCompress = !compress
+			c.ForceCompress = true
+
+			b.Run(fmt.Sprintf("size=%dMiB/compress=%v", size>>20, compress), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(data)))
+				for i := 0; i < b.N; i++ {
+					asset := Asset{Path: path, Name: "asset.txt", Func: "asset_txt"}
+					err := writeReleaseAsset(ioutil.Discard, c, &asset)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// lookupBenchmark is the benchmark run in the packages generated by
+// BenchmarkLookup, which looks up every asset in turn, without reading
+// its data.
+const lookupBenchmark = `package assets
+
+import "testing"
+
+func BenchmarkLookup(b *testing.B) {
+	names := AssetNames()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := bindata_lookup(names[i%len(names)]); !ok {
+			b.Fatal("Missing asset")
+		}
+	}
+}
+`
+
+// BenchmarkLookup compares the lookup methods for a package of 20,000
+// assets. It reports the time taken to build the tests of the package,
+// to start them, and to look up an asset. Building takes a while, so
+// it is skipped in short mode.
+func BenchmarkLookup(b *testing.B) {
+	if testing.Short() {
+		b.Skip("Building the generated packages takes a while")
+	}
+
+	gotool, err := exec.LookPath("go")
+	if err != nil {
+		b.Skip("The go tool is required to build the generated packages")
+	}
+
+	files := make(map[string][]byte)
+	for i := 0; i < 20000; i++ {
+		files[fmt.Sprintf("static/%03d/asset%05d.txt", i/100, i)] = []byte(fmt.Sprint(i))
+	}
+
+	nsPerOp := regexp.MustCompile(`BenchmarkLookup\S*\s+\d+\s+([0-9.]+) ns/op`)
+	for _, lookup := range []Lookup{LookupMap, LookupSorted, LookupSwitch} {
+		b.Run(lookup.String(), func(b *testing.B) {
+			dir := b.TempDir()
+			c := NewConfig()
+			c.Package = "assets"
+			c.Output = filepath.Join(dir, "bindata.go")
+			c.GrateImport = ""
+			c.Lookup = lookup
+
+			fd, err := os.Create(c.Output)
+			if err == nil {
+				err = TranslateTo(fd, c, AssetsFromMap(files))
+				fd.Close()
+			}
+			if err == nil {
+				err = ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module assets\n\ngo 1.16\n"), 0644)
+			}
+			if err == nil {
+				err = ioutil.WriteFile(filepath.Join(dir, "bindata_test.go"), []byte(lookupBenchmark), 0644)
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			run := func(name string, args ...string) (string, time.Duration) {
+				cmd := exec.Command(name, args...)
+				cmd.Dir = dir
+				cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=")
+				start := time.Now()
+				out, err := cmd.CombinedOutput()
+				if err != nil {
+					b.Fatalf("%s: %v\n%s", name, err, out)
+				}
+				return string(out), time.Since(start)
+			}
+
+			for i := 0; i < b.N; i++ {
+				_, build := run(gotool, "test", "-c", "-o", "assets.test")
+				test := filepath.Join(dir, "assets.test")
+
+				// Most of the time a test binary takes to run
+				// nothing at all is spent starting the process.
+				startup := time.Duration(math.MaxInt64)
+				for j := 0; j < 10; j++ {
+					_, d := run(test, "-test.run=^$")
+					if d < startup {
+						startup = d
+					}
+				}
+
+				out, _ := run(test, "-test.run=^$", "-test.bench=Lookup")
+				m := nsPerOp.FindStringSubmatch(out)
+				if m == nil {
+					b.Fatalf("No benchmark result in %s", out)
+				}
+				ns, _ := strconv.ParseFloat(m[1], 64)
+
+				b.ReportMetric(build.Seconds(), "s/build")
+				b.ReportMetric(float64(startup.Microseconds())/1000, "ms/startup")
+				b.ReportMetric(ns, "ns/lookup")
+			}
+		})
+	}
+}
+
+func TestIncremental(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input")
+	err := os.Mkdir(input, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(name, content string) {
+		err := ioutil.WriteFile(filepath.Join(input, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	provenance, templates := false, ""
+	translate := func(output, cache string) []byte {
+		c := NewConfig()
+		c.Input = []InputConfig{{Path: input}}
+		c.Prefix = input
+		c.Output = filepath.Join(dir, output)
+		c.IncrementalCache = cache
+		c.Jobs = 2
+		c.Provenance = provenance
+		c.TemplateDir = templates
+
+		err := Translate(c)
+		if err != nil {
+			t.Fatalf("expected to be no error: %+v", err)
+		}
+
+		data, err := ioutil.ReadFile(c.Output)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	write("a.txt", "first")
+	write("b.txt", "second")
+	write("c.txt", "third")
+
+	cache := filepath.Join(dir, "bindata.cache")
+	translate("bindata.go", cache)
+
+	write("b.txt", "second, but longer")
+	write("d.txt", "fourth")
+	os.Remove(filepath.Join(input, "a.txt"))
+
+	got := translate("bindata.go", cache)
+	want := translate("full.go", "")
+	if !bytes.Equal(got, want) {
+		t.Errorf("incremental output differs from full output")
+	}
+
+	// Options changing the code of the assets invalidate the cache.
+	provenance = true
+	got = translate("bindata.go", cache)
+	want = translate("full.go", "")
+	if !bytes.Equal(got, want) {
+		t.Errorf("incremental output with provenance differs from full output")
+	}
+
+	// So do changes of the code templates.
+	templates = filepath.Join(dir, "templates")
+	err = os.Mkdir(templates, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, text := range []string{"// CUSTOM\n", "// CHANGED\n"} {
+		err = ioutil.WriteFile(filepath.Join(templates, "asset.tmpl"), []byte(text+codeTemplateText["asset.tmpl"]), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got = translate("bindata.go", cache)
+		want = translate("full.go", "")
+		if !bytes.Equal(got, want) || !bytes.Contains(got, []byte(text)) {
+			t.Errorf("incremental output with template %q differs from full output", text)
+		}
+	}
+}
+
+func TestWatchAndTranslate(t *testing.T) {
+	defer func(interval time.Duration) { WatchInterval = interval }(WatchInterval)
+	WatchInterval = 20 * time.Millisecond
+
+	dir := t.TempDir()
+	input := filepath.Join(dir, "input")
+	err := os.Mkdir(input, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(content string) {
+		err := ioutil.WriteFile(filepath.Join(input, "a.txt"), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("small")
+
+	events := make(chan Event, 100)
+	c := NewConfig()
+	c.Input = []InputConfig{{Path: input}}
+	c.Output = filepath.Join(dir, "bindata.go")
+	c.NoCompress = true
+	c.MaxAssetSize = 10
+	c.Events = func(e Event) {
+		if e.Kind == EventDone || e.Kind == EventError {
+			events <- e
+		}
+	}
+
+	stop := make(chan struct{})
+	result := make(chan error)
+	go func() { result <- WatchAndTranslate(c, stop) }()
+
+	next := func(kind EventKind) {
+		select {
+		case e := <-events:
+			if e.Kind != kind {
+				t.Fatalf("expected %v event, got %v: %s", kind, e.Kind, e.Message)
+			}
+		case <-time.After(10 * time.Second):
+			t.Fatalf("expected %v event", kind)
+		}
+	}
+	next(EventDone)
+
+	// A failed regeneration does not end watching.
+	write("far too large")
+	next(EventError)
+
+	write("fixed")
+	next(EventDone)
+
+	data, err := ioutil.ReadFile(c.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(data, []byte("fixed")) {
+		t.Errorf("expected the output to hold the fixed asset")
+	}
+
+	close(stop)
+	if err := <-result; err != nil {
+		t.Fatalf("expected to be no error: %+v", err)
+	}
+}
+
+func TestReadEmbedded(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"a.txt":   []byte("some `text`"),
+		"b.bin":   {0, 1, 2, 0xff},
+		"c.txt":   bytes.Repeat([]byte("compress me "), 100),
+		"d/e.txt": []byte("\xEF\xBB\xBFbom"),
+		"d/f.txt": bytes.Repeat([]byte("compress me "), 100),
+	}
+	input := filepath.Join(dir, "input")
+	for name, data := range files {
+		file := filepath.Join(input, name)
+		err := os.MkdirAll(filepath.Dir(file), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(file, data, 0644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("BINDATA_TEST_KEY", "000102030405060708090a0b0c0d0e0f")
+
+	for _, layout := range []string{"default", "nomemcopy", "blob", "encrypted", "literals", "sorted", "switch"} {
+		c := NewConfig()
+		c.Input = []InputConfig{{Path: input, Recursive: true}}
+		c.Prefix = input
+		c.Output = filepath.Join(dir, layout+".go")
+		c.NoMemCopy = layout == "nomemcopy"
+		c.SingleBlob = layout == "blob"
+		c.Lookup, _ = ParseLookup(layout)
+		if layout == "literals" {
+			c.MaxLiteralSize = 5
+		}
+		if layout == "encrypted" {
+			c.EncryptKeyEnv = "BINDATA_TEST_KEY"
+		}
+
+		stats, err := TranslateStats(c)
+		if err != nil {
+			t.Fatalf("%s: expected to be no error: %+v", layout, err)
+		}
+		if stats.Duplicates != 1 || stats.Saved == 0 {
+			t.Errorf("%s: found %d duplicates saving %d bytes, want 1", layout, stats.Duplicates, stats.Saved)
+		}
+
+		assets, err := ReadEmbedded(c.Output)
+		if err != nil {
+			t.Fatalf("%s: expected to be no error: %+v", layout, err)
+		}
+		if len(assets) != len(files) {
+			t.Fatalf("%s: found %d assets, want %d", layout, len(assets), len(files))
+		}
+
+		for i := range assets {
+			data, err := assets[i].Bytes()
+			if err != nil {
+				t.Fatalf("%s: %s: expected to be no error: %+v", layout, assets[i].Name, err)
+			}
+			if !bytes.Equal(data, files[assets[i].Name]) {
+				t.Errorf("%s: %s: read %q", layout, assets[i].Name, data)
+			}
+		}
+
+		changes, err := Diff(c)
+		if err != nil || len(changes) != 0 {
+			t.Errorf("%s: Diff = %v, %v; want no changes", layout, changes, err)
+		}
+	}
+}
+
+func TestSortedNames(t *testing.T) {
+	for _, debug := range []bool{false, true} {
+		c := NewConfig()
+		c.Debug = debug
+
+		var buf bytes.Buffer
+		err := writeNames(&buf, c)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if strings.Contains(buf.String(), "var _bindata_names = ") == debug {
+			t.Errorf("debug %v: names are sorted once: %v", debug, !debug)
+		}
+	}
+
+	var buf bytes.Buffer
+	err := TranslateTo(&buf, NewConfig(), []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "func AssetNamesWithPrefix(prefix string) []string {") {
+		t.Errorf("AssetNamesWithPrefix is missing")
+	}
+}
+
+func TestInventory(t *testing.T) {
+	toc := []Asset{
+		{Path: "testdata/dupname/foo_bar", Name: "a"},
+		{Path: "testdata/dupname/foo_bar", Name: "b"},
+	}
+
+	c := NewConfig()
+	c.NoCompress = true
+
+	var buf bytes.Buffer
+	err := TranslateTo(&buf, c, toc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The duplicate shares the data of the first asset.
+	for _, want := range []string{
+		"func AssetsCount() int {\n\treturn 2\n}",
+		"func AssetsSize() int64 {\n\treturn 4\n}",
+		"func AssetsCompressedSize() int64 {\n\treturn 2\n}",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+}
+
+func TestAssetTree(t *testing.T) {
+	c := NewConfig()
+	c.NoCompress = true
+	c.ModTime = 86400
+
+	var buf bytes.Buffer
+	err := TranslateTo(&buf, c, AssetsFromMap(map[string][]byte{
+		"b/c/d.txt": []byte("d"),
+		"b/e.txt":   []byte("ee"),
+		"b.txt":     []byte("bbb"),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = parser.ParseFile(token.NewFileSet(), "bindata.go", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The file infos are in the order of the names, and the assets
+	// are not loaded to obtain them.
+	out := buf.String()
+	for _, want := range []string{
+		"type AssetNode struct {",
+		"func AssetTree() (*AssetNode, error) {",
+		"var _bindata_infos = [...]bindata_info_t{\n\t{3, 420, 86400},\n\t{1, 420, 86400},\n\t{2, 420, 86400},\n}",
+		"if node.Func != nil {\n\t\tn.Info = bindata_info(name)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+}
+
+// cacheTest is the test run in the package generated by TestAssetCache.
+// After reading a, b, a and c, the least recently used b is evicted.
+const cacheTest = `package assets
+
+import "testing"
+
+func TestAssetCache(t *testing.T) {
+	for _, name := range []string{"a.txt", "b.txt", "a.txt", "c.txt"} {
+		if _, err := Asset(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lru := &_bindata_cache_lru
+	if lru.size != 120 || lru.entries.Len() != 2 || _b_txt_cache.entry != nil {
+		t.Errorf("expected a and c to be cached, got %d bytes in %d entries", lru.size, lru.entries.Len())
+	}
+
+	SetAssetCacheSize(60)
+	if lru.size != 60 || _a_txt_cache.entry != nil || _c_txt_cache.entry == nil {
+		t.Errorf("expected c to be cached, got %d bytes in %d entries", lru.size, lru.entries.Len())
+	}
+
+	FlushAssetCache()
+	if lru.size != 0 || lru.entries.Len() != 0 {
+		t.Errorf("expected an empty cache, got %d bytes in %d entries", lru.size, lru.entries.Len())
+	}
+}
+`
+
+func TestAssetCache(t *testing.T) {
+	c := NewConfig()
+	c.Package = "assets"
+	c.GrateImport = ""
+	c.CacheDecompressed = true
+	c.CacheSize = 130
+	c.ForceCompress = true
+
+	files := make(map[string][]byte)
+	for _, name := range []string{"a", "b", "c"} {
+		files[name+".txt"] = bytes.Repeat([]byte(name), 60)
+	}
+
+	var buf bytes.Buffer
+	err := TranslateTo(&buf, c, AssetsFromMap(files))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = parser.ParseFile(token.NewFileSet(), "bindata.go", buf.Bytes(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"_bindata_cache_lru.limit = 130",
+		"func SetAssetCacheSize(bytes int64) {",
+		"func bindata_cache_evict() {",
+		"var _a_txt_cache bindata_cache",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+
+	// The eviction is checked by the tests of the generated package.
+	if testing.Short() {
+		t.Skip("Building the generated package takes a while")
+	}
+	gotool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("The go tool is required to build the generated package")
+	}
+
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{
+		"go.mod":          []byte("module assets\n\ngo 1.16\n"),
+		"bindata.go":      buf.Bytes(),
+		"bindata_test.go": []byte(cacheTest),
+	} {
+		err := ioutil.WriteFile(filepath.Join(dir, name), data, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := exec.Command(gotool, "test", "-count=1", ".")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GO111MODULE=on", "GOFLAGS=")
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Errorf("%v\n%s", err, out)
+	}
+}
+
+func TestPoolReaders(t *testing.T) {
+	files := map[string][]byte{"a.txt": bytes.Repeat([]byte("a"), 1000)}
+
+	for _, tt := range []struct {
+		compression Compression
+		pool        bool
+		valid       bool
+	}{
+		{CompressGzip, true, true},
+		{CompressGzip, false, true},
+		{CompressZstd, true, false},
+		{CompressBrotli, true, false},
+	} {
+		c := NewConfig()
+		c.Compression = tt.compression
+		c.PoolReaders = tt.pool
+
+		var buf bytes.Buffer
+		err := TranslateTo(&buf, c, AssetsFromMap(files))
+		if (err == nil) != tt.valid {
+			t.Errorf("%v with pooling %v: unexpected error %v", tt.compression, tt.pool, err)
+		}
+
+		pooled := strings.Contains(buf.String(), "_bindata_gzip_pool")
+		if pooled != (tt.pool && tt.valid) {
+			t.Errorf("%v with pooling %v: expected pool %v", tt.compression, tt.pool, !pooled)
+		}
+		if !pooled {
+			continue
+		}
+
+		_, err = parser.ParseFile(token.NewFileSet(), "bindata.go", buf.Bytes(), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestProvenance(t *testing.T) {
+	c := NewConfig()
+	c.Provenance = true
+	c.ModTime = 86400
+
+	var buf bytes.Buffer
+	err := TranslateTo(&buf, c, []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sum := sha256.Sum256([]byte("b\n"))
+	want := fmt.Sprintf(`// foo_bar returns the asset "foo_bar".
+//
+// Source: testdata/dupname/foo_bar
+// Size: 2
+// SHA-256: %x
+// Modified: 1970-01-02T00:00:00Z
+func foo_bar() (*asset, error) {`, sum)
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("missing provenance comment:\n%s", buf.String())
+	}
+
+	c.NameSalt = "salt"
+	err = TranslateTo(ioutil.Discard, c, []Asset{{Path: "testdata/dupname/foo_bar", Name: "foo_bar"}})
+	if err == nil {
+		t.Errorf("expected an error with name obfuscation")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, data string) {
+		file := filepath.Join(dir, name)
+		err := os.MkdirAll(filepath.Dir(file), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(file, []byte(data), 0644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	output := filepath.Join(dir, "out")
+	run := func(input string) {
+		c := NewConfig()
+		c.Input = []InputConfig{{Path: filepath.Join(dir, input), Recursive: true}}
+		c.Prefix = dir
+		c.Output = output
+		c.SplitOutput = true
+		c.Merge = true
+
+		err := Translate(c)
+		if err != nil {
+			t.Fatalf("%s: expected to be no error: %+v", input, err)
+		}
+	}
+
+	write("web/app.css", "shared")
+	write("web/index.html", "index")
+	write("zz/copy.css", "shared")
+	run("web")
+	run("zz")
+
+	// The copy shares the data of web/app.css, which changes.
+	write("web/app.css", "changed")
+	os.Remove(filepath.Join(dir, "web/index.html"))
+	run("web")
+
+	assets, err := ReadEmbedded(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{"web/app.css": "changed", "zz/copy.css": "shared"}
+	if len(assets) != len(want) {
+		t.Fatalf("found %d assets, want %d", len(assets), len(want))
+	}
+
+	for i := range assets {
+		data, err := assets[i].Bytes()
+		if err != nil {
+			t.Fatalf("%s: expected to be no error: %+v", assets[i].Name, err)
+		}
+		if string(data) != want[assets[i].Name] {
+			t.Errorf("%s: read %q", assets[i].Name, data)
+		}
+	}
+}
+
+func TestUncompressedRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"crlf.txt": []byte("a\r\nb\r\n"),
+		"nul.txt":  []byte("a\x00b"),
+		"text.txt": []byte("a`b\n"),
+	}
+
+	input := filepath.Join(dir, "input")
+	err := os.Mkdir(input, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range files {
+		err = ioutil.WriteFile(filepath.Join(input, name), data, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Small files are not worth compressing with the default config.
+	c := NewConfig()
+	c.Input = []InputConfig{{Path: input}}
+	c.Prefix = input
+	c.Output = filepath.Join(dir, "bindata.go")
+
+	stats, err := TranslateStats(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, file := range stats.Files {
+		if file.Compressed {
+			t.Errorf("%s: expected to be embedded uncompressed", file.Name)
+		}
+	}
+
+	assets, err := ReadEmbedded(c.Output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range assets {
+		data, err := assets[i].Bytes()
+		if err != nil {
+			t.Fatalf("%s: expected to be no error: %+v", assets[i].Name, err)
+		}
+		if !bytes.Equal(data, files[assets[i].Name]) {
+			t.Errorf("%s: read %q", assets[i].Name, data)
+		}
+	}
+}
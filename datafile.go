@@ -0,0 +1,215 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"io"
+	"path/filepath"
+)
+
+// dataFileMagic ends the trailer of a data file.
+const dataFileMagic = "BINDATA\x00"
+
+// validateDataFile ensures a data file is only
+// used along with options supporting it.
+func validateDataFile(c *Config) error {
+	if len(c.DataFile) == 0 || c.Debug {
+		return nil
+	}
+
+	switch {
+	case !c.SingleBlob:
+		return fmt.Errorf("Data file requires the SingleBlob layout")
+	case c.stringData():
+		return fmt.Errorf("Data file cannot be combined with NoMemCopy or NoUnsafe")
+	case c.Assembly:
+		return fmt.Errorf("Data file cannot be combined with assembly output")
+	case c.SelfTest:
+		return fmt.Errorf("Self tests cannot be combined with a data file")
+	}
+
+	return nil
+}
+
+// dataFile holds the identifier and size of the
+// data written to the data file.
+type dataFile struct {
+	id   [8]byte
+	size int64
+}
+
+// writeDataFile writes the data file, whose contents are written by fn.
+// They are followed by a trailer holding their size, an identifier taken
+// from their SHA-256 digest and a magic, by which the generated loader
+// finds and checks the data, even if it is appended to an executable.
+func writeDataFile(c *Config, fn func(w io.Writer) error) (*dataFile, error) {
+	df := new(dataFile)
+	err := writeFile(c, c.DataFile, func(w io.Writer) error {
+		dw := &dataWriter{Writer: w, h: sha256.New()}
+		err := fn(dw)
+		if err != nil {
+			return err
+		}
+
+		copy(df.id[:], dw.h.Sum(nil))
+		df.size = dw.n
+
+		var trailer [24]byte
+		binary.LittleEndian.PutUint64(trailer[:8], uint64(df.size))
+		copy(trailer[8:16], df.id[:])
+		copy(trailer[16:], dataFileMagic)
+		_, err = w.Write(trailer[:])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return df, nil
+}
+
+// dataWriter hashes and counts the data written through it.
+type dataWriter struct {
+	io.Writer
+	h hash.Hash
+	n int64
+}
+
+func (w *dataWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.h.Write(p[:n])
+	w.n += int64(n)
+	return n, err
+}
+
+// writeDataFileInfo writes the constants identifying the data file.
+func writeDataFileInfo(w io.Writer, c *Config, df *dataFile) error {
+	_, err := fmt.Fprintf(w, `// _bindata_data_name is the name of the data file holding the assets.
+const _bindata_data_name = %q
+
+// _bindata_data_id and _bindata_data_size identify the data
+// written along with this code.
+const (
+	_bindata_data_id   = %s
+	_bindata_data_size = %d
+)
+
+`, filepath.Base(c.DataFile), bytesLiteral(df.id[:]), df.size)
+	return err
+}
+
+// writeDataLoader writes SetAssetData and the functions locating the
+// data file at runtime. Debug builds read the assets from disk, so
+// SetAssetData does nothing.
+func writeDataLoader(w io.Writer, c *Config) error {
+	if c.Debug {
+		_, err := fmt.Fprintf(w, `// SetAssetData sets the reader holding the data file of the assets.
+// In debug builds, the assets are read from disk, so it does nothing.
+func SetAssetData(r io.ReaderAt, size int64) error {
+	return nil
+}
+
+`)
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, `var (
+	_bindata_data_mu sync.Mutex
+	_bindata_data    io.ReaderAt
+)
+
+// SetAssetData sets the reader holding the data file of the assets, which
+// is otherwise looked for at the end of the executable, then next to it.
+// The reader holds size bytes, which end in the data file written along
+// with this code. It returns an error if the data does not match.
+func SetAssetData(r io.ReaderAt, size int64) error {
+	data, err := bindata_open_data(r, size)
+	if err != nil {
+		return err
+	}
+
+	_bindata_data_mu.Lock()
+	_bindata_data = data
+	_bindata_data_mu.Unlock()
+	return nil
+}
+
+// bindata_data returns the reader for the asset data, opening the
+// data file on first use.
+func bindata_data() (io.ReaderAt, error) {
+	_bindata_data_mu.Lock()
+	defer _bindata_data_mu.Unlock()
+
+	if _bindata_data != nil {
+		return _bindata_data, nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("Asset data not found: %%w", err)
+	}
+
+	for _, path := range []string{exe, filepath.Join(filepath.Dir(exe), _bindata_data_name)} {
+		data, err := bindata_open_file(path)
+		if err == nil {
+			_bindata_data = data
+			return data, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Asset data not found: append %%s to the executable or place it next to it", _bindata_data_name)
+}
+
+// bindata_open_file returns the asset data at the end of the named file.
+// The file is kept open for reading the assets.
+func bindata_open_file(path string) (io.ReaderAt, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := fd.Stat()
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	data, err := bindata_open_data(fd, fi.Size())
+	if err != nil {
+		fd.Close()
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// bindata_open_data returns the asset data at the end of r, if its
+// trailer matches the data written along with this code.
+func bindata_open_data(r io.ReaderAt, size int64) (io.ReaderAt, error) {
+	var trailer [24]byte
+	if size < int64(len(trailer))+_bindata_data_size {
+		return nil, fmt.Errorf("Asset data is too short")
+	}
+
+	_, err := r.ReadAt(trailer[:], size-int64(len(trailer)))
+	if err != nil {
+		return nil, fmt.Errorf("Read asset data: %%w", err)
+	}
+
+	if string(trailer[16:]) != %q || string(trailer[8:16]) != _bindata_data_id ||
+		binary.LittleEndian.Uint64(trailer[:8]) != _bindata_data_size {
+		return nil, fmt.Errorf("Asset data does not match the generated code")
+	}
+
+	return io.NewSectionReader(r, size-int64(len(trailer))-_bindata_data_size, _bindata_data_size), nil
+}
+
+`, dataFileMagic)
+	return err
+}
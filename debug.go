@@ -7,11 +7,13 @@ package bindata
 import (
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 )
 
 // writeDebug writes the debug code file.
-func writeDebug(w io.Writer, toc []Asset) error {
-	err := writeDebugHeader(w)
+func writeDebug(w io.Writer, c *Config, toc []Asset) error {
+	err := writeDebugHeader(w, c)
 	if err != nil {
 		return err
 	}
@@ -21,6 +23,7 @@ func writeDebug(w io.Writer, toc []Asset) error {
 		if err != nil {
 			return err
 		}
+		c.assetWritten(toc, i)
 	}
 
 	return nil
@@ -28,54 +31,335 @@ func writeDebug(w io.Writer, toc []Asset) error {
 
 // writeDebugHeader writes output file headers.
 // This targets debug builds.
-func writeDebugHeader(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `import (
-	"fmt"
-	"io/ioutil"
-	"strings"
-	"os"
-	"path"
-	"path/filepath"
-)
+func writeDebugHeader(w io.Writer, c *Config) error {
+	// Assets found at runtime are renamed like those found now.
+	var rewrite string
+	if len(c.Rewrites) > 0 {
+		rewrite = "\n\t\tname = bindata_rewrite(name)"
+	}
 
-// bindata_read reads the given file from disk. It returns an error on failure.
+	_, err := fmt.Fprintf(w, `// bindata_read reads the given file from disk. It returns an error on failure.
 func bindata_read(path, name string) ([]byte, error) {
-	buf, err := ioutil.ReadFile(path)
+	buf, err := %s(path)
 	if err != nil {
-		err = fmt.Errorf("Error reading asset %%s at %%s: %%v", name, path, err)
+		err = fmt.Errorf("Error reading asset %%s at %%s: %%w", name, path, err)
 	}
 	return buf, err
 }
 
+// bindata_load reads the asset and its file info from disk.
+func bindata_load(path, name string) (*asset, error) {
+	bytes, err := bindata_read(path, name)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		err = fmt.Errorf("Error reading asset info %%s at %%s: %%w", name, path, err)
+	}
+
+	a := &asset{bytes: bytes, info: fi}
+	return a, err
+}
+
 type asset struct {
-	bytes []byte
-	info  os.FileInfo
+	bytes  []byte
+	info   os.FileInfo
+	shared bool   // bytes are kept by the package
+	text   string // bytes as a string constant, if they are kept in one
+}
+
+// bindata_root describes an input directory, which is scanned
+// for assets whenever the table of contents is requested.
+type bindata_root struct {
+	path      string // Absolute directory path.
+	match     string // Directory path as matched against the patterns.
+	prefix    string // Prefix stripped from the asset names.
+	recursive bool
+	ignore    []*regexp.Regexp
+	include   []string
+	rules     []bindata_rule // Rules of the .bindataignore file.
+}
+
+// bindata_rule is a rule of a .bindataignore file, matched against
+// slash separated paths relative to the input directory.
+type bindata_rule struct {
+	pattern *regexp.Regexp
+	negate  bool
+	dir     bool
 }
 
-`)
+// bindata_lookup returns the generator for the asset with the given name.
+// Assets which did not exist at generation time are found by scanning
+// the input directories.
+func bindata_lookup(name string) (func() (*asset, error), bool) {
+	if f, ok := _bindata[name]; ok {
+		return f, ok
+	}
+	f, ok := bindata_toc()[name]
+	return f, ok
+}
+
+// bindata_toc scans the input directories and returns
+// the current table of contents.
+func bindata_toc() map[string]func() (*asset, error) {
+	toc := make(map[string]func() (*asset, error))
+	for name, path := range _bindata_files {
+		toc[name] = bindata_file(path, name)
+	}
+	for i := range _bindata_roots {
+		root := &_bindata_roots[i]
+		bindata_scan(toc, root, root.path, root.match)
+	}
+	return toc
+}
+
+// bindata_scan adds all files found in the given directory to the toc.
+func bindata_scan(toc map[string]func() (*asset, error), root *bindata_root, dir, match string) {
+	list, err := %s(dir)
+	if err != nil {
+		return
+	}
+	for _, fi := range list {
+		p := filepath.Join(dir, fi.Name())
+		m := filepath.Join(match, fi.Name())
+		if root.ignored(m, fi.IsDir()) {
+			continue
+		}
+		if fi.IsDir() {
+			if root.recursive {
+				bindata_scan(toc, root, p, m)
+			}
+			continue
+		}
+		if !root.included(m) {
+			continue
+		}
+		name := filepath.ToSlash(m)
+		if strings.HasPrefix(name, root.prefix) {
+			name = name[len(root.prefix):]
+		}
+		name = strings.TrimPrefix(name, "/")%s
+		toc[name] = bindata_file(p, name)
+	}
+}
+
+// ignored reports whether the given file matches an ignore pattern,
+// or is ignored by the rules, where the last matching one decides.
+func (root *bindata_root) ignored(file string, dir bool) bool {
+	for _, re := range root.ignore {
+		if re.MatchString(file) {
+			return true
+		}
+	}
+	rel, err := filepath.Rel(root.match, file)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+	ignored := false
+	for _, r := range root.rules {
+		if (!r.dir || dir) && r.pattern.MatchString(rel) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// included reports whether the given file matches the include patterns.
+func (root *bindata_root) included(file string) bool {
+	if len(root.include) == 0 {
+		return true
+	}
+	rel, err := filepath.Rel(root.match, file)
+	if err != nil {
+		rel = file
+	}
+	rel = filepath.ToSlash(rel)
+	for _, pattern := range root.include {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// bindata_file returns a generator reading the given file from disk.
+func bindata_file(path, name string) func() (*asset, error) {
+	return func() (*asset, error) {
+		return bindata_load(path, name)
+	}
+}
+
+// bindata_tree builds the asset tree from the current table of contents,
+// and the recorded directories.
+func bindata_tree() *_bintree_t {
+	tree := &_bintree_t{Children: map[string]*_bintree_t{}}
+	for name, f := range bindata_toc() {
+		node := tree
+		for _, p := range strings.Split(name, "/") {
+			child := node.Children[p]
+			if child == nil {
+				child = &_bintree_t{Children: map[string]*_bintree_t{}}
+				node.Children[p] = child
+			}
+			node = child
+		}
+		node.Func = f
+	}
+	for name := range _bindata_dirs {
+		node := tree
+		for _, p := range strings.Split(name, "/") {
+			if node.Func != nil {
+				break
+			}
+			child := node.Children[p]
+			if child == nil {
+				child = &_bintree_t{Children: map[string]*_bintree_t{}}
+				node.Children[p] = child
+			}
+			node = child
+		}
+	}
+	return tree
+}
+
+`, c.ioutil("ReadFile"), c.ioutil("ReadDir"), rewrite)
+	if err != nil {
+		return err
+	}
+
+	if len(c.Rewrites) > 0 {
+		err = writeDebugRewrites(w, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeDebugRoots(w, c)
+}
+
+// writeDebugRoots writes the input directories and patterns needed
+// to scan for assets at runtime. Inputs which name a single file
+// are recorded as they are.
+func writeDebugRoots(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, "var _bindata_roots = []bindata_root{\n")
+	if err != nil {
+		return err
+	}
+
+	var files []Asset
+	for i := range c.Input {
+		input := &c.Input[i]
+		filter := newFilter(c, input)
+
+		fi, err := os.Stat(input.Path)
+		if err != nil {
+			return err
+		}
+
+		if !fi.IsDir() {
+			err = findFile(c, input, &files, make(map[string]int))
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		err = writeDebugRoot(w, c, input, filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\nvar _bindata_files = map[string]string{\n")
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range files {
+		_, err = fmt.Fprintf(w, "\t%q: %q,\n", c.rewriteName(asset.Name), asset.Path)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
 	return err
 }
 
-// writeDebugAsset write a debug entry for the given asset.
-// A debug entry is simply a function which reads the asset from
-// the original file (e.g.: from disk).
-func writeDebugAsset(w io.Writer, asset *Asset) error {
-	_, err := fmt.Fprintf(w, `// %s reads file data from disk. It returns an error on failure.
-func %s() (*asset, error) {
-	path := %q
-	name := %q
-	bytes, err := bindata_read(path, name)
+// writeDebugRoot writes the bindata_root entry for an input directory.
+func writeDebugRoot(w io.Writer, c *Config, input *InputConfig, filter *fileFilter) error {
+	path, _ := filepath.Abs(input.Path)
+	match, prefix := resolvePrefix(input.Path, c.prefix(input))
+
+	_, err := fmt.Fprintf(w, "\t{\n\t\tpath:      %q,\n\t\tmatch:     %q,\n\t\tprefix:    %q,\n\t\trecursive: %v,\n\t\tignore: []*regexp.Regexp{\n",
+		path, match, prefix, input.Recursive)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	fi, err := os.Stat(path)
+	for _, re := range filter.ignore {
+		_, err = fmt.Fprintf(w, "\t\t\tregexp.MustCompile(%q),\n", re.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\t\t},\n\t\tinclude: []string{\n")
 	if err != nil {
-		err = fmt.Errorf("Error reading asset info %%s at %%s: %%v", name, path, err)
+		return err
 	}
 
-	a := &asset{bytes: bytes, info: fi}
-	return a, err
+	for _, pattern := range filter.include {
+		_, err = fmt.Fprintf(w, "\t\t\t%q,\n", pattern)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\t\t},\n")
+	if err != nil {
+		return err
+	}
+
+	// The rules are read now, so changes to the
+	// ignore file require generating the code again.
+	if filter.rules != nil {
+		_, err = fmt.Fprintf(w, "\t\trules: []bindata_rule{\n")
+		if err != nil {
+			return err
+		}
+
+		for _, rule := range filter.rules.rules {
+			_, err = fmt.Fprintf(w, "\t\t\t{regexp.MustCompile(%q), %v, %v},\n", rule.pattern.String(), rule.negate, rule.dir)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(w, "\t\t},\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\t},\n")
+	return err
+}
+
+// writeDebugAsset write a debug entry for the given asset.
+// A debug entry is simply a function which reads the asset from
+// the original file (e.g.: from disk).
+func writeDebugAsset(w io.Writer, asset *Asset) error {
+	_, err := fmt.Fprintf(w, `// %s reads file data from disk. It returns an error on failure.
+func %s() (*asset, error) {
+	return bindata_load(%q, %q)
 }
 
 `, asset.Func, asset.Func, asset.Path, asset.Name)
@@ -0,0 +1,104 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+)
+
+// dedupe reports whether identical assets share their data. This
+// applies to release builds, except for incremental regeneration,
+// where the code of an asset must not depend on any other asset.
+func (c *Config) dedupe() bool {
+	return !c.Debug && (len(c.IncrementalCache) == 0 || c.SingleBlob || c.SplitOutput)
+}
+
+// findDuplicates marks the assets whose contents are the same as those
+// of an earlier asset in the table of contents. Only assets of equal
+// size are read and hashed to compare them.
+func findDuplicates(toc []Asset) error {
+	bySize := make(map[int64][]int)
+	for i := range toc {
+		fi, err := toc[i].stat()
+		if err != nil {
+			return err
+		}
+
+		bySize[fi.Size()] = append(bySize[fi.Size()], i)
+	}
+
+	for _, list := range bySize {
+		if len(list) < 2 {
+			continue
+		}
+
+		seen := make(map[[sha256.Size]byte]*Asset)
+		for _, i := range list {
+			digest, err := assetDigest(&toc[i])
+			if err != nil {
+				return err
+			}
+
+			toc[i].Digest = digest
+			if original, ok := seen[digest]; ok {
+				toc[i].original = original
+			} else {
+				seen[digest] = &toc[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+// assetDigest returns the SHA-256 sum of the contents of the asset.
+func assetDigest(asset *Asset) ([sha256.Size]byte, error) {
+	var digest [sha256.Size]byte
+
+	fd, err := asset.open()
+	if err != nil {
+		return digest, err
+	}
+
+	defer fd.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, fd)
+	copy(digest[:], h.Sum(nil))
+	return digest, err
+}
+
+// copyDuplicates copies the results of encoding the original
+// assets to their duplicates, once all assets were written.
+func copyDuplicates(toc []Asset) {
+	for i := range toc {
+		original := toc[i].original
+		if original == nil {
+			continue
+		}
+
+		toc[i].Digest = original.Digest
+		toc[i].Size = original.Size
+		toc[i].EmbeddedSize = original.EmbeddedSize
+		toc[i].Compressed = original.Compressed
+	}
+}
+
+// writeDuplicateAsset writes a release entry for an asset, which
+// returns the data of the original asset with the same contents.
+func writeDuplicateAsset(w io.Writer, c *Config, asset *Asset) error {
+	_, err := fmt.Fprintf(w, `func %s_bytes() ([]byte, error) {
+	return %s_bytes()
+}
+
+`, asset.Func, asset.original.Func)
+	if err != nil {
+		return err
+	}
+
+	return asset_release_common(w, c, asset)
+}
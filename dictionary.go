@@ -0,0 +1,296 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"compress/flate"
+	"container/heap"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// dictSize is the size of the deflate window. Longer
+	// dictionaries are not of any use.
+	dictSize = 32 * 1024
+
+	// dictSample and dictSamples limit the data read for training,
+	// from each asset and from all assets together.
+	dictSample  = 16 * 1024
+	dictSamples = 8 * 1024 * 1024
+
+	// dictSegment is the length of the pieces of assets
+	// making up the dictionary.
+	dictSegment = 128
+
+	// dictKmer is the length of the byte sequences counted
+	// while training.
+	dictKmer = 8
+)
+
+// sharedDictionary reports whether assets are compressed
+// with a shared dictionary.
+func (c *Config) sharedDictionary() bool {
+	return c.SharedDictionary && !c.Debug && c.compression() == CompressGzip
+}
+
+// validateDictionary ensures a shared dictionary is only
+// used along with options supporting it.
+func validateDictionary(c *Config) error {
+	if !c.SharedDictionary || c.Debug {
+		return nil
+	}
+
+	if c.compression() != CompressGzip {
+		return fmt.Errorf("Shared dictionary requires gzip compression")
+	}
+
+	if len(c.IncrementalCache) > 0 {
+		return fmt.Errorf("Shared dictionary cannot be combined with an incremental cache")
+	}
+
+	return nil
+}
+
+// trainDictionary builds the shared dictionary from the given assets,
+// if enabled. Duplicates must have been marked already.
+//
+// The dictionary is assembled from the pieces of assets holding the most
+// byte sequences found in other assets as well. Pieces are picked in turn,
+// and sequences already held by the dictionary do not count for later
+// ones. The most useful pieces end up last, closest to the data.
+func trainDictionary(c *Config, toc []Asset) error {
+	c.dictionary = nil
+	if !c.sharedDictionary() {
+		return nil
+	}
+
+	samples, err := readSamples(toc)
+	if err != nil {
+		return err
+	}
+
+	t := newTrainer(samples)
+	picked := t.pick(dictSize / dictSegment)
+
+	dict := make([]byte, 0, dictSize)
+	for i := len(picked) - 1; i >= 0; i-- {
+		s := picked[i]
+		dict = append(dict, samples[s.sample][s.start:s.end]...)
+	}
+
+	c.dictionary = dict
+	return nil
+}
+
+// readSamples reads the start of every asset, except duplicates
+// and files of a known compressed format.
+func readSamples(toc []Asset) ([][]byte, error) {
+	var samples [][]byte
+	total := 0
+	for i := range toc {
+		asset := &toc[i]
+		if asset.original != nil || incompressible[strings.ToLower(filepath.Ext(asset.Path))] {
+			continue
+		}
+
+		fd, err := asset.open()
+		if err != nil {
+			return nil, err
+		}
+
+		sample, err := ioutil.ReadAll(io.LimitReader(fd, dictSample))
+		fd.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Read %s: %v", asset.origin(), err)
+		}
+
+		if len(sample) < dictKmer {
+			continue
+		}
+
+		samples = append(samples, sample)
+		total += len(sample)
+		if total >= dictSamples {
+			break
+		}
+	}
+	return samples, nil
+}
+
+// segment is a piece of a sample, which may become
+// part of the dictionary.
+type segment struct {
+	sample, start, end int
+	score              int
+}
+
+// trainer picks the segments making up the dictionary.
+type trainer struct {
+	samples [][]byte
+
+	// freq counts the samples holding each sequence. Sequences
+	// taken into the dictionary are reset to zero.
+	freq map[uint64]int
+}
+
+func newTrainer(samples [][]byte) *trainer {
+	t := &trainer{samples: samples, freq: make(map[uint64]int)}
+
+	seen := make(map[uint64]int)
+	for i, sample := range samples {
+		for j := 0; j+dictKmer <= len(sample); j++ {
+			k := binary.LittleEndian.Uint64(sample[j:])
+			if seen[k] != i+1 {
+				seen[k] = i + 1
+				t.freq[k]++
+			}
+		}
+	}
+
+	return t
+}
+
+// score returns the number of samples sharing each sequence
+// of the segment, summed over its distinct sequences. Sequences
+// found in a single sample do not count.
+func (t *trainer) score(s segment) int {
+	sample := t.samples[s.sample]
+	seen := make(map[uint64]bool, s.end-s.start)
+	score := 0
+	for j := s.start; j+dictKmer <= s.end; j++ {
+		k := binary.LittleEndian.Uint64(sample[j:])
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		if f := t.freq[k]; f > 1 {
+			score += f
+		}
+	}
+	return score
+}
+
+// take marks the sequences of the segment as held by the dictionary.
+func (t *trainer) take(s segment) {
+	sample := t.samples[s.sample]
+	for j := s.start; j+dictKmer <= s.end; j++ {
+		k := binary.LittleEndian.Uint64(sample[j:])
+		if _, ok := t.freq[k]; ok {
+			t.freq[k] = 0
+		}
+	}
+}
+
+// pick returns up to n segments, ordered from the most useful one.
+// Scores only drop as segments are picked, so the score of a segment
+// is only updated once it comes out on top.
+func (t *trainer) pick(n int) []segment {
+	var h segmentHeap
+	for i, sample := range t.samples {
+		for start := 0; start+dictKmer <= len(sample); start += dictSegment {
+			end := start + dictSegment
+			if end > len(sample) {
+				end = len(sample)
+			}
+			s := segment{sample: i, start: start, end: end}
+			s.score = t.score(s)
+			if s.score > 0 {
+				h = append(h, s)
+			}
+		}
+	}
+	heap.Init(&h)
+
+	var picked []segment
+	for len(picked) < n && h.Len() > 0 {
+		s := heap.Pop(&h).(segment)
+		s.score = t.score(s)
+		if s.score == 0 {
+			continue
+		}
+		if h.Len() > 0 && h.less(h[0], s) {
+			heap.Push(&h, s)
+			continue
+		}
+
+		t.take(s)
+		picked = append(picked, s)
+	}
+	return picked
+}
+
+// segmentHeap orders segments by descending score, and by
+// their position for equal scores, to keep the output stable.
+type segmentHeap []segment
+
+func (h segmentHeap) less(a, b segment) bool {
+	if a.score != b.score {
+		return a.score > b.score
+	}
+	if a.sample != b.sample {
+		return a.sample < b.sample
+	}
+	return a.start < b.start
+}
+
+func (h segmentHeap) Len() int            { return len(h) }
+func (h segmentHeap) Less(i, j int) bool  { return h.less(h[i], h[j]) }
+func (h segmentHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *segmentHeap) Push(x interface{}) { *h = append(*h, x.(segment)) }
+
+func (h *segmentHeap) Pop() interface{} {
+	old := *h
+	s := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return s
+}
+
+// inflateDict decompresses deflate data written with the given dictionary.
+func inflateDict(data, dict []byte) ([]byte, error) {
+	fr := flate.NewReaderDict(bytes.NewReader(data), dict)
+	defer fr.Close()
+	return ioutil.ReadAll(fr)
+}
+
+// writeDictionary writes the shared dictionary.
+func writeDictionary(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// _bindata_dict is the dictionary shared by all compressed assets.
+var _bindata_dict = "`)
+	if err != nil {
+		return err
+	}
+
+	_, err = newStringWriter(w, c).Write(c.dictionary)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `"
+
+var _bindata_dict_bytes = []byte(_bindata_dict)
+
+func bindata_decompress(data []byte, name string) ([]byte, error) {
+	fr := flate.NewReaderDict(bytes.NewReader(data), _bindata_dict_bytes)
+
+	var buf bytes.Buffer
+	_, err := io.Copy(&buf, fr)
+	fr.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %%q: %%w", name, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+`)
+	return err
+}
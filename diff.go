@@ -0,0 +1,123 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// ChangeKind describes how an asset changed.
+type ChangeKind int
+
+// Kinds of changes reported by Diff.
+const (
+	AssetAdded    ChangeKind = iota // The asset is missing from the generated code.
+	AssetRemoved                    // The asset no longer exists in the inputs.
+	AssetModified                   // The contents of the asset differ.
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case AssetAdded:
+		return "added"
+	case AssetRemoved:
+		return "removed"
+	case AssetModified:
+		return "modified"
+	}
+	return fmt.Sprintf("ChangeKind(%d)", int(k))
+}
+
+// Change describes an asset, which differs between the inputs
+// and the generated code.
+type Change struct {
+	Name string
+	Kind ChangeKind
+}
+
+// Diff compares the assets found in the inputs of the given configuration
+// with the assets embedded in the code previously written to its output.
+// Assets are compared by name and contents. The changes are sorted by name.
+// An empty result means the generated code is up to date.
+func Diff(c *Config) ([]Change, error) {
+	output := c.Output
+	if c.SplitOutput {
+		output = c.splitDir()
+	}
+
+	embedded, err := ReadEmbedded(output)
+	if err != nil {
+		return nil, err
+	}
+
+	toc, _, cleanup, err := findAssets(c)
+	defer cleanup()
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make(map[string]*Asset, len(toc))
+	for i := range toc {
+		sources[toc[i].Name] = &toc[i]
+	}
+
+	var changes []Change
+	for i := range embedded {
+		name := embedded[i].Name
+		source, ok := sources[name]
+		if !ok {
+			changes = append(changes, Change{name, AssetRemoved})
+			continue
+		}
+
+		delete(sources, name)
+
+		equal, err := sameContents(&embedded[i], source.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !equal {
+			changes = append(changes, Change{name, AssetModified})
+		}
+	}
+
+	for name := range sources {
+		changes = append(changes, Change{name, AssetAdded})
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Name < changes[j].Name })
+	return changes, nil
+}
+
+// sameContents reports whether the embedded asset holds
+// the contents of the given file.
+func sameContents(asset *EmbeddedAsset, path string) (bool, error) {
+	data, err := asset.Bytes()
+	if err != nil {
+		return false, fmt.Errorf("Asset %s: %v", asset.Name, err)
+	}
+
+	fd, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+
+	defer fd.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, fd)
+	if err != nil {
+		return false, err
+	}
+
+	sum := sha256.Sum256(data)
+	return bytes.Equal(sum[:], h.Sum(nil)), nil
+}
@@ -0,0 +1,103 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// writeDigests writes the AssetDigest and Digests functions.
+// Release builds embed the digests computed during generation,
+// debug builds hash the assets when asked.
+func writeDigests(w io.Writer, c *Config, toc []Asset) error {
+	if c.Debug {
+		return writeDebugDigests(w, c)
+	}
+
+	_, err := fmt.Fprintf(w, `// AssetDigest returns the SHA-256 digest of the asset with the given name.
+// The digest is computed during generation.
+func AssetDigest(name %s) ([32]byte, error) {
+	cannonicalName := bindata_clean(%s)
+	if d, ok := _bindata_digests[cannonicalName]; ok {
+		return d, nil
+	}
+	return [32]byte{}, fmt.Errorf("%%w: %%s", ErrAssetNotFound, name)
+}
+
+// Digests returns the SHA-256 digests of all assets, mapped to their names.
+func Digests() map[string][32]byte {
+	digests := make(map[string][32]byte, len(_bindata_digests))
+	for name, d := range _bindata_digests {
+		digests[name] = d
+	}
+	return digests
+}
+
+// _bindata_digests holds the SHA-256 digest of each asset, mapped to its name.
+var _bindata_digests = map[string][32]byte{
+`, c.nameType(), c.stringArg("name"))
+	if err != nil {
+		return err
+	}
+
+	for i := range toc {
+		_, err = fmt.Fprintf(w, "\t%q: {", toc[i].Name)
+		if err != nil {
+			return err
+		}
+
+		for j, b := range toc[i].Digest {
+			if j > 0 {
+				_, err = fmt.Fprintf(w, ", ")
+				if err != nil {
+					return err
+				}
+			}
+
+			_, err = fmt.Fprintf(w, "0x%02x", b)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, err = fmt.Fprintf(w, "},\n")
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
+
+// writeDebugDigests writes digest functions, which hash
+// the assets read from disk.
+func writeDebugDigests(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// AssetDigest returns the SHA-256 digest of the asset with the given name.
+// In debug builds, the asset is read from disk and hashed on every call.
+func AssetDigest(name %s) ([32]byte, error) {
+	data, err := AssetUnsafe(name)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+// Digests returns the SHA-256 digests of all assets, mapped to their names.
+// Assets which can not be read are left out.
+func Digests() map[string][32]byte {
+	digests := make(map[string][32]byte)
+	for _, name := range AssetNames() {
+		if d, err := AssetDigest(%s); err == nil {
+			digests[name] = d
+		}
+	}
+	return digests
+}
+
+`, c.nameType(), c.nameArg("name"))
+	return err
+}
@@ -19,6 +19,10 @@ the asset data. Instead, it generates function stubs which load the data from
 the original file on disk. The asset API remains identical between debug and
 release builds, so your code will not have to change.
 
+Debug builds also scan the input directories whenever AssetNames or AssetDir
+are called, so files which are added after generating the code show up
+without having to regenerate.
+
 This is useful during development when you expect the assets to change often.
 The host application using these assets uses the same API in both cases and
 will not have to care where the actual data comes from.
@@ -44,10 +48,16 @@ The downside of this, is that it requires dependencies on the `reflect` and
 `unsafe` packages. These may be restricted on platforms like AppEngine and
 thus prevent you from using this mode.
 
-Another disadvantage is that the byte slice we create, is strictly read-only.
-For most use-cases this is not a problem, but if you ever try to alter the
-returned byte slice, a runtime panic is thrown. Use this mode only on target
-platforms where memory constraints are an issue.
+Another disadvantage is that the byte slice we create, is strictly read-only,
+and altering it throws a runtime panic. Asset therefore returns a copy of data
+which is kept by the package, like uncompressed assets in this mode, or cached
+ones. AssetUnsafe returns the data itself, without copying, and is the fast path
+for callers which never alter it. Use this mode only on target platforms where
+memory constraints are an issue.
+
+AssetString returns the data as a string. For uncompressed assets kept in string
+constants, it returns the constant itself, so templates or SQL statements are
+read without copying them at all.
 
 The default behaviour is to use the old code generation method. This
 prevents the two previously mentioned issues, but will employ at least one
@@ -64,7 +74,8 @@ implementation without dependencies on `reflect` and `unsafe`:
 
 Here is the same functionality, but uses the `.rodata` hack.
 The byte slice returned from this example can not be written to without
-generating a runtime error.
+generating a runtime error, which is why Asset copies it, and only
+AssetUnsafe returns it as it is.
 
 	var _myfile = "\x89\x50\x4e\x47\x0d\x0a\x1a"
 
@@ -79,6 +90,66 @@ generating a runtime error.
 		return b
 	}
 
+The `NoUnsafe` option keeps the data in string constants as well, but does
+without `reflect` and `unsafe`. Each asset is converted to a byte slice on
+first use, using a `sync.Once`, and the result is returned on every later
+call. This copies the data once, but never more than that.
+
+
+Long lines
+
+The data of large assets is split into string literals joined with +, so that
+no line of the output exceeds 16 KB. Some editors, code review tools and CI
+systems do not cope with longer lines. The LineLength option changes the limit,
+and a negative value disables it.
+
+Literals joined with + still make up a single constant for the compiler, which
+needs a lot of memory for assets of several hundred MB, and may fail on larger
+ones. With MaxLiteralSize set, or the -maxliteral flag, the data of an asset
+is split into several variables of at most that many bytes, like _model_bin,
+_model_bin_part1 and so on, which are joined when the asset is read.
+
+
+Assembly output
+
+The Assembly option, or the -asm flag, keeps the data out of Go literals
+altogether. It is written into an assembly file next to the output, named like
+it with a .s extension, as DATA directives of one array per asset:
+
+	DATA ·_index_html_data+0(SB)/8, $"<!doctyp"
+	GLOBL ·_index_html_data(SB), RODATA|NOPTR, $40
+
+The Go code only declares these arrays, so the compiler never sees the data,
+and the assembler copes with assets the compiler cannot handle. Compression and
+encryption work as usual. The data is placed in read only memory, so writing to
+the bytes AssetUnsafe returns for an uncompressed asset crashes the program.
+Asset returns a copy, as always for data kept by the package. Debug builds
+write an assembly file without data, so the one of an earlier release build
+does not break the package.
+
+
+Asset lookup
+
+Release builds keep the assets in a map from their names by default, which is
+filled when the program starts. The Lookup option, or the -lookup flag, selects
+another way for bundles of many thousands of assets:
+
+	bindata -lookup switch -pkg assets -o assets/bindata.go static/...
+
+With sorted, the names are generated as a sorted slice, which Asset searches
+by binary search. With switch, Asset goes through a switch statement over the
+names, which the compiler turns into a binary search of its own. Neither needs
+any work at startup. Functions going through all assets, like AssetGlob, build
+the map once they are called.
+
+BenchmarkLookup, run with go test -bench Lookup, generates a package of 20,000
+assets for each way and measures it. There, the map added about 6ms to the
+startup of a program, with lookups of about 15ns. The sorted slice saved the
+startup time, but lookups took about 120ns, comparing whole names. The switch
+saved the startup time as well, with lookups of about 75ns, but the package
+took almost twice as long to compile. Otherwise, most of the compile time goes
+into the functions of the assets, whichever way they are looked up.
+
 
 Optional compression
 
@@ -92,6 +163,336 @@ even increase the size of the data.
 
 The default behaviour of the program is to use compression.
 
+Every read of a compressed asset allocates a new gzip reader. With PoolReaders,
+or the -pool flag, the readers are kept in a sync.Pool and reused, and the
+buffer for the decompressed data is sized from the gzip trailer, so it never
+grows. This saves most allocations when assets are read at high rates.
+
+The CacheDecompressed option, or the -cache flag, keeps the decompressed data of
+each asset after its first use, which FlushAssetCache drops again. To bound the
+memory used, CacheSize, or the -cachesize flag, limits the total size of the
+cached data. Once it is exceeded, the assets used least recently are dropped,
+so frequently used ones stay decompressed. SetAssetCacheSize changes the limit
+at runtime.
+
+The Compression option selects the codec. Besides the default gzip, assets
+can be compressed with Zstandard, which offers better ratios and much faster
+decompression. The generated code then depends on
+github.com/klauspost/compress/zstd, and the generator itself must be built
+with the `zstd` build tag. Brotli works the same way, using
+github.com/andybalholm/brotli and the `brotli` build tag.
+
+The generated AssetCompressed(name, encoding) function returns the data of an
+asset as it is embedded, as long as it was compressed with the given content
+coding, like "gzip" or "br". HTTP servers can send this with a matching
+Content-Encoding header, instead of compressing the asset on every request.
+
+Assets which do not benefit from compression, like PNG images or files for
+which a compressed sample is not noticeably smaller, are embedded uncompressed
+even when compression is enabled. Set ForceCompress to compress them anyway.
+
+
+Shared dictionary
+
+Compressing each asset on its own does little for small files, like many
+JSON documents or templates, since there is hardly any repetition within each
+of them. With the SharedDictionary option, or the -dict flag, a dictionary of
+up to 32 KiB is built from the byte sequences found in many of the assets,
+and all assets are compressed with it. The dictionary is embedded once, and
+the generated code passes it to compress/flate when decompressing an asset.
+This requires gzip compression. The assets are then stored as raw deflate
+data, which AssetCompressed does not return.
+
+
+Parallel compression
+
+Compressing a single large asset, like a machine learning model, takes long
+on one core, no matter how many assets are encoded at once. With ParallelSize
+set, or the -parallel flag, assets of at least that many bytes are split into
+chunks, which are compressed by several workers, the way pigz does:
+
+	bindata -parallel 64000000 -parallelworkers 8 -parallelchunk 4000000 models/
+
+Each chunk is compressed with the data before it as the dictionary, so the
+result is a single gzip stream, which the generated code decompresses as
+usual, and which is only slightly larger. The output depends on the chunk
+size, but not on the number of workers. This requires gzip compression.
+
+
+Encryption
+
+Set EncryptKeyEnv to the name of an environment variable holding a hex encoded
+AES key, to encrypt the data of all assets with AES-GCM during generation. The
+generated code decrypts the assets on use, with the key read from the same
+variable at runtime, or passed to the generated SetAssetKey function. Without
+a valid key, the assets can not be loaded. Assets with the same contents are
+encrypted the same way, so the output does not change between runs.
+
+
+Tamper detection
+
+With HMACKeyEnv set to the name of an environment variable holding a hex
+encoded key, release builds embed an HMAC of every asset. Assets are checked
+against it whenever they are loaded, and the generated VerifyAssets function
+checks all of them at once, for example on startup. This detects embedded
+data which was patched or corrupted in the binary.
+
+Without a key, the Checksums option, or the -checksums flag, embeds a CRC-32
+checksum of every asset instead. It is checked against the contents of an
+asset whenever it is loaded, after decompression, so data which was truncated
+or overwritten in memory fails with an error naming the asset, instead of
+being returned silently.
+
+
+Migrating from go-bindata
+
+The Compat option produces a drop-in replacement for the output of the
+original go-bindata tool. The generated code does not import the grate
+package, and asserts that Asset, MustAsset, AssetInfo, AssetNames, AssetDir,
+RestoreAsset and RestoreAssets have the signatures callers expect.
+
+
+Directories
+
+An input directory is read recursively if the Recursive option, or -r flag, is
+set, or if its path ends in /..., as in go tooling:
+
+	$ go-bindata static/... templates/
+
+Here, static is read with its subdirectories, while templates is not. In
+configuration files, each input may set recursive on its own, which is implied
+by a path ending in /...; setting it to false for such a path is an error.
+
+The directories below recursive directory inputs are recorded along with
+their modes, so AssetDir lists empty directories as well, and RestoreAssets
+recreates them with the original modes. Directories are not recorded for
+archive and remote inputs, with include patterns, or with obfuscated names.
+
+
+Ignored files
+
+Files matching any of the Ignore patterns, or the -ignore flag, are skipped,
+and so are directories, along with everything below them. Two options cover
+the patterns most projects need. IgnoreHidden, or -ignorehidden, skips files
+and directories whose name starts with a dot, like .DS_Store. IgnoreVCS, or
+-ignorevcs, skips the metadata of version control systems, like .git, .hg,
+.svn and .gitignore files, and the node_modules and bower_components
+directories. Both apply in debug builds and in watch mode as well.
+
+Exclusion rules can also live next to the assets, in a .bindataignore file in
+the root of an input directory or archive. It follows the syntax of .gitignore
+files, so the rules are shared with other tools embedding from the same tree:
+
+	# TypeScript sources, except for the declarations.
+	*.ts
+	!/types/*.d.ts
+	src/
+	testdata/
+
+Patterns with a slash are relative to the input root, others match at any
+level, and a trailing slash only matches directories. The last matching rule
+decides, so ! includes files again, unless a directory holding them is
+ignored. The .bindataignore file itself is never embedded. Debug builds apply
+the rules read during generation.
+
+
+Unreadable files
+
+Generation stops at the first file which cannot be read. For large trees,
+like those on network file systems, the ContinueOnError option, or the
+-continue flag, leaves out the files and directories which cannot be read,
+like those without permission or vanished since the directory was listed,
+and generates the code for the remaining assets. All failures are returned
+at the end as AssetErrors, which errors.Is and errors.As look through, and
+in Stats.Failures. The command prints each of them along with their number,
+and exits with a failure status.
+
+
+Asset names
+
+Asset names always use forward slashes, on Windows as well. Names of files,
+given inputs, rewrites and the assets passed to TranslateTo are converted
+during generation, with backslashes turned into slashes and elements like ".."
+resolved. The generated lookup functions convert the names passed to them in
+the same way, so Asset(`css\app.css`), Asset("/css/app.css") and
+AssetDir(`css\`) find the same asset and directory as their plain forms.
+
+
+Renaming assets
+
+The Rewrites option, or the repeatable -rewrite flag, renames assets without
+renaming their files. Each rewrite replaces the parts of the names matching a
+regular expression, once the prefix is stripped, and may refer to submatches:
+
+	$ go-bindata -r -rewrite '^dist/assets/=' -rewrite '\.[0-9a-f]{8}(\.\w+)$=$1' dist/
+
+This embeds dist/assets/js/app.3f2a9c1b.js as js/app.js. Rewrites apply in
+turn, to directories as well, and in debug builds to the files found at
+runtime. A rewrite leaving an asset without a name is an error.
+
+
+File lists
+
+Build systems often know the exact files they produce. Instead of walking
+directories, the -filelist flag reads the files to embed from a list, with
+one file per line, or from standard input if it is given as -. A name after
+an arrow replaces the name the file is embedded under:
+
+	$ find dist -name '*.js' | go-bindata -filelist - -prefix dist
+	$ cat assets.list
+	# Hashed bundles are looked up by their logical names.
+	dist/app.3f2a9c.js => js/app.js
+	dist/favicon.ico
+	$ go-bindata -filelist assets.list
+
+Names given this way are used as they are, without stripping the prefix.
+ReadFileList parses such lists for the Input option, whose entries may set
+Name for a single file themselves.
+
+
+Certificates
+
+Programs running in containers built from scratch lack the certificates of an
+operating system to verify TLS peers with. The RootCAs option, or the -rootcas
+flag, generates a RootCAs function, which assembles an x509.CertPool from the
+PEM encoded certificate bundles among the assets, like a copy of
+ca-certificates.crt:
+
+	pool, err := RootCAs()
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{RootCAs: pool},
+	}}
+
+Assets with a .pem, .crt or .cer extension, which hold certificates, are found
+during generation. Other PEM blocks, like private keys, are not considered.
+Generation fails if a certificate cannot be parsed, or none is found.
+
+
+Time zones
+
+Static binaries running on systems without /usr/share/zoneinfo cannot load
+time zones. The TimeZones option, or the -timezones flag, names an asset
+holding a time zone database, like the zoneinfo.zip found in lib/time of a Go
+installation:
+
+	bindata -timezones zoneinfo.zip zoneinfo.zip
+
+The generated code registers it with the time package, the same way importing
+time/tzdata does, so time.LoadLocation falls back to it if a time zone is not
+found on the system. Generation fails if the asset does not exist, or is not a
+zip archive. This requires Go 1.15 or newer to compile the generated code.
+
+
+Extracting assets
+
+Helper executables and native libraries need to exist as files to be run or
+loaded. With the Extract option, or the -extract flag, the generated code has
+an ExtractAll function, which writes all assets to a directory and returns its
+path:
+
+	dir, err := ExtractAll("")
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(filepath.Join(dir, "tools", "helper"))
+
+The directory is created below the given one, or below the cache directory of
+the user if it is empty, and named after a hash of the names, modes and
+contents of the assets. The assets are written to a temporary directory with
+their recorded modes and modification times, which is only renamed once
+complete. An existing directory of that name is therefore reused as it is, by
+later calls and runs alike, until the assets change.
+
+
+Errors
+
+Functions of the generated code looking up an asset by name return an error
+wrapping ErrAssetNotFound if there is no such asset, so callers can test for
+it with errors.Is. Failures reading an asset wrap the underlying error.
+
+Two options change what Asset, its variants and AssetInfo do for a name matching
+no asset. With FallbackAsset, or the -fallbackasset flag, they return the named
+asset instead, like a 404.html page. With PanicOnMissing, or the -panicmissing
+flag, they panic with the error, which suits packages whose assets are all
+known at compile time, so a missing one is a bug.
+
+With IgnoreCase, or the -ignorecase flag, names are matched regardless of case,
+so Asset("Templates/Index.HTML") returns templates/index.html. Exact matches
+are preferred, and generation fails if two assets differ only by case. This
+is checked before falling back or panicking.
+
+
+Archive inputs
+
+An input path may name a .zip, .tar, .tar.gz or .tgz archive instead of a
+directory. Its entries are embedded as if the archive were a directory of the
+same name, with the sizes, modes and modification times recorded in the
+archive. Only regular files are included. Archives can not be used in debug
+builds, which read the assets from disk.
+
+Inputs may also be HTTP or HTTPS URLs, which are downloaded during generation
+and embedded under the last element of the URL path. Their SHA-256 checksum
+must be given in InputConfig.SHA256, or on the command line as a fragment, as
+in https://example.com/GeoLite2.mmdb#sha256=<hex>. A download which does not
+match the checksum fails the generation. Remote archives are unpacked like
+local ones.
+
+
+Platform specific assets
+
+Inputs can be limited to some target platforms, each given as "goos" or
+"goos/goarch", in InputConfig.Platforms, or on the command line as a fragment
+of the path:
+
+	$ go-bindata -o assets/bindata.go -prefix tools/linux_amd64/ web/ tools/linux_amd64/#platforms=linux/amd64
+
+The output is then written once for each listed platform, with the platform
+appended to its name, as in assets/bindata_linux_amd64.go, and once more under
+the configured name for all other platforms. Each file holds the assets of the
+inputs without platforms, along with those for its platform, and carries the
+build constraint for it, so exactly one of them is built for any target. The
+assets of inputs for different platforms may share a name, like a helper binary
+built for each of them. Platform specific inputs cannot be combined with split
+output or incremental regeneration.
+
+
+Transforms
+
+The Transforms option holds functions, which are applied to the contents of
+every asset before it is embedded, for instance to minify scripts or to strip
+comments. CommandTransform runs an external command as such a filter for the
+assets matching a glob pattern. On the command line, the -filter flag does the
+same:
+
+	bindata -filter '*.js=uglifyjs -c' -filter '*.sql=sqlstrip' assets/
+
+
+The Minify option removes comments and insignificant whitespace from style
+sheets, scripts, JSON and HTML documents with the given extensions, so they
+take less space even before compression:
+
+	bindata -minify css,js,html assets/
+
+
+Per extension settings
+
+The PerExtension option maps file extensions to AssetOptions, which override
+the settings of the configuration for the matching assets in release builds.
+Assets can be embedded uncompressed, or compressed even if they do not benefit
+from it, be kept in string constants like with NoMemCopy, be minified, or
+pass through additional transforms:
+
+	c.PerExtension = map[string]bindata.AssetOptions{
+		".png":   {NoCompress: true},
+		".css":   {Minify: true},
+		".proto": {NoCompress: true, NoMemCopy: true},
+	}
+
+Configuration files hold these settings under the "extensions" key.
+
 
 Path prefix stripping
 
@@ -115,15 +516,508 @@ Running with the `-prefix` flag, we get:
 	_bindata["templates/foo.html"] = templates_foo_html
 
 
+Name collisions
+
+Assets from different inputs may end up with the same name. By default, this
+fails the generation. With the Collisions option, the asset of the first or
+last input is kept instead, or the names of later assets get a numeric suffix,
+as in "app-2.css". TranslateStats reports the number of collisions.
+
+
+Function names
+
+Every asset gets an unexported function in the generated code, whose name is
+derived from the asset name. By default, it is in snake case, like
+web_static_css_app_css for web/static/css/app.css. The FuncNaming option, or
+the -funcnames flag, selects camel case instead, like webStaticCssAppCss.
+FuncNameFor can derive the names in any other way, for instance from the last
+element of the path only:
+
+	c.FuncNameFor = func(name string) string {
+		return "asset_" + regexp.MustCompile(`\W`).ReplaceAllString(path.Base(name), "_")
+	}
+
+Names derived for more than one asset, or which are keywords or predeclared
+identifiers, get a numeric suffix, as in asset_app_css2.
+
+
 Build tags
 
-With the optional Tags field, you can specify any go build tags that
+With the optional Tags field, you can specify any go build constraints that
 must be fulfilled for the output file to be included in a build. This
 is useful when including binary data in multiple formats, where the desired
 format is specified at build time with the appropriate tags.
 
-The tags are appended to a `// +build` line in the beginning of the output file
-and must follow the build tags syntax specified by the go tool.
+All constraints must hold. They are combined into a `//go:build` line in the
+beginning of the output file, followed by the equivalent `// +build` lines,
+and must follow the build constraint syntax specified by the go tool.
+
+
+File headers
+
+Every generated file carries the "// Code generated by bindata. DO NOT EDIT."
+marker, which tells linters, editors and code review tools not to touch it.
+The HeaderTemplate option, or the -header flag naming a file, adds a header
+above it, like a license block. It is a text/template, which may refer to the
+package, output, time of generation, command line and bindata version:
+
+	// Copyright {{.Timestamp.Year}} Example Corp. All rights reserved.
+	// SPDX-License-Identifier: Apache-2.0
+	// Generated for package {{.Package}} by: {{.CommandLine}}
+
+The header is written as it is, so it must consist of comments. Build
+constraints may only follow line comments, so with Tags, block comments are
+rejected. The time of generation is taken from ModTime, if set.
+
+
+Custom code
+
+The Prologue and Epilogue options insert snippets of Go code into the
+generated file, after the imports and at its end. Each Snippet lists the
+packages its code imports, which are merged with those of the generated code.
+Snippets with Template set are executed as a text/template first, with the
+variables of the header template, the names of all assets as .Assets, and
+.Debug for debug builds. The init function registering release builds with
+grate is itself the first snippet of the prologue.
+
+The repeatable -prologue and -epilogue flags read snippets from files, which
+are templates if their name ends in .tmpl. Files may start with import
+declarations, which ParseSnippet moves to the imports of the snippet:
+
+	import "log"
+
+	func init() {
+		log.Printf("%d assets embedded", len(_bindata))
+	}
+
+
+Code templates
+
+The code of release builds reading the embedded data and returning assets is
+generated from text/templates, like asset.tmpl for the function returning an
+asset. The TemplateDir option, or the -templatedir flag, names a directory of
+templates replacing the built-in ones with the same names, so the runtime API
+can be changed without forking the generator. The codetemplates command of
+bindata, or WriteCodeTemplates, writes the built-in templates as a starting
+point:
+
+	bindata codetemplates tmpl
+	bindata -templatedir tmpl -pkg assets -o assets/bindata.go static/...
+
+The replaced templates are parsed and executed once before generation, and
+errors name the file and line. As the templates produce Go code, the Format
+option helps to find mistakes in their output.
+
+
+Target Go version
+
+By default, the generated code builds with any version of Go, and uses
+io/ioutil, interface{}, reflect.SliceHeader and // +build lines, which newer
+versions of go vet and linters complain about. The GoVersion option, or the
+-goversion flag, names the version of Go the code targets instead, so the
+newer forms are used where available:
+
+	bindata -goversion 1.21 -pkg assets -o assets/bindata.go static/...
+
+From Go 1.16 on, os.ReadFile, os.WriteFile, os.ReadDir, os.MkdirTemp and
+io.ReadAll replace the functions of io/ioutil. From 1.17 on, build constraints
+are written as //go:build lines only, from 1.18 on any replaces interface{},
+and from 1.20 on unsafe.Slice and unsafe.StringData replace the slice header
+in NoMemCopy mode. The value mod takes the version from the go directive of
+the go.mod file in the directory of the output, or the closest one above it.
+
+
+Typed asset names
+
+With the TypedNames option, the generated code declares an AssetName type and
+a constant for every asset, like AssetCssAppCss for "css/app.css". Asset,
+MustAsset and the other lookup functions then take an AssetName, so that a
+misspelled name fails to compile instead of failing at runtime:
+
+	data := MustAsset(AssetCssAppCss)
+
+Setting NameSalt goes one step further, and replaces the asset names by salted
+hashes. The constants are generated as well, but hold the hashes, so the names
+and directory layout of the assets do not appear in the compiled program. The
+assets are then only accessible through the constants.
+
+The Accessors option, or the -accessors flag, generates an exported function
+for every asset instead, which returns its contents, like MustAsset does. The
+names are in mixed caps, with common initialisms in upper case, like IndexHTML
+for "index.html" and CSSAppCSS for "css/app.css", and get a numeric suffix if
+they are taken by the generated API or another asset already:
+
+	w.Write(IndexHTML())
+
+Accessors cannot be combined with NameSalt, as the names of the functions
+end up in the compiled program.
+
+
+File system interface
+
+With the FS option, the generated code additionally exposes an AssetFS()
+function. It returns an fs.FS backed by the embedded table of contents, which
+also implements fs.ReadDirFS, fs.StatFS and fs.GlobFS. This allows the assets
+to be used directly with the standard library:
+
+	http.Handle("/", http.FileServer(http.FS(AssetFS())))
+
+The Overlay option adds an OverlayFS(dir) function. Its file system reads
+files from the given directory first, and only falls back to the embedded
+assets for files which are missing there. Templates or other assets of a
+deployed program can then be replaced without rebuilding it.
+
+Without the FS option, AssetGlob selects the names of assets matching a
+pattern, like "templates/*.tmpl", and WalkAssets visits all assets below a
+directory in the manner of filepath.Walk.
+
+AssetNames returns the names of all assets in sorted order, and
+AssetNamesWithPrefix only those starting with a prefix, like "css/". Release
+builds sort the names once, so the latter finds them by binary search instead
+of going through all assets:
+
+	for _, name := range AssetNamesWithPrefix("css/") {
+		fmt.Println(name)
+	}
+
+AssetsCount, AssetsSize and AssetsCompressedSize return the number of assets,
+the total size of their contents, and the size of the data embedded for them,
+as known from the generation, so startup logs can report them without loading
+any asset. Debug builds read the assets from disk for AssetsSize.
+
+AssetTree returns the whole tree of assets and directories in one call, as
+AssetNode values holding the name, file info and sorted children of each, so
+rendering the tree does not take an AssetDir call for every directory. Release
+builds take the file infos from a table written along with the tree, so no
+asset is decompressed or decrypted. It is named AssetTree rather than Tree,
+like AssetDir, AssetNames and the other functions about the assets, so it does
+not clash with the code of the package the assets are generated into.
+
+
+Templates
+
+The Templates option generates ParseTemplates(pattern, funcs), which parses
+the assets matching a path.Match pattern as html/template templates, and
+ParseTextTemplates, which does the same with text/template. As with
+template.ParseFS, each template is named after the base name of its asset:
+
+	t, err := ParseTemplates("views/*.html", template.FuncMap{"upper": strings.ToUpper})
+
+
+Database migrations
+
+With the Migrations option set to the asset directory holding them, like
+"migrations", a Migrations() function lists the embedded database migrations,
+ordered by version. The files are named as golang-migrate expects them, like
+0001_create_users.up.sql and 0001_create_users.down.sql. MigrationNames and
+MigrationAsset serve as the source of the go_bindata driver of golang-migrate:
+
+	src, err := bindata.WithInstance(bindata.Resource(MigrationNames(), MigrationAsset))
+
+
+Localized assets
+
+With the Locales option set to an asset directory holding a directory per
+locale, named after its BCP 47 language tag, like locales/en-US and locales/de,
+AssetLocalized(locale, name) looks up an asset for the given locale. If the
+asset does not exist for "de-AT", it is looked up for "de", and finally for
+the DefaultLocale:
+
+	bindata -locales locales -defaultlocale en i18n/
+
+
+HTTP handler
+
+The Handler option generates an AssetHandler() function, which serves the
+embedded assets over HTTP. It sets Content-Type, Last-Modified and a strong
+ETag derived from the SHA-256 sum of each asset, so clients can make use of
+conditional requests without any further glue code:
+
+	http.Handle("/static/", http.StripPrefix("/static/", AssetHandler()))
+
+Single page applications route most paths on the client. With IndexFallback
+set to an asset like "index.html", or the -fallback flag, the handler serves
+it for every path matching no asset, and serves the index.html of directories.
+Paths starting with one of the FallbackExclude prefixes are still answered
+with 404 Not Found, so mistyped API calls do not receive the page:
+
+	bindata -handler -fallback index.html -fallbackexclude api/ web/dist/
+
+The Fingerprints option generates a HashedName(name) function, which inserts
+a hash of the contents of an asset into its name, like css/app.3f9ab2c1.css.
+The handler serves assets under these names too, and lets clients cache them
+for good, as the names change along with the contents. Templates can use
+HashedName to refer to assets, and UnhashedName maps the names back.
+
+With the ContentTypes option, a ContentType(name) function returns the MIME
+type of an asset. Release builds determine the types during generation, from
+the file extension, or by sniffing the contents of files whose extension is
+missing or unknown to the system. The handler then sets these types, so it
+does not depend on the MIME types known to the system it runs on.
+
+With the Precompressed option, the handler sends compressed assets as they
+are embedded to clients which accept their encoding, instead of decompressing
+them first. An AssetGzip() function returns this data for other servers.
+
+
+Duplicate assets
+
+Release builds embed the data of identical assets only once. Assets with the
+same contents as an earlier one return its data instead, along with their own
+name and file info. TranslateStats reports the number of duplicates and the
+bytes saved this way. This does not apply to incremental regeneration.
+
+
+Single blob layout
+
+By default, every asset gets its own variable and a few functions. With
+thousands of small files, this slows down compiling and linking noticeably.
+The SingleBlob option instead writes all assets into one string constant,
+along with an index holding the offset and length of each of them. The API
+of the generated code stays the same.
+
+
+Data files
+
+With SingleBlob, the DataFile option, or the -datafile flag, writes the data
+of the assets into the named file rather than the string constant. The
+generated code only holds the index, so building it stays fast however large
+the assets are. The file ends in a trailer identifying the data, so it can be
+shipped next to the executable, or appended to it:
+
+	cat assets.dat >> myapp
+
+On first use, the data is looked for at the end of the executable, then in a
+file of the same name next to it, and read through an io.ReaderAt. Data of
+another build is rejected. SetAssetData points the package at the data
+elsewhere, like in tests, where the test binary is built in a temporary
+directory:
+
+	fd, err := os.Open("assets.dat")
+	...
+	fi, err := fd.Stat()
+	...
+	err = SetAssetData(fd, fi.Size())
+
+
+Merging split output
+
+With SplitOutput, every asset goes into a file of its own, next to the shared
+bindata_toc.go. The Merge option, or the -merge flag, keeps the assets of the
+existing output which the inputs do not cover, so groups of assets can be
+regenerated independently into one package:
+
+	bindata -split -merge -pkg assets -o assets/ web/...
+	bindata -split -merge -pkg assets -o assets/ docs/...
+
+An input directory covers all assets named as if found below it, so assets
+deleted from it are removed from the output, while those of other directories
+stay. Inputs naming a single file, an archive or a URL only cover the assets
+they produce. The files of kept assets are left as they are, and the table of
+contents is written for all assets. Kept assets sharing the data of one which
+is regenerated get their own copy. All runs must use the same options, as the
+kept files rely on the shared code, and empty directories are only recorded
+for the inputs of the latest run. Merging requires a release build, and cannot
+be combined with name obfuscation or a shared dictionary.
+
+
+Asset manifest
+
+Set ManifestPath to write a JSON manifest along with the generated code. It
+lists every asset with its name, source path, size, mode, modification time
+and SHA-256 sum, and the embedded size of compressed assets. Deployment
+tooling can use it to check what a build contains without parsing Go code.
+
+
+Self tests
+
+The SelfTest option, or the -selftest flag, writes a test file along with the
+generated code, named like the output with a _test suffix, or bindata_test.go
+in split mode. Running go test on the package then reads every asset, checks
+its size against the one reported by AssetInfo, and checks that the tree of
+AssetDir holds the same assets as AssetNames. This catches broken generated
+code, like a codec which does not decompress what it compressed, before it is
+shipped. With encryption, the tests are skipped unless the key is set.
+
+All functions of the generated code are safe for concurrent use. Lazily built
+state, like the table of contents of the sorted lookup, decompressed data kept
+with CacheDecompressed, or the key read for encryption, is guarded by a
+sync.Once or a mutex, and every call decompresses into a buffer of its own.
+The self tests include one reading all assets from several goroutines at once,
+flushing the cache in between. Run it with the race detector to check this for
+the options in use, or for code patched in by hand:
+
+	go test -race ./assets
+
+
+Statistics
+
+TranslateStats returns the size of every asset and of the data embedded for
+it, along with the totals and the time taken. The WriteReport method of the
+statistics prints them, with the largest assets, as the -report flag of
+bindata does.
+
+Tools embedding the generator can follow its progress by setting Events to a
+callback, rather than parsing the output of bindata. It is called with an Event
+once the assets are found, after the code of each asset was written, for every
+size limit warning and unreadable file, and once the output is done:
+
+	c.Events = func(e bindata.Event) {
+		if e.Kind == bindata.EventAsset {
+			bar.Set(e.Done, e.Total)
+		}
+	}
+
+Events are delivered one at a time, and assets in the order they are written,
+even when they are compressed in parallel.
+
+
+Size limits
+
+MaxAssetSize and MaxTotalSize guard against embedding files by accident, like
+a large video in the binary of a command line tool. Generation fails if any
+asset, or all assets together, exceed them. With SizeLimitWarn set, the limits
+are only reported in Stats.Warnings, which bindata prints.
+
+
+Dry runs
+
+Plan locates and encodes the assets like Translate, but writes nothing. It
+returns the same statistics as TranslateStats, which help to check the filters
+of the inputs, and to find large files before they end up in a binary. The
+plan command of bindata prints their report, taking the same options as a
+regular run.
+
+
+Generation metadata
+
+The Metadata option, or the -metadata flag, records the time of generation,
+the version of bindata and a hash of the names and contents of all assets as
+the GeneratedAt, GeneratorVersion and SourceHash constants. AssetsVersion
+returns the first twelve digits of the hash, so a deployed binary can report
+exactly which snapshot of the assets it embeds:
+
+	log.Printf("serving assets %s, generated %s", assets.AssetsVersion(), assets.GeneratedAt)
+
+With ModTime set, it is also used as the time of generation, so the output
+stays reproducible. Debug builds read the assets from disk, and report their
+version as "debug".
+
+
+Provenance comments
+
+The Provenance option, or the -provenance flag, adds a comment to the function
+of every asset in release builds, stating where its data came from:
+
+	// index_html returns the asset "index.html".
+	//
+	// Source: static/index.html
+	// Size: 1024
+	// SHA-256: 9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08
+	// Modified: 2024-01-02T15:04:05Z
+
+Reviews of regenerated code can thus tell which file every changed blob belongs
+to, and tools can parse these lines. The sum covers the contents as embedded,
+after any transforms, while the source is the file they were read from. With
+SingleBlob, the comment precedes the entry of the asset in the table. Source
+paths are relative to the directory of the output, so they are the same on
+every machine, and ModTime replaces the modification time. The option cannot be
+combined with name obfuscation, as the comments would reveal the names.
+
+
+Stale output
+
+Release builds record the SHA-256 sum of the source file of every asset in the
+generated code, as a constant which takes no space in binaries. Outdated
+compares the input files with these sums, and reports the assets which were
+added, removed or changed since, without decoding any embedded data. The
+outdated command of bindata takes the same options as a regular run, prints
+these assets, and exits with a failure status if there are any, so a CI job
+can catch code which someone forgot to regenerate:
+
+	bindata outdated -pkg assets -o assets/bindata.go static/...
+
+
+Library use
+
+Other tools can embed the generator. TranslateTo writes the code for a list of
+assets to any io.Writer, so it can be captured in memory:
+
+	var buf bytes.Buffer
+	err := bindata.TranslateTo(&buf, c, []bindata.Asset{
+		{Path: "build/app.js", Name: "app.js"},
+	})
+
+The assets may also come from an fs.FS, or be generated in memory, using
+AssetsFromFS and AssetsFromMap.
+
+
+Configuration files
+
+Projects with many inputs can keep their settings in a YAML or TOML file,
+which is passed to the command with -config, or loaded by NewConfigFromFile.
+Keys are named after the command line flags, and inputs are listed under
+"inputs", each with its own prefix and filters:
+
+	package: assets
+	output: assets/bindata.go
+	compression: zstd
+	recursive: true
+	inputs:
+	  - web/static
+	  - path: configs/defaults
+	    prefix: configs
+	    include: ["*.json"]
+
+Options given on the command line override the settings of the file, and
+inputs given as arguments are added to those of the file. Only the common
+subset of both formats is understood; anchors, block scalars, dates and
+multi-line strings are not supported.
+
+
+Registries
+
+Applications composed of plugins may generate a package of assets for each
+plugin. With the Register option, or the -register flag, the generated code
+has a Register function, which adds its assets to a Registry shared by all of
+them. The option names the package in the registry:
+
+	var assets bindata.Registry
+
+	func init() {
+		if err := plugin.Register(&assets); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+The registry looks up assets by name across all packages. A package is
+rejected as a whole, if one of its asset names is taken by another package
+already. The generated code does not import this package, and accepts any
+type with a matching AddPackage method.
+
+
+Bundles
+
+A project embedding several sets of assets, each into a package of its own,
+can generate all of them in one run. Config.Bundles holds a configuration for
+each of them by name, and configuration files list them under "bundles":
+
+	compression: zstd
+	bundles:
+	  web:
+	    package: web
+	    output: web/bindata.go
+	    inputs: [web/static]
+	  admin:
+	    package: admin
+	    output: admin/bindata.go
+	    inputs: [admin/static]
+
+The bundles of a configuration file share its settings, except for its inputs,
+and may override them. They are generated one after the other, sorted by name.
+Directories read by more than one bundle are only listed once. The settings of
+the command line apply to the top level only, which is generated as well if it
+has inputs. With -stats, each bundle is reported on its own.
 
 */
 package bindata
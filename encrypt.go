@@ -0,0 +1,253 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// encrypt reports whether the asset data is encrypted.
+func (c *Config) encrypt() bool {
+	return len(c.EncryptKeyEnv) > 0 && !c.Debug
+}
+
+// validateEncryption ensures the configured environment
+// variable holds a valid key, if encryption is enabled.
+func validateEncryption(c *Config) error {
+	if !c.encrypt() {
+		return nil
+	}
+
+	_, err := encryptionKey(c.EncryptKeyEnv)
+	return err
+}
+
+// encryptionKey returns the hex encoded AES key held
+// by the named environment variable.
+func encryptionKey(env string) ([]byte, error) {
+	key, err := hex.DecodeString(os.Getenv(env))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid encryption key in %s: %v", env, err)
+	}
+
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	case 0:
+		return nil, fmt.Errorf("Missing encryption key in %s", env)
+	}
+
+	return nil, fmt.Errorf("Invalid encryption key in %s: %d bytes, want 16, 24 or 32", env, len(key))
+}
+
+// newAEAD returns AES-GCM using the given key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// encryptWriter buffers the data of an asset, and writes it encrypted
+// to the underlying writer when closed. The nonce is derived from the
+// key and the data, so the output is the same for every run. Assets
+// with the same contents therefore share their encrypted data, but
+// different contents never share a nonce.
+type encryptWriter struct {
+	w   io.Writer
+	key []byte
+	buf bytes.Buffer
+}
+
+func (w *encryptWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Close writes the nonce, followed by the encrypted data.
+func (w *encryptWriter) Close() error {
+	aead, err := newAEAD(w.key)
+	if err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, w.key)
+	mac.Write(w.buf.Bytes())
+	nonce := mac.Sum(nil)[:aead.NonceSize()]
+
+	_, err = w.w.Write(aead.Seal(nonce, nonce, w.buf.Bytes(), nil))
+	return err
+}
+
+// nopWriteCloser adds a no-op Close method to a writer.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+// newEncryptWriter returns a writer encrypting the data written
+// to w, if encryption is enabled. It must be closed to write the
+// encrypted data.
+func newEncryptWriter(w io.Writer, c *Config) (io.WriteCloser, error) {
+	if !c.encrypt() {
+		return nopWriteCloser{w}, nil
+	}
+
+	key, err := encryptionKey(c.EncryptKeyEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	return &encryptWriter{w: w, key: key}, nil
+}
+
+// writeRaw writes the uncompressed data of the asset read from r
+// to w, encrypting it if enabled. The size of the data written is
+// recorded in the asset.
+func writeRaw(w io.Writer, c *Config, asset *Asset, r io.Reader) error {
+	out := &offsetWriter{Writer: w}
+	ew, err := newEncryptWriter(out, c)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(ew, r)
+	if err != nil {
+		return err
+	}
+
+	err = ew.Close()
+	asset.EmbeddedSize = out.n
+	return err
+}
+
+// decryptData decrypts asset data written by encryptWriter, using
+// the key held by the named environment variable.
+func decryptData(data []byte, env string) ([]byte, error) {
+	key, err := encryptionKey(env)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	size := aead.NonceSize()
+	if len(data) < size {
+		return nil, fmt.Errorf("Encrypted data is too short")
+	}
+
+	return aead.Open(nil, data[:size], data[size:], nil)
+}
+
+// writeDecrypt writes the functions decrypting the assets at runtime.
+// The key is read from the environment variable on first use, unless
+// it was set with SetAssetKey.
+func writeDecrypt(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// _bindata_key_env names the environment variable
+// holding the hex encoded key of the assets.
+var _bindata_key_env = %q
+
+var (
+	_bindata_key_mu sync.Mutex
+	_bindata_aead   cipher.AEAD
+)
+
+// SetAssetKey sets the AES key used to decrypt the assets, instead of
+// reading it from the environment. It must be 16, 24 or 32 bytes long.
+func SetAssetKey(key []byte) error {
+	aead, err := bindata_aead(key)
+	if err != nil {
+		return err
+	}
+	_bindata_key_mu.Lock()
+	_bindata_aead = aead
+	_bindata_key_mu.Unlock()
+	return nil
+}
+
+func bindata_aead(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// bindata_decrypt decrypts the data of the named asset.
+func bindata_decrypt(data []byte, name string) ([]byte, error) {
+	_bindata_key_mu.Lock()
+	aead := _bindata_aead
+	if aead == nil {
+		key, err := hex.DecodeString(os.Getenv(_bindata_key_env))
+		if err == nil {
+			aead, err = bindata_aead(key)
+		}
+		if err != nil {
+			_bindata_key_mu.Unlock()
+			return nil, fmt.Errorf("Error decrypting %%s: invalid key in %%s: %%w", name, _bindata_key_env, err)
+		}
+		_bindata_aead = aead
+	}
+	_bindata_key_mu.Unlock()
+
+	size := aead.NonceSize()
+	if len(data) < size {
+		return nil, fmt.Errorf("Error decrypting %%s: data is too short", name)
+	}
+	b, err := aead.Open(nil, data[:size], data[size:], nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error decrypting %%s: %%w", name, err)
+	}
+	return b, nil
+}
+
+`, c.EncryptKeyEnv)
+	if err != nil || !c.stringData() && !c.extNoMemCopy() {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `// bindata_decrypt_raw decrypts the data of the named asset,
+// held in string form.
+func bindata_decrypt_raw(data %s, name string) ([]byte, error) {
+	b, err := bindata_read_raw(data, name)
+	if err != nil {
+		return nil, err
+	}
+	return bindata_decrypt(b, name)
+}
+
+`, stringDataType(c))
+	return err
+}
+
+// decryptStep returns the generated statements, which decrypt
+// the byte slice in variable b, if encryption is enabled.
+func decryptStep(c *Config) string {
+	if !c.encrypt() {
+		return ""
+	}
+
+	return `	b, err = bindata_decrypt(b, name)
+	if err != nil {
+		return nil, err
+	}
+
+`
+}
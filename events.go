@@ -0,0 +1,100 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+// EventKind tells what an Event reports.
+type EventKind int
+
+const (
+	// EventFound reports the assets found in the inputs, before any
+	// code is written. Total holds their number.
+	EventFound EventKind = iota
+
+	// EventAsset reports an asset whose code was written, in the order
+	// of the output. Done counts the assets written so far, out of Total.
+	EventAsset
+
+	// EventWarning reports an exceeded size limit, with SizeLimitWarn.
+	EventWarning
+
+	// EventFailure reports a file or directory which was left out with
+	// ContinueOnError. Err holds its *AssetError.
+	EventFailure
+
+	// EventDone reports the end of the generation of an output,
+	// along with its statistics.
+	EventDone
+
+	// EventError reports a regeneration which failed in watch mode.
+	// Err holds its error. WatchAndTranslate goes on watching.
+	EventError
+)
+
+// String returns the name of the event kind, as used in logs.
+func (k EventKind) String() string {
+	switch k {
+	case EventFound:
+		return "found"
+	case EventAsset:
+		return "asset"
+	case EventWarning:
+		return "warning"
+	case EventFailure:
+		return "failure"
+	case EventDone:
+		return "done"
+	case EventError:
+		return "error"
+	}
+	return "unknown"
+}
+
+// Event reports the progress of Translate to Config.Events, so tools
+// embedding bindata can show progress bars and structured logs.
+type Event struct {
+	Kind    EventKind
+	Output  string     // The output file or directory being generated.
+	Asset   AssetStats // The asset written, for EventAsset.
+	Done    int        // The number of assets written so far, for EventAsset.
+	Total   int        // The number of assets of the output.
+	Message string     // The text of an EventWarning, EventFailure or EventError.
+	Err     error      // The error of an EventFailure or EventError.
+	Stats   *Stats     // The statistics of the output, for EventDone.
+}
+
+// event reports the given event, if an events callback is set.
+func (c *Config) event(e Event) {
+	if c.Events == nil {
+		return
+	}
+
+	e.Output = c.Output
+	if len(e.Message) == 0 && e.Err != nil {
+		e.Message = e.Err.Error()
+	}
+	c.Events(e)
+}
+
+// assetWritten reports the asset with index i of toc, once its code
+// was written. Assets are written in order, so i+1 of them are done.
+func (c *Config) assetWritten(toc []Asset, i int) {
+	if c.Events == nil {
+		return
+	}
+
+	c.event(Event{Kind: EventAsset, Asset: newAssetStats(&toc[i]), Done: i + 1, Total: len(toc)})
+}
+
+// assetEvents returns the function passed to encodeAssets, which
+// reports each asset of toc once written, or nil without callback.
+func (c *Config) assetEvents(toc []Asset) func(i int) {
+	if c.Events == nil {
+		return nil
+	}
+
+	return func(i int) {
+		c.assetWritten(toc, i)
+	}
+}
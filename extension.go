@@ -0,0 +1,149 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// AssetOptions holds the settings for the assets with a given file
+// extension, as listed in Config.PerExtension. They apply to release
+// builds only.
+type AssetOptions struct {
+	// NoCompress embeds the assets uncompressed, while ForceCompress
+	// compresses them even if they do not benefit from it.
+	NoCompress    bool
+	ForceCompress bool
+
+	// NoMemCopy keeps the data of the assets in string constants, as
+	// Config.NoMemCopy does for all assets. This has no effect in the
+	// single blob layout.
+	NoMemCopy bool
+
+	// Minify minifies the assets, as if the extension was listed
+	// in Config.Minify.
+	Minify bool
+
+	// Transforms are applied to the assets after the transforms
+	// of the configuration, and before minification.
+	Transforms []TransformFunc
+}
+
+// validateExtensions ensures the per extension settings
+// do not contradict each other.
+func validateExtensions(c *Config) error {
+	for ext, opts := range c.PerExtension {
+		if opts.NoCompress && opts.ForceCompress {
+			return fmt.Errorf("Extension %s cannot be both compressed and not compressed", ext)
+		}
+
+		if _, ok := minifiers[minifyExt(ext)]; opts.Minify && !ok {
+			return fmt.Errorf("Unsupported minify extension %q", ext)
+		}
+	}
+	return nil
+}
+
+// extOptions returns the settings for the given file extension.
+// Extensions are compared without regard to case, with or without
+// a leading dot.
+func (c *Config) extOptions(ext string) AssetOptions {
+	if len(ext) == 0 {
+		return AssetOptions{}
+	}
+
+	ext = minifyExt(ext)
+	for key, opts := range c.PerExtension {
+		if minifyExt(key) == ext {
+			return opts
+		}
+	}
+	return AssetOptions{}
+}
+
+// assetOptions returns the settings for the given asset.
+func (c *Config) assetOptions(asset *Asset) AssetOptions {
+	return c.extOptions(filepath.Ext(asset.Path))
+}
+
+// assetStringData reports whether the data of the given
+// asset is kept in string constants.
+func (c *Config) assetStringData(asset *Asset) bool {
+	return c.stringData() || !c.SingleBlob && c.assetOptions(asset).NoMemCopy
+}
+
+// extNoMemCopy reports whether some assets are kept in string constants,
+// while the others are not. The generated code then needs the functions
+// reading string data in addition to the usual ones.
+func (c *Config) extNoMemCopy() bool {
+	if c.stringData() || c.SingleBlob || c.Debug {
+		return false
+	}
+
+	for _, opts := range c.PerExtension {
+		if opts.NoMemCopy {
+			return true
+		}
+	}
+	return false
+}
+
+// extTransform returns a TransformFunc, which applies the transforms
+// configured for the extension of each asset. It returns nil if there
+// are none.
+func (c *Config) extTransform() TransformFunc {
+	found := false
+	for _, opts := range c.PerExtension {
+		found = found || len(opts.Transforms) > 0
+	}
+	if !found {
+		return nil
+	}
+
+	return func(name string, r io.Reader) (io.Reader, error) {
+		var err error
+		for _, transform := range c.extOptions(path.Ext(name)).Transforms {
+			r, err = transform(name, r)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return r, nil
+	}
+}
+
+// extensionsKey describes the per extension settings affecting the code
+// of an asset, for the incremental cache. Like other transforms, the
+// transforms are not noticed.
+func extensionsKey(c *Config) string {
+	list := make([]string, 0, len(c.PerExtension))
+	for ext, opts := range c.PerExtension {
+		list = append(list, fmt.Sprintf("%s:%v/%v/%v/%v", minifyExt(ext),
+			opts.NoCompress, opts.ForceCompress, opts.NoMemCopy, opts.Minify))
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
+
+// header_read_string writes bindata_read_string, which reads compressed
+// data held in string form, when only some assets are kept in strings.
+func header_read_string(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `func bindata_read_string(data, name string) ([]byte, error) {
+	b, err := bindata_read_raw(data, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return bindata_read(b, name)
+}
+
+`)
+	return err
+}
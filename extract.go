@@ -0,0 +1,168 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// extractKeyLen is the number of bytes of the hash naming
+// the directory written by ExtractAll.
+const extractKeyLen = 8
+
+// extractKey returns the hex encoded hash of the names, modes and
+// contents of the assets, which names the directory they are extracted
+// to. It is the same as bindata_extract_key computes in debug builds.
+func extractKey(toc []Asset) (string, error) {
+	list := make([]*Asset, len(toc))
+	for i := range toc {
+		list[i] = &toc[i]
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	h := sha256.New()
+	for _, asset := range list {
+		fi, err := asset.stat()
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(h, "%s\x00%o\x00%x\x00", asset.Name, fi.Mode(), asset.Digest)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:extractKeyLen]), nil
+}
+
+// writeExtract writes the ExtractAll function. Release builds embed
+// the hash naming the directory, debug builds compute it when asked.
+func writeExtract(w io.Writer, c *Config, toc []Asset) error {
+	_, err := fmt.Fprintf(w, `// ExtractAll writes all assets to a directory below dir, and returns
+// its path. If dir is empty, the cache directory of the user is used,
+// or the directory for temporary files if there is none. The files are
+// written with their recorded modes and modification times, so helper
+// executables and native libraries can be run and loaded from there.
+//
+// The directory is named after a hash of the names, modes and contents
+// of the assets. It is only renamed to this name once all assets were
+// written, so an existing directory of that name holds the same assets,
+// and is used as it is. Later calls return the path right away.
+func ExtractAll(dir string) (string, error) {
+	_bindata_extract_mu.Lock()
+	defer _bindata_extract_mu.Unlock()
+
+	if dir == "" {
+		cache, err := os.UserCacheDir()
+		if err != nil {
+			cache = os.TempDir()
+		}
+		dir = cache
+	}
+
+	key, err := bindata_extract_key()
+	if err != nil {
+		return "", err
+	}
+
+	target := filepath.Join(dir, "bindata-"+key)
+	if _bindata_extracted[target] {
+		return target, nil
+	}
+
+	if fi, err := os.Lstat(target); err == nil && fi.IsDir() {
+		_bindata_extracted[target] = true
+		return target, nil
+	}
+
+	err = os.MkdirAll(dir, os.FileMode(0755))
+	if err != nil {
+		return "", err
+	}
+
+	temp, err := %s(dir, "bindata-"+key+".tmp")
+	if err != nil {
+		return "", err
+	}
+
+	err = RestoreAssets(temp, "")
+	if err == nil {
+		err = os.Rename(temp, target)
+	}
+	if err != nil {
+		os.RemoveAll(temp)
+
+		// Another process may have extracted the assets meanwhile.
+		if fi, serr := os.Lstat(target); serr != nil || !fi.IsDir() {
+			return "", err
+		}
+	}
+
+	_bindata_extracted[target] = true
+	return target, nil
+}
+
+var (
+	_bindata_extract_mu sync.Mutex
+	_bindata_extracted  = make(map[string]bool)
+)
+
+`, c.ioutil("TempDir"))
+	if err != nil {
+		return err
+	}
+
+	if c.Debug {
+		return writeDebugExtractKey(w, c)
+	}
+
+	key, err := extractKey(toc)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `// bindata_extract_key returns the hash naming the directory
+// written by ExtractAll, as computed during generation.
+func bindata_extract_key() (string, error) {
+	return %q, nil
+}
+
+`, key)
+	return err
+}
+
+// writeDebugExtractKey writes a bindata_extract_key function,
+// which hashes the assets read from disk.
+func writeDebugExtractKey(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// bindata_extract_key returns the hash naming the directory
+// written by ExtractAll. In debug builds, all assets are read
+// from disk and hashed.
+func bindata_extract_key() (string, error) {
+	names := AssetNames()
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		data, err := AssetUnsafe(%s)
+		if err != nil {
+			return "", err
+		}
+
+		info, err := AssetInfo(%s)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(h, "%%s\x00%%o\x00%%x\x00", name, info.Mode(), sha256.Sum256(data))
+	}
+	return hex.EncodeToString(h.Sum(nil)[:%d]), nil
+}
+
+`, c.nameArg("name"), c.nameArg("name"), extractKeyLen)
+	return err
+}
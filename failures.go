@@ -0,0 +1,95 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+)
+
+// AssetError describes a file, or a directory of assets, which could
+// not be read, and was left out with Config.ContinueOnError.
+type AssetError struct {
+	Path string
+	Err  error
+}
+
+func (e *AssetError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *AssetError) Unwrap() error {
+	return e.Err
+}
+
+// AssetErrors is returned by Translate with Config.ContinueOnError, if
+// some files or directories could not be read. The code is generated
+// without them nevertheless.
+type AssetErrors []*AssetError
+
+// Error summarizes the errors, naming the first of them.
+func (e AssetErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	return fmt.Sprintf("%d files or directories could not be read, the first being %v", len(e), e[0])
+}
+
+// Unwrap returns the errors, so they are found by errors.Is and errors.As.
+func (e AssetErrors) Unwrap() []error {
+	list := make([]error, len(e))
+	for i := range e {
+		list[i] = e[i]
+	}
+	return list
+}
+
+// add records the error reading the given path. The path is
+// dropped from the error itself, as it is reported anyway.
+func (e *AssetErrors) add(path string, err error) {
+	var pe *fs.PathError
+	if errors.As(err, &pe) {
+		err = pe.Err
+	}
+
+	*e = append(*e, &AssetError{Path: path, Err: err})
+}
+
+// readableAssets returns the assets whose files can be read, and
+// records the others, like those vanished since they were found.
+func readableAssets(toc []Asset, failed *AssetErrors) []Asset {
+	readable := toc[:0]
+	for _, asset := range toc {
+		err := checkReadable(&asset)
+		if err != nil {
+			failed.add(asset.Path, err)
+			continue
+		}
+
+		readable = append(readable, asset)
+	}
+	return readable
+}
+
+// checkReadable reads the first byte of the asset,
+// to ensure its file can be opened and read.
+func checkReadable(asset *Asset) error {
+	fd, err := asset.open()
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	var b [1]byte
+	_, err = fd.Read(b[:])
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
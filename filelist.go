@@ -0,0 +1,117 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ReadFileList returns an input for each file listed in r, one per line.
+// A file is embedded under its path, less the prefix, unless the line
+// gives its name after an arrow, as in:
+//
+//	build/app.3f2a.js => js/app.js
+//
+// Empty lines and lines starting with # are skipped. Build systems which
+// know the files they produce can list them this way, so no directories
+// are walked.
+func ReadFileList(r io.Reader) ([]InputConfig, error) {
+	var inputs []InputConfig
+
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		var input InputConfig
+		if i := strings.Index(line, "=>"); i >= 0 {
+			input.Path = strings.TrimSpace(line[:i])
+			input.Name = strings.TrimSpace(line[i+2:])
+			if len(input.Name) == 0 {
+				return nil, fmt.Errorf("File list line %d: missing name after =>", n)
+			}
+		} else {
+			input.Path = line
+		}
+
+		if len(input.Path) == 0 {
+			return nil, fmt.Errorf("File list line %d: missing path", n)
+		}
+
+		inputs = append(inputs, input)
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	return inputs, nil
+}
+
+// readFileList returns the inputs listed in the named file.
+func readFileList(name string) ([]InputConfig, error) {
+	fd, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	inputs, err := ReadFileList(fd)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+
+	return inputs, nil
+}
+
+// validateName ensures an input with a name names a single local file.
+func validateName(input *InputConfig) error {
+	switch {
+	case len(input.Name) == 0:
+		return nil
+	case isRemote(input.Path) || isArchive(input.Path):
+		return fmt.Errorf("Input '%s' cannot be given a name, as it is not a single file", input.Path)
+	case input.Recursive:
+		return fmt.Errorf("Input '%s' cannot be given a name and be recursive", input.Path)
+	case len(assetName(input.Name)) == 0:
+		return fmt.Errorf("Invalid name '%s' for input '%s'", input.Name, input.Path)
+	}
+
+	fi, err := os.Stat(input.Path)
+	if err == nil && fi.IsDir() {
+		return fmt.Errorf("Input '%s' cannot be given a name, as it is a directory", input.Path)
+	}
+
+	return nil
+}
+
+// findFile adds the asset for an input naming a single file. It is
+// named by the input, if it has a name, or like any other asset.
+func findFile(c *Config, input *InputConfig, toc *[]Asset, knownFuncs map[string]int) error {
+	if len(input.Name) == 0 {
+		return findFiles(input.Path, c.prefix(input), false, toc, newFilter(c, input), knownFuncs)
+	}
+
+	var found []Asset
+	err := findFiles(input.Path, c.prefix(input), false, &found, newFilter(c, input), make(map[string]int))
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range found {
+		asset.Name = assetName(input.Name)
+		asset.Func = safeFunctionName(asset.Name, knownFuncs)
+		*toc = append(*toc, asset)
+	}
+
+	return nil
+}
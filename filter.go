@@ -0,0 +1,113 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// fileFilter decides which files below an input path are included.
+type fileFilter struct {
+	// root is the input directory. Include patterns containing a slash
+	// are matched against paths relative to it. It is set by findFiles
+	// when left empty.
+	root string
+
+	ignore  []*regexp.Regexp // Patterns matched against the file path.
+	include []string         // Glob patterns; if set, files must match one.
+	rules   *ignoreFile      // Rules of the ignore file of the input, if any.
+
+	// failures records the directories which cannot be read, which
+	// are skipped with Config.ContinueOnError. It is nil otherwise.
+	failures *AssetErrors
+
+	// walked holds the directory listings shared by all bundles
+	// generated in the same run, if any.
+	walked walkCache
+}
+
+// hiddenPattern matches files and directories whose name starts with
+// a dot, like .DS_Store or .cache, for the IgnoreHidden option.
+var hiddenPattern = regexp.MustCompile(`(^|[/\\])\.[^/\\]*$`)
+
+// vcsPattern matches the metadata of version control systems and the
+// directories of installed dependencies, for the IgnoreVCS option.
+var vcsPattern = regexp.MustCompile(`(^|[/\\])(\.git(attributes|ignore|keep|modules)?|\.hg(ignore|tags)?|\.svn|\.bzr(ignore)?|CVS|_darcs|node_modules|bower_components)$`)
+
+// newFilter returns the filter for the given input, combining
+// the global patterns with those of the input itself.
+func newFilter(c *Config, input *InputConfig) *fileFilter {
+	f := new(fileFilter)
+	if c.IgnoreHidden {
+		f.ignore = append(f.ignore, hiddenPattern)
+	}
+	if c.IgnoreVCS {
+		f.ignore = append(f.ignore, vcsPattern)
+	}
+	f.ignore = append(f.ignore, c.Ignore...)
+	f.ignore = append(f.ignore, input.Ignore...)
+	f.include = append(f.include, c.Include...)
+	f.include = append(f.include, input.Include...)
+	f.walked = c.walked
+
+	// Errors reading the ignore file are reported by validate.
+	f.rules, _ = readIgnoreFile(input.Path)
+	return f
+}
+
+// ignored reports whether the given file or directory matches any
+// of the ignore patterns, or is ignored by the rules of the input.
+func (f *fileFilter) ignored(file string, dir bool) bool {
+	for _, re := range f.ignore {
+		if re.MatchString(file) {
+			return true
+		}
+	}
+	return f.rules != nil && f.rules.ignored(file, dir)
+}
+
+// included reports whether the given file matches the include patterns.
+// Patterns without a slash are matched against the base name of the file,
+// all others against its slash separated path relative to the root.
+// Without any patterns, all files are included.
+func (f *fileFilter) included(file string) bool {
+	if len(f.include) == 0 {
+		return true
+	}
+
+	rel, err := filepath.Rel(f.root, file)
+	if err != nil {
+		rel = file
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, pattern := range f.include {
+		name := rel
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(rel)
+		}
+
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateInclude ensures the given glob patterns are well formed.
+func validateInclude(patterns []string) error {
+	for _, pattern := range patterns {
+		_, err := path.Match(pattern, "")
+		if err != nil {
+			return fmt.Errorf("Invalid include pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
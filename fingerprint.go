@@ -0,0 +1,154 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// fingerprintLen is the number of digest bytes in a fingerprinted name.
+const fingerprintLen = 4
+
+// hashedName inserts the hex encoded start of the digest into the name,
+// before its extension. Names without an extension, or which consist of
+// an extension only, like ".htaccess", get the hash appended.
+func hashedName(name string, digest []byte) string {
+	hash := hex.EncodeToString(digest[:fingerprintLen])
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if len(base) == 0 || strings.HasSuffix(base, "/") {
+		return name + "." + hash
+	}
+	return base + "." + hash + ext
+}
+
+// writeFingerprints writes the HashedName and UnhashedName functions.
+// Release builds embed the names computed from the digests recorded
+// during generation, debug builds hash the assets when asked.
+func writeFingerprints(w io.Writer, c *Config, toc []Asset) error {
+	if c.Debug {
+		return writeDebugFingerprints(w, c)
+	}
+
+	_, err := fmt.Fprintf(w, `// HashedName returns the name of the asset with a hash of its contents
+// inserted before the extension, like "css/app.3f9ab2c1.css". As the name
+// changes along with the contents, it can be cached by clients for good.
+// AssetHandler serves the asset under this name as well. The name is
+// returned unchanged if the asset does not exist.
+func HashedName(name %s) string {
+	cannonicalName := bindata_clean(%s)
+	if hashed, ok := _bindata_hashed[cannonicalName]; ok {
+		return hashed
+	}
+	return %s
+}
+
+// UnhashedName returns the name of the asset with the given hashed name,
+// as returned by HashedName. It reports whether there is such an asset.
+func UnhashedName(hashed string) (string, bool) {
+	name, ok := _bindata_unhashed[hashed]
+	return name, ok
+}
+
+`, c.nameType(), c.stringArg("name"), c.stringArg("name"))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(toc))
+	for i := range toc {
+		names[i] = hashedName(toc[i].Name, toc[i].Digest[:])
+	}
+
+	_, err = fmt.Fprintf(w, "// _bindata_hashed holds the hashed name of each asset, mapped to its name.\nvar _bindata_hashed = map[string]string{\n")
+	if err != nil {
+		return err
+	}
+
+	for i := range toc {
+		_, err = fmt.Fprintf(w, "\t%q: %q,\n", toc[i].Name, names[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n// _bindata_unhashed maps the hashed names to the asset names.\nvar _bindata_unhashed = map[string]string{\n")
+	if err != nil {
+		return err
+	}
+
+	for i := range toc {
+		_, err = fmt.Fprintf(w, "\t%q: %q,\n", names[i], toc[i].Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
+
+// writeDebugFingerprints writes fingerprint functions,
+// which hash the assets read from disk.
+func writeDebugFingerprints(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// HashedName returns the name of the asset with a hash of its contents
+// inserted before the extension, like "css/app.3f9ab2c1.css". As the name
+// changes along with the contents, it can be cached by clients for good.
+// AssetHandler serves the asset under this name as well. The name is
+// returned unchanged if the asset does not exist. In debug builds, the
+// asset is read from disk and hashed on every call.
+func HashedName(name %s) string {
+	data, err := AssetUnsafe(name)
+	if err != nil {
+		return %s
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:%d])
+	ext := path.Ext(%s)
+	base := strings.TrimSuffix(%s, ext)
+	if base == "" || strings.HasSuffix(base, "/") {
+		return %s + "." + hash
+	}
+	return base + "." + hash + ext
+}
+
+// UnhashedName returns the name of the asset with the given hashed name,
+// as returned by HashedName. It reports whether there is such an asset.
+// In debug builds, all assets are hashed to find it.
+func UnhashedName(hashed string) (string, bool) {
+	for _, name := range AssetNames() {
+		if HashedName(%s) == hashed {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+`, c.nameType(), c.stringArg("name"), fingerprintLen, c.stringArg("name"),
+		c.stringArg("name"), c.stringArg("name"), c.nameArg("name"))
+	return err
+}
+
+// serveHashed returns the part of bindata_serve, which serves
+// assets requested by their hashed names.
+func serveHashed(c *Config) string {
+	if !c.Fingerprints {
+		return ""
+	}
+
+	return `	if !ok {
+		if original, found := UnhashedName(name); found {
+			name = original
+			f, ok = bindata_lookup(name)
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		}
+	}
+`
+}
@@ -0,0 +1,77 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+)
+
+// validateFormat ensures formatting is not combined with the
+// incremental cache, which records offsets in the unformatted code.
+func validateFormat(c *Config) error {
+	if c.Format && len(c.IncrementalCache) > 0 && !c.Debug && !c.SingleBlob && !c.SplitOutput {
+		return fmt.Errorf("Format cannot be combined with an incremental cache")
+	}
+	return nil
+}
+
+// writeSource is like writeFile, but passes the generated code through
+// go/format before writing it, if Format is enabled. Code which does
+// not parse is written to a temporary file for inspection instead.
+func writeSource(c *Config, name string, fn func(w io.Writer) error) error {
+	if !c.Format {
+		return writeFile(c, name, fn)
+	}
+
+	src, err := formatSource(name, fn)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(c, name, func(w io.Writer) error {
+		_, err := w.Write(src)
+		return err
+	})
+}
+
+// formatSource returns the code written by fn, formatted with go/format.
+// Code which does not parse is written to a temporary file for inspection.
+func formatSource(name string, fn func(w io.Writer) error) ([]byte, error) {
+	var buf bytes.Buffer
+	err := fn(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, formatError(name, buf.Bytes(), err)
+	}
+
+	return src, nil
+}
+
+// formatError returns the error for generated code which failed to
+// format, after writing the code to a temporary file. Line numbers
+// in the error refer to that file.
+func formatError(name string, src []byte, err error) error {
+	fd, terr := ioutil.TempFile("", "bindata-*.go")
+	if terr != nil {
+		return fmt.Errorf("Generated code for %s is invalid: %v", name, err)
+	}
+
+	defer fd.Close()
+
+	_, terr = fd.Write(src)
+	if terr != nil {
+		return fmt.Errorf("Generated code for %s is invalid: %v", name, err)
+	}
+
+	return fmt.Errorf("Generated code for %s is invalid: %v (written to %s)", name, err, fd.Name())
+}
@@ -0,0 +1,266 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// writeFS writes an io/fs implementation backed by the
+// table of contents and the asset tree.
+func writeFS(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `// AssetFS returns a file system holding the embedded assets.
+// The returned value implements fs.FS, fs.ReadDirFS, fs.StatFS and fs.GlobFS.
+func AssetFS() fs.FS {
+	return bindata_fs{}
+}
+
+type bindata_fs struct{}
+
+// Open implements fs.FS.
+func (bindata_fs) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	if f, ok := bindata_lookup(name); ok {
+		a, err := f()
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		info := bindata_fs_info{FileInfo: a.info, name: path.Base(name)}
+		return &bindata_fs_file{Reader: bytes.NewReader(a.bytes), info: info}, nil
+	}
+	entries, err := bindata_fs_readdir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	info := bindata_dir_info{name: path.Base(name)}
+	return &bindata_fs_dir{info: info, entries: entries}, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (bindata_fs) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	entries, err := bindata_fs_readdir(name)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
+
+// Stat implements fs.StatFS.
+func (bindata_fs) Stat(name string) (fs.FileInfo, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrInvalid}
+	}
+	if f, ok := bindata_lookup(name); ok {
+		a, err := f()
+		if err != nil {
+			return nil, &fs.PathError{Op: "stat", Path: name, Err: err}
+		}
+		return bindata_fs_info{FileInfo: a.info, name: path.Base(name)}, nil
+	}
+	if bindata_fs_node(name) == nil {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+	return bindata_dir_info{name: path.Base(name)}, nil
+}
+
+// Glob implements fs.GlobFS. Unlike AssetGlob,
+// it matches the names of directories as well.
+func (bindata_fs) Glob(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	var names []string
+	var walk func(node *_bintree_t, dir string)
+	walk = func(node *_bintree_t, dir string) {
+		for child, next := range node.Children {
+			name := path.Join(dir, child)
+			if ok, _ := path.Match(pattern, name); ok {
+				names = append(names, name)
+			}
+			walk(next, name)
+		}
+	}
+	walk(bindata_tree(), "")
+	sort.Strings(names)
+	return names, nil
+}
+
+// bindata_fs_node returns the asset tree node for the directory
+// with the given name, or nil if there is no such directory.
+func bindata_fs_node(name string) *_bintree_t {
+	node := bindata_tree()
+	if name == "." {
+		return node
+	}
+	for _, p := range strings.Split(name, "/") {
+		node = node.Children[p]
+		if node == nil {
+			return nil
+		}
+	}
+	if node.Func != nil {
+		return nil
+	}
+	return node
+}
+
+// bindata_fs_readdir returns the sorted entries of the given directory.
+func bindata_fs_readdir(name string) ([]fs.DirEntry, error) {
+	node := bindata_fs_node(name)
+	if node == nil {
+		return nil, fs.ErrNotExist
+	}
+	names := make([]string, 0, len(node.Children))
+	for child := range node.Children {
+		names = append(names, child)
+	}
+	sort.Strings(names)
+	entries := make([]fs.DirEntry, 0, len(names))
+	for _, child := range names {
+		var info fs.FileInfo = bindata_dir_info{name: child}
+		if f := node.Children[child].Func; f != nil {
+			a, err := f()
+			if err != nil {
+				return nil, err
+			}
+			info = bindata_fs_info{FileInfo: a.info, name: child}
+		}
+		entries = append(entries, fs.FileInfoToDirEntry(info))
+	}
+	return entries, nil
+}
+
+// bindata_fs_info overrides the name of an asset's file info,
+// as the io/fs interfaces expect base names only.
+type bindata_fs_info struct {
+	fs.FileInfo
+	name string
+}
+
+func (fi bindata_fs_info) Name() string {
+	return fi.name
+}
+
+type bindata_fs_file struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f *bindata_fs_file) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+func (f *bindata_fs_file) Close() error {
+	return nil
+}
+
+type bindata_fs_dir struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *bindata_fs_dir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+func (d *bindata_fs_dir) Close() error {
+	return nil
+}
+func (d *bindata_fs_dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: fs.ErrInvalid}
+}
+func (d *bindata_fs_dir) ReadDir(count int) ([]fs.DirEntry, error) {
+	n := len(d.entries) - d.offset
+	if count > 0 && n == 0 {
+		return nil, io.EOF
+	}
+	if count > 0 && n > count {
+		n = count
+	}
+	list := d.entries[d.offset : d.offset+n]
+	d.offset += n
+	return list, nil
+}
+
+`)
+	return err
+}
+
+// writeOverlay writes the OverlayFS function, whose file system
+// prefers files found on disk over the embedded assets.
+func writeOverlay(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `// OverlayFS returns a file system which reads files from the given directory
+// on disk, and falls back to the embedded assets for files which do not exist
+// there. Directory listings merge both, with files on disk taking precedence.
+// This allows replacing individual assets of a deployed binary.
+func OverlayFS(dir string) fs.FS {
+	return bindata_overlay_fs{disk: os.DirFS(dir)}
+}
+
+type bindata_overlay_fs struct {
+	disk fs.FS
+}
+
+// Open implements fs.FS.
+func (o bindata_overlay_fs) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	info, err := fs.Stat(o.disk, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return bindata_fs{}.Open(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return o.disk.Open(name)
+	}
+	entries, err := o.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	return &bindata_fs_dir{info: info, entries: entries}, nil
+}
+
+// ReadDir implements fs.ReadDirFS.
+func (o bindata_overlay_fs) ReadDir(name string) ([]fs.DirEntry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	disk, err := fs.ReadDir(o.disk, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return bindata_fs{}.ReadDir(name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	embedded, err := bindata_fs_readdir(name)
+	if err != nil {
+		return disk, nil
+	}
+	seen := make(map[string]bool, len(disk))
+	for _, entry := range disk {
+		seen[entry.Name()] = true
+	}
+	for _, entry := range embedded {
+		if !seen[entry.Name()] {
+			disk = append(disk, entry)
+		}
+	}
+	sort.Slice(disk, func(i, j int) bool {
+		return disk[i].Name() < disk[j].Name()
+	})
+	return disk, nil
+}
+
+`)
+	return err
+}
@@ -0,0 +1,55 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+//go:build fsnotify
+// +build fsnotify
+
+package bindata
+
+import (
+	"github.com/fsnotify/fsnotify"
+)
+
+func init() {
+	notifyChanges = func(dirs []string) (<-chan struct{}, func(dirs []string), func(), error) {
+		w, err := fsnotify.NewWatcher()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// Directories which vanished in the meantime are seen
+		// as changes anyway, so errors adding them are ignored.
+		add := func(dirs []string) {
+			for _, dir := range dirs {
+				w.Add(dir)
+			}
+		}
+		add(dirs)
+
+		// An error, like an overflow of the queue of events,
+		// may hide a change, so it is reported as one.
+		changes := make(chan struct{}, 1)
+		go func() {
+			for {
+				select {
+				case _, ok := <-w.Events:
+					if !ok {
+						return
+					}
+				case _, ok := <-w.Errors:
+					if !ok {
+						return
+					}
+				}
+
+				select {
+				case changes <- struct{}{}:
+				default:
+				}
+			}
+		}()
+
+		return changes, add, func() { w.Close() }, nil
+	}
+}
@@ -0,0 +1,115 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+)
+
+// FuncNaming selects how the identifiers of the functions
+// generated for the assets are derived from their names.
+type FuncNaming int
+
+// Known function naming strategies.
+const (
+	FuncSnakeCase FuncNaming = iota // Like css_app_min_css for css/app.min.css. This is the default.
+	FuncCamelCase                   // Like cssAppMinCss for css/app.min.css.
+)
+
+func (v FuncNaming) String() string {
+	switch v {
+	case FuncSnakeCase:
+		return "snake"
+	case FuncCamelCase:
+		return "camel"
+	}
+	return fmt.Sprintf("FuncNaming(%d)", int(v))
+}
+
+// ParseFuncNaming returns the function naming strategy with the given
+// name, as returned by its String method.
+func ParseFuncNaming(name string) (FuncNaming, error) {
+	for _, v := range []FuncNaming{FuncSnakeCase, FuncCamelCase} {
+		if v.String() == name {
+			return v, nil
+		}
+	}
+	return FuncSnakeCase, fmt.Errorf("Unknown function naming %q", name)
+}
+
+// customFuncs reports whether the function names of the assets are
+// assigned once they are known, rather than while they are found.
+func (c *Config) customFuncs() bool {
+	return c.FuncNaming != FuncSnakeCase || c.FuncNameFor != nil
+}
+
+// reservedFuncName reports whether the given identifier is a keyword,
+// a predeclared identifier, or one of the generated code, which must not
+// be redeclared by the function of an asset.
+func reservedFuncName(name string) bool {
+	return token.IsKeyword(name) || types.Universe.Lookup(name) != nil ||
+		name == "asset" || name == "init" || name == "main"
+}
+
+// camelCaseName converts the given asset name into an identifier,
+// which starts with a lower case letter, and starts every following
+// word with an upper case one.
+func camelCaseName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for i, word := range words {
+		word = strings.ToLower(word)
+		if i > 0 {
+			word = strings.ToUpper(word[:1]) + word[1:]
+		}
+		b.WriteString(word)
+	}
+
+	ident := b.String()
+	if len(ident) == 0 || unicode.IsDigit(rune(ident[0])) {
+		ident = "_" + ident
+	}
+	return ident
+}
+
+// funcName returns the identifier for the function of the named
+// asset, as derived by the configured strategy.
+func (c *Config) funcName(name string) (string, error) {
+	if c.FuncNameFor == nil {
+		if c.FuncNaming == FuncCamelCase {
+			return camelCaseName(name), nil
+		}
+		return snakeCaseName(name), nil
+	}
+
+	ident := c.FuncNameFor(name)
+	if !token.IsIdentifier(ident) || token.IsExported(ident) || ident == "_" {
+		return "", fmt.Errorf("Invalid function name %q for asset %s, want an unexported identifier", ident, name)
+	}
+	return ident, nil
+}
+
+// nameFuncs assigns the function names of the assets, using the
+// configured strategy. Names derived for more than one asset get
+// numeric suffixes, as in css_app_css2.
+func nameFuncs(c *Config, toc []Asset) error {
+	knownFuncs := make(map[string]int, len(toc))
+	for i := range toc {
+		ident, err := c.funcName(toc[i].Name)
+		if err != nil {
+			return err
+		}
+
+		toc[i].Func = uniqueFuncName(ident, knownFuncs)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// goVersionMod is the value of Config.GoVersion which selects the
+// version declared by the go.mod file governing the output.
+const goVersionMod = "mod"
+
+// ioutilFuncs maps the functions of io/ioutil used by the generated
+// code to their replacements, which are available since Go 1.16.
+var ioutilFuncs = map[string]string{
+	"ReadAll":   "io.ReadAll",
+	"ReadDir":   "os.ReadDir",
+	"ReadFile":  "os.ReadFile",
+	"TempDir":   "os.MkdirTemp",
+	"WriteFile": "os.WriteFile",
+}
+
+// goVersionPattern matches a Go version, like 1.21, go1.21, 1.21.3 or
+// 1.21rc1, holding the minor version in its submatch.
+var goVersionPattern = regexp.MustCompile(`^(?:go)?1\.(\d+)(?:\.\d+|(?:rc|beta)\d+)?$`)
+
+// parseGoVersion returns the minor version of the given Go version.
+func parseGoVersion(version string) (int, error) {
+	m := goVersionPattern.FindStringSubmatch(version)
+	if m == nil {
+		return 0, fmt.Errorf("Invalid Go version %q", version)
+	}
+
+	minor, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, fmt.Errorf("Invalid Go version %q", version)
+	}
+	return minor, nil
+}
+
+// moduleGoVersion returns the go directive of the go.mod file in the
+// directory of the given output, or in the closest one above it.
+func moduleGoVersion(output string) (string, error) {
+	dir, err := filepath.Abs(filepath.Dir(output))
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		name := filepath.Join(dir, "go.mod")
+		fd, err := os.Open(name)
+		if err == nil {
+			defer fd.Close()
+			return goDirective(fd, name)
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("No go.mod found for %s", output)
+		}
+		dir = parent
+	}
+}
+
+// goDirective returns the version of the go directive of the given
+// go.mod file.
+func goDirective(fd *os.File, name string) (string, error) {
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "go" {
+			return fields[1], nil
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", fmt.Errorf("Missing go directive in %s", name)
+}
+
+// validateGoVersion checks Config.GoVersion, and records the minor
+// version the generated code targets.
+func validateGoVersion(c *Config) error {
+	c.goMinor = 0
+	version := c.GoVersion
+	if len(version) == 0 {
+		return nil
+	}
+
+	if version == goVersionMod {
+		var err error
+		version, err = moduleGoVersion(c.Output)
+		if err != nil {
+			return err
+		}
+	}
+
+	minor, err := parseGoVersion(version)
+	if err != nil {
+		return err
+	}
+
+	c.goMinor = minor
+	return nil
+}
+
+// goAtLeast reports whether the generated code targets
+// Go 1.minor or later.
+func (c *Config) goAtLeast(minor int) bool {
+	return c.goMinor >= minor
+}
+
+// anyType returns the empty interface type, as spelled in
+// the generated code.
+func (c *Config) anyType() string {
+	if c.goAtLeast(18) {
+		return "any"
+	}
+	return "interface{}"
+}
+
+// ioutil returns the function of io/ioutil with the given name, or its
+// replacement if the generated code targets Go 1.16 or later.
+func (c *Config) ioutil(name string) string {
+	if c.goAtLeast(16) {
+		return ioutilFuncs[name]
+	}
+	return "ioutil." + name
+}
@@ -0,0 +1,42 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"go/token"
+	"path"
+)
+
+// hasGrateInit reports whether the grate init function is generated.
+func (c *Config) hasGrateInit() bool {
+	return len(c.GrateImport) > 0 && len(c.GrateHooks) > 0 && !c.Compat
+}
+
+// gratePackage returns the name of the grate package, which is
+// the last element of its import path.
+func (c *Config) gratePackage() string {
+	return path.Base(c.GrateImport)
+}
+
+// validateGrate ensures the grate package name and the
+// hooked variables are valid identifiers.
+func validateGrate(c *Config) error {
+	if !c.hasGrateInit() {
+		return nil
+	}
+
+	if !token.IsIdentifier(c.gratePackage()) {
+		return fmt.Errorf("Invalid grate import path %q", c.GrateImport)
+	}
+
+	for fn, v := range c.GrateHooks {
+		if !token.IsIdentifier(v) {
+			return fmt.Errorf("Invalid grate variable %q for %s", v, fn)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// writeHandler writes an http.Handler serving the embedded assets.
+func writeHandler(w io.Writer, c *Config, toc []Asset) error {
+	_, err := fmt.Fprintf(w, `// AssetHandler returns an http.Handler serving the embedded assets.
+// The request path, without its leading slash, is used as asset name.
+// Responses carry a Content-Type derived from the asset name,
+// a Last-Modified header from the recorded modification time and
+// a strong ETag computed from the asset contents. Conditional and
+// range requests are handled by http.ServeContent.
+func AssetHandler() http.Handler {
+	return http.HandlerFunc(bindata_serve)
+}
+
+func bindata_serve(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	f, ok := bindata_lookup(name)
+%s%s	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+%s	a, err := f()
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+%s	w.Header().Set("ETag", bindata_etag(name, a.bytes))
+	http.ServeContent(w, r, name, a.info.ModTime(), bytes.NewReader(a.bytes))
+}
+
+`, serveHashed(c), serveFallback(c), servePrecompressed(c), serveContentType(c))
+	if err != nil {
+		return err
+	}
+
+	if len(c.IndexFallback) > 0 {
+		err = writeFallback(w, c, toc)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.precompressed() {
+		err = writePrecompressed(w, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.Debug {
+		return writeDebugETag(w)
+	}
+
+	return writeReleaseETags(w, toc)
+}
+
+// precompressed reports whether the handler serves compressed data as it is.
+func (c *Config) precompressed() bool {
+	return c.Precompressed && !c.Debug && c.compression() != CompressNone && !c.encrypt() && !c.sharedDictionary()
+}
+
+// servePrecompressed returns the part of bindata_serve, which sends
+// the compressed data to clients accepting it.
+func servePrecompressed(c *Config) string {
+	if !c.precompressed() {
+		return ""
+	}
+
+	return `	w.Header().Add("Vary", "Accept-Encoding")
+	if bindata_serve_compressed(w, r, name) {
+		return
+	}
+`
+}
+
+// serveFallback returns the part of bindata_serve, which looks
+// up the asset to serve for paths matching no asset.
+func serveFallback(c *Config) string {
+	if len(c.IndexFallback) == 0 {
+		return ""
+	}
+
+	return `	if !ok {
+		name, f, ok = bindata_fallback(name)
+	}
+`
+}
+
+// writeFallback writes bindata_fallback, which selects the index of
+// a directory or the fallback asset for paths matching no asset.
+func writeFallback(w io.Writer, c *Config, toc []Asset) error {
+	fallback := strings.TrimPrefix(c.IndexFallback, "/")
+	if !c.obfuscate() && !hasAsset(toc, fallback) {
+		return fmt.Errorf("Index fallback %s is not an asset", fallback)
+	}
+
+	_, err := fmt.Fprintf(w, `// _bindata_fallback_exclude holds the path prefixes,
+// which do not fall back to the index.
+var _bindata_fallback_exclude = []string{
+`)
+	if err != nil {
+		return err
+	}
+
+	for _, prefix := range c.FallbackExclude {
+		_, err = fmt.Fprintf(w, "\t%q,\n", strings.TrimPrefix(prefix, "/"))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, `}
+
+// bindata_fallback returns the asset to serve for a path matching
+// no asset: the index.html of a directory, or the fallback asset,
+// unless the path is excluded from the fallback.
+func bindata_fallback(name string) (string, func() (*asset, error), bool) {
+	index := path.Join(name, "index.html")
+	if f, ok := bindata_lookup(index); ok {
+		return index, f, true
+	}
+
+	for _, prefix := range _bindata_fallback_exclude {
+		if strings.HasPrefix(name, prefix) {
+			return name, nil, false
+		}
+	}
+
+	f, ok := bindata_lookup(%q)
+	return %q, f, ok
+}
+
+`, fallback, fallback)
+	return err
+}
+
+// hasAsset reports whether the table of contents
+// holds an asset of the given name.
+func hasAsset(toc []Asset, name string) bool {
+	for i := range toc {
+		if toc[i].Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// serveContentType returns the part of bindata_serve, which sets the
+// Content-Type determined by ContentType. Without it, http.ServeContent
+// derives the type from the name, or sniffs it.
+func serveContentType(c *Config) string {
+	if !c.ContentTypes {
+		return ""
+	}
+
+	return fmt.Sprintf(`	if ctype := ContentType(%s); ctype != "" {
+		w.Header().Set("Content-Type", ctype)
+	}
+`, c.nameArg("name"))
+}
+
+// handlerContentType returns an expression for the Content-Type
+// of the named asset, as used for precompressed data.
+func handlerContentType(c *Config) string {
+	if c.ContentTypes {
+		return fmt.Sprintf("ContentType(%s)", c.nameArg("name"))
+	}
+	return "mime.TypeByExtension(path.Ext(name))"
+}
+
+// writePrecompressed writes the functions sending compressed
+// data to clients accepting it.
+func writePrecompressed(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// bindata_serve_compressed sends the compressed data of the named asset,
+// if the client accepts its encoding. The data is sent as it is embedded,
+// with a matching Content-Encoding header. This needs a Content-Type known
+// from the asset name, as the compressed data can not be sniffed. It reports
+// whether a response was sent.
+func bindata_serve_compressed(w http.ResponseWriter, r *http.Request, name string) bool {
+	encoding := %q
+	ctype := %s
+	if ctype == "" || !bindata_accepts(r, encoding) {
+		return false
+	}
+	data, err := AssetCompressed(%s, encoding)
+	if err != nil {
+		return false
+	}
+
+	// The compressed data is a different representation,
+	// so it gets its own ETag.
+	etag := bindata_etag(name, nil)
+	etag = strings.TrimSuffix(etag, "\"") + "-" + encoding + "\""
+
+	w.Header().Set("Content-Type", ctype)
+	w.Header().Set("Content-Encoding", encoding)
+	w.Header().Set("ETag", etag)
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+	return true
+}
+
+// bindata_accepts reports whether the Accept-Encoding header
+// of the request allows the given content coding.
+func bindata_accepts(r *http.Request, encoding string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		params := strings.Split(part, ";")
+		if strings.TrimSpace(params[0]) != encoding {
+			continue
+		}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(param[2:], 64)
+				return err == nil && q > 0
+			}
+		}
+		return true
+	}
+	return false
+}
+
+`, c.compression().encoding(), handlerContentType(c), c.nameArg("name"))
+	return err
+}
+
+// writeDebugETag writes an ETag function which hashes
+// the asset contents on every request.
+func writeDebugETag(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `// bindata_etag returns the strong ETag for the given asset contents.
+func bindata_etag(name string, data []byte) string {
+	sum := sha256.Sum256(data)
+	return "\""+hex.EncodeToString(sum[:])+"\""
+}
+
+`)
+	return err
+}
+
+// writeReleaseETags writes a table of ETags computed
+// from the digests recorded during asset generation.
+func writeReleaseETags(w io.Writer, toc []Asset) error {
+	_, err := fmt.Fprintf(w, `// bindata_etag returns the strong ETag for the given asset.
+func bindata_etag(name string, data []byte) string {
+	return _bindata_etags[name]
+}
+
+// _bindata_etags holds the strong ETag of each asset, mapped to its name.
+var _bindata_etags = map[string]string{
+`)
+	if err != nil {
+		return err
+	}
+
+	for i := range toc {
+		etag := `"` + hex.EncodeToString(toc[i].Digest[:]) + `"`
+		_, err = fmt.Fprintf(w, "\t%q: %q,\n", toc[i].Name, etag)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, `}
+
+`)
+	return err
+}
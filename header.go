@@ -0,0 +1,161 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// generatedMarker starts the files written by bindata, following the
+// custom header, if any. It tells tools the code is generated, and
+// identifies the files which may be removed once they are stale.
+const generatedMarker = "// Code generated by bindata. DO NOT EDIT."
+
+// templateData holds the variables available to Config.HeaderTemplate,
+// and to the templates of snippets.
+type templateData struct {
+	Package     string    // The package of the generated code.
+	Output      string    // The output file or directory.
+	Timestamp   time.Time // The time of generation, or ModTime if set.
+	CommandLine string    // The command line of the generating program.
+	Version     string    // The version of bindata.
+	Debug       bool      // Whether this is a debug build.
+	Assets      []string  // The names of the assets, for snippets.
+}
+
+// templateData returns the variables for the templates, with
+// the names of the given assets.
+func (c *Config) templateData(toc []Asset) *templateData {
+	names := make([]string, len(toc))
+	for i := range toc {
+		names[i] = toc[i].Name
+	}
+
+	return &templateData{
+		Package:     c.Package,
+		Output:      c.Output,
+		Timestamp:   c.generatedAt(),
+		CommandLine: commandLine(),
+		Version:     Version,
+		Debug:       c.Debug,
+		Assets:      names,
+	}
+}
+
+// parseHeader parses the header template, if there is one.
+func (c *Config) parseHeader() (*template.Template, error) {
+	if len(c.HeaderTemplate) == 0 {
+		return nil, nil
+	}
+
+	t, err := template.New("header").Option("missingkey=error").Parse(c.HeaderTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid header template: %v", err)
+	}
+	return t, nil
+}
+
+// generatedAt returns the time recorded as the time of generation.
+// With ModTime set, the output is meant to be reproducible.
+func (c *Config) generatedAt() time.Time {
+	if c.ModTime != 0 {
+		return time.Unix(c.ModTime, 0).UTC()
+	}
+	return time.Now().UTC()
+}
+
+// writeFileHeader writes the custom header, followed by the marker of
+// generated code. Build constraints may only follow line comments, so
+// the header must not hold anything else if there are any.
+func writeFileHeader(w io.Writer, c *Config) error {
+	t, err := c.parseHeader()
+	if err != nil {
+		return err
+	}
+
+	if t != nil {
+		var buf bytes.Buffer
+		err = t.Execute(&buf, c.templateData(nil))
+		if err != nil {
+			return fmt.Errorf("Invalid header template: %v", err)
+		}
+
+		header := strings.TrimRight(buf.String(), "\n")
+		if len(c.Tags) > 0 && !lineComments(header) {
+			return fmt.Errorf("Header template must only produce line comments, as the output has build constraints")
+		}
+
+		if len(header) > 0 {
+			_, err = fmt.Fprintf(w, "%s\n\n", header)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n\n", generatedMarker)
+	return err
+}
+
+// lineComments reports whether all lines of the text which
+// are not blank are line comments.
+func lineComments(text string) bool {
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > 0 && !strings.HasPrefix(line, "//") {
+			return false
+		}
+	}
+	return true
+}
+
+// commandLine returns the command line of the running program, with
+// arguments quoted if needed, and the program named by its base name.
+func commandLine() string {
+	args := make([]string, len(os.Args))
+	for i, arg := range os.Args {
+		if i == 0 {
+			arg = filepath.Base(arg)
+		}
+		if len(arg) == 0 || strings.ContainsAny(arg, " \t\n\"'\\$`") {
+			arg = strconv.Quote(arg)
+		}
+		args[i] = arg
+	}
+	return strings.Join(args, " ")
+}
+
+// hasGeneratedMarker reports whether the marker of generated
+// code is found before the package clause of the given file.
+func hasGeneratedMarker(file string) (bool, error) {
+	fd, err := os.Open(file)
+	if err != nil {
+		return false, err
+	}
+
+	defer fd.Close()
+
+	s := bufio.NewScanner(fd)
+	for s.Scan() {
+		line := strings.TrimRight(s.Text(), "\r")
+		if line == generatedMarker {
+			return true, nil
+		}
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+	}
+
+	return false, s.Err()
+}
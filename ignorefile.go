@@ -0,0 +1,185 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ignoreFileName is the name of the file holding the ignore
+// rules of an input directory, in the syntax of .gitignore.
+const ignoreFileName = ".bindataignore"
+
+// ignoreRule is a rule of an ignore file.
+type ignoreRule struct {
+	pattern *regexp.Regexp // Matched against the slash separated relative path.
+	negate  bool           // The rule includes files again, as in !important.log.
+	dir     bool           // The rule only matches directories, as in build/.
+}
+
+// ignoreFile holds the rules of the ignore file in the root directory.
+type ignoreFile struct {
+	root  string
+	rules []ignoreRule
+}
+
+// readIgnoreFile reads the ignore file in the given directory. It
+// returns nil if there is none, or if dir is not a directory.
+func readIgnoreFile(dir string) (*ignoreFile, error) {
+	if fi, err := os.Stat(dir); err != nil || !fi.IsDir() {
+		return nil, nil
+	}
+
+	fd, err := os.Open(filepath.Join(dir, ignoreFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseIgnoreFile(root, fd)
+}
+
+// parseIgnoreFile parses the rules read from r, for the given root.
+// As in .gitignore files, blank lines and those starting with # are
+// skipped, and so are lines holding invalid patterns.
+func parseIgnoreFile(root string, r io.Reader) (*ignoreFile, error) {
+	f := &ignoreFile{root: root}
+
+	// The ignore file itself is never an asset.
+	f.rules = append(f.rules, ignoreRule{pattern: regexp.MustCompile("^" + regexp.QuoteMeta(ignoreFileName) + "$")})
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		rule, ok := compileIgnoreRule(s.Text())
+		if ok {
+			f.rules = append(f.rules, rule)
+		}
+	}
+
+	return f, s.Err()
+}
+
+// compileIgnoreRule compiles a line of an ignore file. It reports
+// false for lines holding no rule.
+func compileIgnoreRule(line string) (ignoreRule, bool) {
+	var rule ignoreRule
+
+	// Trailing spaces are dropped, unless they are escaped.
+	line = strings.TrimRight(line, " \t\r")
+	if strings.HasSuffix(line, "\\") {
+		line += " "
+	}
+
+	if len(line) == 0 || line[0] == '#' {
+		return rule, false
+	}
+
+	if line[0] == '!' {
+		rule.negate = true
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dir = true
+		line = strings.TrimRight(line, "/")
+	}
+
+	// Patterns with a slash are relative to the root,
+	// all others match at any level.
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+	if len(line) == 0 {
+		return rule, false
+	}
+
+	expr := "(^|.*/)"
+	if anchored {
+		expr = "^"
+	}
+
+	pattern, err := regexp.Compile(expr + globExpr(line) + "$")
+	if err != nil {
+		return rule, false
+	}
+
+	rule.pattern = pattern
+	return rule, true
+}
+
+// globExpr converts a pattern of an ignore file into a regular
+// expression. A ** matches any number of directories.
+func globExpr(pattern string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**") && i+2 == len(pattern):
+			b.WriteString(".*")
+			i++
+		case ch == '*':
+			b.WriteString("[^/]*")
+		case ch == '?':
+			b.WriteString("[^/]")
+		case ch == '[':
+			n := strings.IndexByte(pattern[i+1:], ']')
+			if n < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+
+			class := pattern[i+1 : i+1+n]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.Replace(class, `\`, `\\`, -1) + "]")
+			i += n + 1
+		case ch == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	return b.String()
+}
+
+// ignored reports whether the given file or directory below the root
+// is ignored. As in .gitignore files, the last matching rule decides.
+func (f *ignoreFile) ignored(file string, dir bool) bool {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(f.root, abs)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	ignored := false
+	for _, rule := range f.rules {
+		if (!rule.dir || dir) && rule.pattern.MatchString(rel) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
@@ -0,0 +1,186 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// writeImports writes the import block for the generated code.
+// The set of packages depends on the options in the given configuration,
+// since unused imports would make the output fail to compile.
+func writeImports(w io.Writer, c *Config) error {
+	pkgs := make(map[string]bool)
+	add := func(names ...string) {
+		for _, name := range names {
+			pkgs[name] = true
+		}
+	}
+
+	// Table of contents, asset tree and restore procedure.
+	add("errors", "fmt", "os", "path", "path/filepath", "sort", "strings", "time")
+
+	// The functions of io/ioutil moved to os and io in Go 1.16.
+	ioutil := "io"
+	if !c.goAtLeast(16) {
+		ioutil = "io/ioutil"
+		add(ioutil)
+	}
+
+	if c.Debug {
+		add("regexp")
+	} else {
+
+		if c.sharedDictionary() {
+			add("bytes", "compress/flate", "fmt", "io")
+		} else {
+			add(compressionImports(c.compression())...)
+		}
+
+		if c.PoolReaders {
+			add("encoding/binary", "sync")
+		}
+
+		if c.lookup() != LookupMap {
+			add("sync")
+		}
+
+		if c.CacheDecompressed && c.compression() != CompressNone {
+			add("container/list", "sync")
+		}
+
+		if c.authenticate() {
+			add("crypto/hmac", "crypto/sha256")
+		}
+
+		if c.Checksums {
+			add("hash/crc32")
+		}
+
+		if c.encrypt() {
+			add("crypto/aes", "crypto/cipher", "encoding/hex", "sync")
+		}
+
+		if len(c.DataFile) > 0 {
+			add("encoding/binary", "io", "sync")
+		}
+
+		if c.NoUnsafe {
+			add("sync")
+		} else if c.NoMemCopy || c.extNoMemCopy() {
+			add("unsafe")
+
+			if !c.goAtLeast(20) {
+				add("reflect")
+			}
+		}
+	}
+
+	if len(c.DataFile) > 0 {
+		add("io")
+	}
+
+	if c.FS {
+		add("bytes", "io", "io/fs", "path", "sort", "strings")
+	}
+
+	if c.Handler {
+		add("bytes", "net/http", "path", "strings")
+
+		if c.Debug {
+			add("crypto/sha256", "encoding/hex")
+		}
+
+		if c.precompressed() {
+			add("strconv", "time")
+
+			if !c.ContentTypes {
+				add("mime")
+			}
+		}
+	}
+
+	if c.ContentTypes && c.Debug {
+		add("mime", "net/http", "path")
+	}
+
+	if c.Fingerprints && c.Debug {
+		add("crypto/sha256", "encoding/hex")
+	}
+
+	if c.Templates {
+		add("html/template", "path", textTemplateImport)
+	}
+
+	if c.Digests && c.Debug {
+		add("crypto/sha256")
+	}
+
+	if c.RootCAs {
+		add("crypto/x509")
+	}
+
+	if c.Extract {
+		add("sync")
+
+		if c.Debug {
+			add("crypto/sha256", "encoding/hex")
+		}
+	}
+
+	if len(c.TimeZones) > 0 {
+		add("archive/zip", "bytes", ioutil, "syscall")
+
+		if !pkgs["unsafe"] {
+			add(timeZoneImport)
+		}
+	}
+
+	add(c.snippetImports()...)
+
+	list := make([]string, 0, len(pkgs))
+	for name := range pkgs {
+		list = append(list, name)
+	}
+
+	return writeImportList(w, list)
+}
+
+// writeImportList writes an import block for the given packages.
+// Packages imported under another name are given as the name and
+// path, separated by a space. Nothing is written if the list is empty.
+func writeImportList(w io.Writer, list []string) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	sort.Strings(list)
+
+	_, err := fmt.Fprintf(w, "import (\n")
+	if err != nil {
+		return err
+	}
+
+	for _, name := range list {
+		if n := strings.IndexByte(name, ' '); n > 0 {
+			_, err = fmt.Fprintf(w, "\t%s %q\n", name[:n], name[n+1:])
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, err = fmt.Fprintf(w, "\t%q\n", name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, ")\n\n")
+	return err
+}
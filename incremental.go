@@ -0,0 +1,281 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// manifestVersion is increased whenever the generated code for an asset
+// changes, so caches written by older versions are not used.
+const manifestVersion = 3
+
+// manifest records the code generated for each asset
+// during a release build. See Config.IncrementalCache.
+type manifest struct {
+	Version int    `json:"version"`
+	Options string `json:"options"` // Options affecting the code of an asset.
+
+	// Size and modification time of the output, to detect
+	// whether it was changed since.
+	OutputSize    int64 `json:"output_size"`
+	OutputModTime int64 `json:"output_mtime"`
+
+	Assets map[string]manifestEntry `json:"assets"` // Mapped to the asset path.
+}
+
+// manifestEntry records the code generated for an asset.
+type manifestEntry struct {
+	Name       string `json:"name"`
+	Func       string `json:"func"`
+	Size       int64  `json:"size"`
+	ModTime    int64  `json:"mtime"`
+	Digest     string `json:"digest"` // SHA-256 sum of the asset contents.
+	Compressed bool   `json:"compressed"`
+	Embedded   int64  `json:"embedded_size"`
+	Parts      int    `json:"parts,omitempty"`
+
+	// Location and SHA-256 sum of the generated code in the output.
+	Offset int64  `json:"offset"`
+	Length int64  `json:"length"`
+	Chunk  string `json:"chunk"`
+}
+
+// incremental holds the state of an incremental run.
+type incremental struct {
+	c    *Config
+	prev *manifest // Manifest of the previous run, if usable.
+	old  *os.File  // Output of the previous run.
+	next *manifest // Manifest of this run.
+	out  *offsetWriter
+}
+
+// openIncremental prepares an incremental run. It returns nil if
+// the configuration does not allow one. The previous output is only
+// used if neither it nor the options changed since the last run.
+func openIncremental(c *Config) (*incremental, error) {
+	if len(c.IncrementalCache) == 0 || c.Debug || c.SingleBlob || c.SplitOutput {
+		return nil, nil
+	}
+
+	inc := &incremental{
+		c: c,
+		next: &manifest{
+			Version: manifestVersion,
+			Options: manifestOptions(c),
+			Assets:  make(map[string]manifestEntry),
+		},
+	}
+
+	data, err := ioutil.ReadFile(c.IncrementalCache)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return inc, nil
+		}
+		return nil, err
+	}
+
+	// A broken cache only means everything is encoded again.
+	var prev manifest
+	if json.Unmarshal(data, &prev) != nil ||
+		prev.Version != inc.next.Version || prev.Options != inc.next.Options {
+		return inc, nil
+	}
+
+	fi, err := os.Stat(c.Output)
+	if err != nil || fi.Size() != prev.OutputSize || fi.ModTime().UnixNano() != prev.OutputModTime {
+		return inc, nil
+	}
+
+	inc.old, err = os.Open(c.Output)
+	if err != nil {
+		return nil, err
+	}
+
+	inc.prev = &prev
+	return inc, nil
+}
+
+// manifestOptions describes the options affecting the code of an asset.
+func manifestOptions(c *Config) string {
+	return fmt.Sprintf("compression=%s level=%d nomemcopy=%v nounsafe=%v cache=%v parallel=%d/%d literal=%d force=%v modtime=%d key=%s hmac=%s checksums=%v minify=%v lines=%d ext=%s provenance=%v templates=%s",
+		c.compression(), c.CompressionLevel, c.NoMemCopy, c.NoUnsafe,
+		c.CacheDecompressed, c.ParallelSize, c.ParallelChunkSize, c.MaxLiteralSize, c.ForceCompress, c.ModTime,
+		keyFingerprint(c.encrypt(), c.EncryptKeyEnv), keyFingerprint(c.authenticate(), c.HMACKeyEnv),
+		c.Checksums, c.Minify, c.lineLength(), extensionsKey(c), c.Provenance, c.templatesSum)
+}
+
+// keyFingerprint identifies the key held by the named environment
+// variable, without revealing it, so assets are encoded again when
+// the key changes.
+func keyFingerprint(enabled bool, env string) string {
+	if !enabled {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(os.Getenv(env)))
+	return hex.EncodeToString(sum[:8])
+}
+
+// tempName returns the name of the file the output is written to.
+// The previous output is read while the new one is written, so
+// it is only replaced once the new output is complete.
+func (inc *incremental) tempName() string {
+	return inc.c.Output + ".tmp"
+}
+
+// wrap returns a writer for the output, which keeps
+// track of the offset of the written code.
+func (inc *incremental) wrap(w io.Writer) io.Writer {
+	inc.out = &offsetWriter{Writer: w}
+	return inc.out
+}
+
+// writeAssets writes the code for all assets, like writeRelease does.
+// The code of assets which did not change since the previous run is
+// copied from the previous output.
+func (inc *incremental) writeAssets(w io.Writer, c *Config, toc []Asset) error {
+	start := inc.out.n
+	entries := make([]manifestEntry, len(toc))
+
+	err := encodeAssets(w, len(toc), c.jobs(), func(w io.Writer, i int) error {
+		asset := &toc[i]
+		fi, err := asset.stat()
+		if err != nil {
+			return err
+		}
+
+		h := sha256.New()
+		cw := &offsetWriter{Writer: io.MultiWriter(w, h)}
+
+		if prev, ok := inc.lookup(asset, fi); ok {
+			err = inc.copyChunk(cw, asset, prev)
+		} else {
+			err = writeReleaseAsset(cw, c, asset)
+		}
+		if err != nil {
+			return err
+		}
+
+		entries[i] = manifestEntry{
+			Name:       asset.Name,
+			Func:       asset.Func,
+			Size:       fi.Size(),
+			ModTime:    fi.ModTime().UnixNano(),
+			Digest:     hex.EncodeToString(asset.Digest[:]),
+			Compressed: asset.Compressed,
+			Embedded:   asset.EmbeddedSize,
+			Parts:      asset.parts,
+			Length:     cw.n,
+			Chunk:      hex.EncodeToString(h.Sum(nil)),
+		}
+		return nil
+	}, c.assetEvents(toc))
+	if err != nil {
+		return err
+	}
+
+	offset := start
+	for i := range entries {
+		entries[i].Offset = offset
+		offset += entries[i].Length
+		inc.next.Assets[toc[i].origin()] = entries[i]
+	}
+
+	return nil
+}
+
+// lookup returns the entry of the previous run for the given asset,
+// if the asset did not change since.
+func (inc *incremental) lookup(asset *Asset, fi os.FileInfo) (manifestEntry, bool) {
+	if inc.prev == nil {
+		return manifestEntry{}, false
+	}
+
+	e, ok := inc.prev.Assets[asset.origin()]
+	ok = ok && e.Name == asset.Name && e.Func == asset.Func &&
+		e.Size == fi.Size() && e.ModTime == fi.ModTime().UnixNano()
+	return e, ok
+}
+
+// copyChunk copies the code of the given asset from the previous output.
+func (inc *incremental) copyChunk(w io.Writer, asset *Asset, e manifestEntry) error {
+	digest, err := hex.DecodeString(e.Digest)
+	if err != nil || len(digest) != len(asset.Digest) {
+		return fmt.Errorf("Invalid digest for %s in %s", asset.Name, inc.c.IncrementalCache)
+	}
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(w, h), io.NewSectionReader(inc.old, e.Offset, e.Length))
+	if err != nil {
+		return err
+	}
+
+	if hex.EncodeToString(h.Sum(nil)) != e.Chunk {
+		return fmt.Errorf("Code for %s in %s does not match %s; remove it to regenerate everything",
+			asset.Name, inc.c.Output, inc.c.IncrementalCache)
+	}
+
+	copy(asset.Digest[:], digest)
+	asset.Compressed = e.Compressed
+	asset.Size = e.Size
+	asset.EmbeddedSize = e.Embedded
+	asset.parts = e.Parts
+	return nil
+}
+
+// finish replaces the previous output with the new one and writes the
+// manifest, if the run succeeded. Otherwise, the new output is removed.
+func (inc *incremental) finish(err error) error {
+	if inc.old != nil {
+		inc.old.Close()
+	}
+
+	if err != nil {
+		os.Remove(inc.tempName())
+		return err
+	}
+
+	err = os.Rename(inc.tempName(), inc.c.Output)
+	if err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(inc.c.Output)
+	if err != nil {
+		return err
+	}
+
+	inc.next.OutputSize = fi.Size()
+	inc.next.OutputModTime = fi.ModTime().UnixNano()
+
+	data, err := json.MarshalIndent(inc.next, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return writeFile(inc.c, inc.c.IncrementalCache, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// offsetWriter counts the bytes written through it.
+type offsetWriter struct {
+	io.Writer
+	n int64
+}
+
+func (w *offsetWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,504 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EmbeddedAsset describes an asset found in generated code.
+type EmbeddedAsset struct {
+	Name       string
+	Size       int64
+	Mode       os.FileMode
+	ModTime    time.Time
+	Compressed bool // Whether the asset is embedded compressed.
+
+	data     func() ([]byte, error)
+	embedded int64 // The size of the embedded data.
+}
+
+// Bytes returns the contents of the asset. For debug output,
+// these are read from the original file.
+func (a *EmbeddedAsset) Bytes() ([]byte, error) {
+	return a.data()
+}
+
+// ReadEmbedded parses code generated by Translate and returns the assets
+// embedded in it, sorted by name. The path names either the output file,
+// or the output directory if it was written with SplitOutput.
+//
+// Assets compressed with a codec outside the standard library can only
+// be read if support for it was compiled in, as with Translate.
+func ReadEmbedded(path string) ([]EmbeddedAsset, error) {
+	g, err := parseGenerated(path)
+	if err != nil {
+		return nil, err
+	}
+
+	toc, ok := g.toc()
+	if !ok {
+		return nil, fmt.Errorf("No table of contents found in %s", path)
+	}
+
+	var list []EmbeddedAsset
+	for _, kv := range toc {
+		name, err := stringValue(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		asset, err := g.asset(name, kv.Value)
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s: %v", name, err)
+		}
+
+		list = append(list, asset)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// generated holds the declarations of generated code.
+type generated struct {
+	vars        map[string]ast.Expr
+	funcs       map[string]*ast.FuncDecl
+	compression Compression
+}
+
+// parseGenerated parses the given file, or all files
+// in the given directory written in split mode.
+func parseGenerated(path string) (*generated, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if fi.IsDir() {
+		files = []string{filepath.Join(path, splitTOCFile)}
+		list, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fi := range list {
+			if strings.HasPrefix(fi.Name(), "bindata_") && strings.HasSuffix(fi.Name(), "_asset.go") {
+				files = append(files, filepath.Join(path, fi.Name()))
+			}
+		}
+	}
+
+	g := &generated{
+		vars:        make(map[string]ast.Expr),
+		funcs:       make(map[string]*ast.FuncDecl),
+		compression: CompressNone,
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		g.add(f)
+	}
+
+	return g, nil
+}
+
+// toc returns the entries of the table of contents, mapping the
+// names of the assets to their generators. Without the map lookup,
+// they are paired from the slices of names and generators.
+func (g *generated) toc() ([]*ast.KeyValueExpr, bool) {
+	if toc, ok := g.vars["_bindata"].(*ast.CompositeLit); ok {
+		var list []*ast.KeyValueExpr
+		for _, elt := range toc.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok {
+				list = append(list, kv)
+			}
+		}
+		return list, true
+	}
+
+	names, ok := g.vars["_bindata_names"].(*ast.CompositeLit)
+	if !ok {
+		return nil, false
+	}
+	funcs, ok := g.vars["_bindata_funcs"].(*ast.CompositeLit)
+	if !ok || len(funcs.Elts) != len(names.Elts) {
+		return nil, false
+	}
+
+	list := make([]*ast.KeyValueExpr, len(names.Elts))
+	for i := range names.Elts {
+		list[i] = &ast.KeyValueExpr{Key: names.Elts[i], Value: funcs.Elts[i]}
+	}
+	return list, true
+}
+
+// add records the declarations of the given file.
+func (g *generated) add(f *ast.File) {
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		for _, v := range []Compression{CompressGzip, CompressZstd, CompressBrotli} {
+			for _, pkg := range compressionImports(v) {
+				if pkg == path && strings.Contains(pkg, "/") {
+					g.compression = v
+				}
+			}
+		}
+	}
+
+	for _, decl := range f.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				g.funcs[decl.Name.Name] = decl
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				vs, ok := spec.(*ast.ValueSpec)
+				if !ok || len(vs.Values) != len(vs.Names) {
+					continue
+				}
+				for i, name := range vs.Names {
+					g.vars[name.Name] = vs.Values[i]
+				}
+			}
+		}
+	}
+}
+
+// asset returns the asset generated by the given expression from
+// the table of contents.
+func (g *generated) asset(name string, fn ast.Expr) (EmbeddedAsset, error) {
+	asset := EmbeddedAsset{Name: name}
+
+	// Single blob layout: _bindata_entries[i].load
+	if sel, ok := fn.(*ast.SelectorExpr); ok {
+		if index, ok := sel.X.(*ast.IndexExpr); ok {
+			i, err := intValue(index.Index)
+			if err != nil {
+				return asset, err
+			}
+			return g.blobAsset(asset, int(i))
+		}
+	}
+
+	id, ok := fn.(*ast.Ident)
+	if !ok {
+		return asset, fmt.Errorf("Unexpected expression in table of contents")
+	}
+
+	decl, ok := g.funcs[id.Name]
+	if !ok {
+		return asset, fmt.Errorf("Function %s not found", id.Name)
+	}
+
+	// Debug output reads the file from disk.
+	if call := findCall(decl, "bindata_load"); call != nil && len(call.Args) == 2 {
+		path, err := stringValue(call.Args[0])
+		if err != nil {
+			return asset, err
+		}
+
+		fi, err := os.Stat(path)
+		if err != nil {
+			return asset, err
+		}
+
+		asset.Size, asset.Mode, asset.ModTime = fi.Size(), fi.Mode(), fi.ModTime()
+		asset.data = func() ([]byte, error) { return ioutil.ReadFile(path) }
+		return asset, nil
+	}
+
+	info := findComposite(decl, "bindata_file_info")
+	if info == nil {
+		return asset, fmt.Errorf("File info not found")
+	}
+
+	err := fileInfoValue(&asset, info)
+	if err != nil {
+		return asset, err
+	}
+
+	source := g.dataSource(id.Name)
+	bytesDecl, ok := g.funcs[source+"_bytes"]
+	if !ok {
+		return asset, fmt.Errorf("Function %s_bytes not found", source)
+	}
+	asset.Compressed = findCall(bytesDecl, "bindata_read") != nil || findCall(bytesDecl, "bindata_read_string") != nil
+
+	data, err := stringValue(g.vars["_"+source])
+	if err != nil {
+		return asset, err
+	}
+
+	// Data split to limit the size of literals continues in
+	// the variables of the further parts.
+	for i := 1; ; i++ {
+		part, ok := g.vars[partVar(source, i)]
+		if !ok {
+			break
+		}
+
+		more, err := stringValue(part)
+		if err != nil {
+			return asset, err
+		}
+		data += more
+	}
+
+	return g.withData(asset, data), nil
+}
+
+// dataSource returns the name of the asset function whose variable holds
+// the data of the given asset. Duplicates have no variable of their own,
+// their _bytes function calls the one of the asset with the same contents.
+func (g *generated) dataSource(name string) string {
+	if _, ok := g.vars["_"+name]; ok {
+		return name
+	}
+
+	decl, ok := g.funcs[name+"_bytes"]
+	if !ok || len(decl.Body.List) != 1 {
+		return name
+	}
+
+	ret, ok := decl.Body.List[0].(*ast.ReturnStmt)
+	if !ok || len(ret.Results) != 1 {
+		return name
+	}
+
+	call, ok := ret.Results[0].(*ast.CallExpr)
+	if !ok {
+		return name
+	}
+
+	fn, ok := call.Fun.(*ast.Ident)
+	if !ok || !strings.HasSuffix(fn.Name, "_bytes") {
+		return name
+	}
+
+	return strings.TrimSuffix(fn.Name, "_bytes")
+}
+
+// blobAsset returns the asset held by the given entry of a single blob.
+func (g *generated) blobAsset(asset EmbeddedAsset, i int) (EmbeddedAsset, error) {
+	entries, ok := g.vars["_bindata_entries"].(*ast.CompositeLit)
+	if !ok || i < 0 || i >= len(entries.Elts) {
+		return asset, fmt.Errorf("Entry %d not found", i)
+	}
+
+	entry, ok := entries.Elts[i].(*ast.CompositeLit)
+	if !ok {
+		return asset, fmt.Errorf("Invalid entry %d", i)
+	}
+
+	var offset, length int64
+	for _, elt := range entry.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		var err error
+		switch fmt.Sprint(kv.Key) {
+		case "offset":
+			offset, err = intValue(kv.Value)
+		case "length":
+			length, err = intValue(kv.Value)
+		case "compressed":
+			asset.Compressed = fmt.Sprint(kv.Value) == "true"
+		case "info":
+			info, ok := kv.Value.(*ast.CompositeLit)
+			if !ok {
+				return asset, fmt.Errorf("Invalid file info")
+			}
+			err = fileInfoValue(&asset, info)
+		}
+		if err != nil {
+			return asset, err
+		}
+	}
+
+	if _, ok := g.vars["_bindata_data_id"]; ok {
+		return asset, fmt.Errorf("Data of entry %d is kept in a data file", i)
+	}
+
+	blob, err := stringValue(g.vars["_bindata_blob"])
+	if err != nil {
+		return asset, err
+	}
+
+	if offset < 0 || length < 0 || offset+length > int64(len(blob)) {
+		return asset, fmt.Errorf("Entry %d is out of range", i)
+	}
+
+	return g.withData(asset, blob[offset:offset+length]), nil
+}
+
+// withData sets the function returning the contents of the asset,
+// which are embedded as the given data. Encrypted data is decrypted
+// with the key held by the environment variable named in the code.
+// Data compressed with a shared dictionary is inflated with the
+// dictionary embedded in the code.
+func (g *generated) withData(asset EmbeddedAsset, data string) EmbeddedAsset {
+	env, _ := stringValue(g.vars["_bindata_key_env"])
+	dict, shared := g.vars["_bindata_dict"]
+	compressed, v := asset.Compressed, g.compression
+
+	asset.embedded = int64(len(data))
+	asset.data = func() ([]byte, error) {
+		b := []byte(data)
+		if len(env) > 0 {
+			var err error
+			b, err = decryptData(b, env)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !compressed {
+			return b, nil
+		}
+
+		if shared {
+			d, err := stringValue(dict)
+			if err != nil {
+				return nil, err
+			}
+			return inflateDict(b, []byte(d))
+		}
+
+		fn, ok := decoders[v]
+		if !ok {
+			return nil, fmt.Errorf("Compression %s is not supported by this build; rebuild with -tags %s", v, buildTags[v])
+		}
+		return fn(b)
+	}
+	return asset
+}
+
+// findCall returns the first call of the named function in decl.
+func findCall(decl *ast.FuncDecl, name string) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok && found == nil {
+			if id, ok := call.Fun.(*ast.Ident); ok && id.Name == name {
+				found = call
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// findComposite returns the first composite literal of the named type in decl.
+func findComposite(decl *ast.FuncDecl, name string) *ast.CompositeLit {
+	var found *ast.CompositeLit
+	ast.Inspect(decl, func(n ast.Node) bool {
+		if lit, ok := n.(*ast.CompositeLit); ok && found == nil {
+			if id, ok := lit.Type.(*ast.Ident); ok && id.Name == name {
+				found = lit
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// fileInfoValue reads a bindata_file_info literal into the asset.
+func fileInfoValue(asset *EmbeddedAsset, info *ast.CompositeLit) error {
+	for _, elt := range info.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+
+		// Conversions like os.FileMode(420) and time.Unix(1, 0)
+		// hold the value in their first argument.
+		value := kv.Value
+		if call, ok := value.(*ast.CallExpr); ok && len(call.Args) > 0 {
+			value = call.Args[0]
+		}
+
+		var err error
+		var n int64
+		switch fmt.Sprint(kv.Key) {
+		case "size":
+			asset.Size, err = intValue(value)
+		case "mode":
+			n, err = intValue(value)
+			asset.Mode = os.FileMode(n)
+		case "modTime":
+			n, err = intValue(value)
+			asset.ModTime = time.Unix(n, 0)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stringValue evaluates a constant string expression, as used for
+// the asset data. Conversions to []byte and bindata_string
+// literals are looked through.
+func stringValue(expr ast.Expr) (string, error) {
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		if expr.Kind == token.STRING {
+			return strconv.Unquote(expr.Value)
+		}
+	case *ast.BinaryExpr:
+		if expr.Op == token.ADD {
+			x, err := stringValue(expr.X)
+			if err != nil {
+				return "", err
+			}
+			y, err := stringValue(expr.Y)
+			return x + y, err
+		}
+	case *ast.CallExpr:
+		if len(expr.Args) == 1 {
+			return stringValue(expr.Args[0])
+		}
+	case *ast.ParenExpr:
+		return stringValue(expr.X)
+	case *ast.UnaryExpr:
+		if expr.Op == token.AND {
+			return stringValue(expr.X)
+		}
+	case *ast.CompositeLit:
+		for _, elt := range expr.Elts {
+			if kv, ok := elt.(*ast.KeyValueExpr); ok && fmt.Sprint(kv.Key) == "data" {
+				return stringValue(kv.Value)
+			}
+		}
+	}
+	return "", fmt.Errorf("Unexpected expression for asset data")
+}
+
+// intValue evaluates an integer literal.
+func intValue(expr ast.Expr) (int64, error) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.INT {
+		return 0, fmt.Errorf("Unexpected expression for integer")
+	}
+	return strconv.ParseInt(lit.Value, 0, 64)
+}
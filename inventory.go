@@ -0,0 +1,63 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// writeInventory writes AssetsCount, AssetsSize and AssetsCompressedSize.
+// Release builds return the totals of the generation, debug builds load
+// the assets found on disk.
+func writeInventory(w io.Writer, c *Config, toc []Asset) error {
+	if c.Debug {
+		_, err := fmt.Fprintf(w, `// AssetsCount returns the number of assets.
+func AssetsCount() int {
+	return len(bindata_toc())
+}
+
+// AssetsSize returns the total size of the contents of the assets.
+// Debug builds read all assets from disk to find it.
+func AssetsSize() int64 {
+	var size int64
+	for _, f := range bindata_toc() {
+		if a, err := f(); err == nil {
+			size += a.info.Size()
+		}
+	}
+	return size
+}
+
+// AssetsCompressedSize returns the size of the data embedded for the
+// assets. Debug builds do not embed any.
+func AssetsCompressedSize() int64 {
+	return 0
+}
+
+`)
+		return err
+	}
+
+	stats := newStats(toc)
+	_, err := fmt.Fprintf(w, `// AssetsCount returns the number of assets.
+func AssetsCount() int {
+	return %d
+}
+
+// AssetsSize returns the total size of the contents of the assets.
+func AssetsSize() int64 {
+	return %d
+}
+
+// AssetsCompressedSize returns the size of the data embedded for the
+// assets, as compressed, counting data shared by duplicates once.
+func AssetsCompressedSize() int64 {
+	return %d
+}
+
+`, stats.Assets, stats.Size, stats.Embedded)
+	return err
+}
@@ -0,0 +1,57 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+)
+
+// checkSizes compares the sizes of the assets against the configured
+// limits. Duplicates must have been marked already. Exceeded limits are
+// returned as warnings if SizeLimitWarn is set, and as an error otherwise.
+func checkSizes(c *Config, toc []Asset) ([]string, error) {
+	if c.MaxAssetSize <= 0 && c.MaxTotalSize <= 0 {
+		return nil, nil
+	}
+
+	var warnings []string
+	exceeded := func(format string, args ...interface{}) error {
+		msg := fmt.Sprintf(format, args...)
+		if !c.SizeLimitWarn {
+			return fmt.Errorf("%s", msg)
+		}
+		warnings = append(warnings, msg)
+		return nil
+	}
+
+	var total int64
+	for i := range toc {
+		asset := &toc[i]
+		fi, err := asset.stat()
+		if err != nil {
+			return nil, err
+		}
+
+		if c.MaxAssetSize > 0 && fi.Size() > c.MaxAssetSize {
+			err = exceeded("Asset %s is %d bytes, exceeding the limit of %d bytes", asset.origin(), fi.Size(), c.MaxAssetSize)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if asset.original == nil {
+			total += fi.Size()
+		}
+	}
+
+	if c.MaxTotalSize > 0 && total > c.MaxTotalSize {
+		err := exceeded("Assets are %d bytes in total, exceeding the limit of %d bytes", total, c.MaxTotalSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,118 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// literalParts splits the data of an asset into several variables, once
+// a literal holds the configured number of bytes. The first variable
+// keeps the usual name, like _index_html, the others get a suffix, like
+// _index_html_part1. Each of them is declared the same way, with the given
+// text opening and closing the literal.
+type literalParts struct {
+	w           io.Writer
+	asset       *Asset
+	size        int64
+	n           int64
+	open, close string
+}
+
+// newLiteralParts returns the splitter for the data of the given asset,
+// or nil if literals are not limited. The literal of the first variable
+// must be opened already.
+func newLiteralParts(w io.Writer, c *Config, asset *Asset, open, close string) *literalParts {
+	asset.parts = 1
+	if c.MaxLiteralSize <= 0 {
+		return nil
+	}
+
+	return &literalParts{w: w, asset: asset, size: c.MaxLiteralSize, open: open, close: close}
+}
+
+// full reports whether the current literal holds the maximum
+// number of bytes, so the next one must be started.
+func (p *literalParts) full() bool {
+	return p != nil && p.n >= p.size
+}
+
+// next closes the current literal, and declares the variable
+// holding the next part.
+func (p *literalParts) next() error {
+	_, err := fmt.Fprintf(p.w, "%s\n\nvar %s = %s", p.close, partVar(p.asset.Func, p.asset.parts), p.open)
+	p.asset.parts++
+	p.n = 0
+	return err
+}
+
+// add counts n bytes written to the current literal.
+func (p *literalParts) add(n int) {
+	if p != nil {
+		p.n += int64(n)
+	}
+}
+
+// partVar returns the name of the variable holding the
+// given part of the data of the asset function.
+func partVar(fn string, part int) string {
+	return fmt.Sprintf("_%s_part%d", fn, part)
+}
+
+// dataVars returns the names of the variables holding the data of the asset.
+func dataVars(asset *Asset) []string {
+	names := []string{"_" + asset.Func}
+	for i := 1; i < asset.parts; i++ {
+		names = append(names, partVar(asset.Func, i))
+	}
+	return names
+}
+
+// dataExpr returns an expression for the data of the asset, as it is
+// declared by the writers of the release code. Data split into several
+// variables is joined when it is read. Duplicates refer to the data of
+// their original.
+func dataExpr(c *Config, asset *Asset) string {
+	if asset.original != nil {
+		asset = asset.original
+	}
+
+	names := dataVars(asset)
+	if len(names) == 1 {
+		return names[0]
+	}
+
+	switch {
+	case !c.assetStringData(asset):
+		return "bindata_join(" + strings.Join(names, ", ") + ")"
+	case c.NoUnsafe:
+		return "&bindata_string{data: " + strings.Join(names, ".data + ") + ".data}"
+	}
+	return strings.Join(names, " + ")
+}
+
+// header_join writes bindata_join, which joins the data
+// of an asset split into several byte slices.
+func header_join(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `// bindata_join returns the data of an asset, which is split
+// into several variables to keep each literal small.
+func bindata_join(parts ...[]byte) []byte {
+	size := 0
+	for _, part := range parts {
+		size += len(part)
+	}
+
+	data := make([]byte, 0, size)
+	for _, part := range parts {
+		data = append(data, part...)
+	}
+	return data
+}
+
+`)
+	return err
+}
@@ -0,0 +1,179 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// localeKey normalizes a language tag for comparison. Tags are
+// compared without regard to case, and underscores separate
+// subtags like hyphens do.
+func localeKey(tag string) string {
+	return strings.ToLower(strings.Replace(tag, "_", "-", -1))
+}
+
+// localeChain returns the normalized language tags to look up for
+// the given locale, from the most specific one to the least. This
+// is the same as bindata_locale_chain in the generated code.
+func localeChain(locale string) []string {
+	var chain []string
+	tag := localeKey(locale)
+	for tag != "" {
+		chain = append(chain, tag)
+
+		n := strings.LastIndexByte(tag, '-')
+		if n < 0 {
+			break
+		}
+		tag = tag[:n]
+
+		// Extensions and private use subtags are introduced
+		// by a single letter, which is dropped along with them.
+		if n := strings.LastIndexByte(tag, '-'); n >= 0 && n == len(tag)-2 {
+			tag = tag[:n]
+		}
+	}
+	return chain
+}
+
+// findLocales returns the locale directories found below the configured
+// directory, mapped to their normalized language tags.
+func findLocales(c *Config, toc []Asset) (map[string]string, error) {
+	root := path.Clean(c.Locales) + "/"
+	locales := make(map[string]string)
+	for i := range toc {
+		name := toc[i].Name
+		if !strings.HasPrefix(name, root) {
+			continue
+		}
+
+		n := strings.IndexByte(name[len(root):], '/')
+		if n <= 0 {
+			continue
+		}
+
+		dir := name[len(root) : len(root)+n]
+		key := localeKey(dir)
+		if other, ok := locales[key]; ok && other != dir {
+			return nil, fmt.Errorf("Locale directories %s and %s are the same locale", other, dir)
+		}
+		locales[key] = dir
+	}
+	return locales, nil
+}
+
+// writeLocales writes the AssetLocalized function, along with
+// the table of the locales found during generation.
+func writeLocales(w io.Writer, c *Config, toc []Asset) error {
+	locales, err := findLocales(c, toc)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `// AssetLocalized returns the named asset for the given locale, a BCP 47
+// language tag like "de-AT". The asset is looked up in the directory of
+// the locale below %q, then in those of the less specific tags, like "de",
+// and finally in the directory of the default locale%s. Tags are compared
+// without regard to case, and may use underscores in place of hyphens.
+func AssetLocalized(locale, name string) ([]byte, error) {
+	for _, tag := range bindata_locale_chain(locale) {
+		dir, ok := _bindata_locales[tag]
+		if !ok {
+			continue
+		}
+
+		data, err := Asset(%s)
+		if err == nil || !errors.Is(err, ErrAssetNotFound) {
+			return data, err
+		}
+	}
+	return nil, fmt.Errorf("%%w: %%s for locale %%s", ErrAssetNotFound, name, locale)
+}
+
+// AssetLocales returns the language tags of the locale directories.
+func AssetLocales() []string {
+	tags := make([]string, 0, len(_bindata_locales))
+	for _, dir := range _bindata_locales {
+		tags = append(tags, dir)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// bindata_locale_chain returns the normalized language tags to look up
+// for the given locale, from the most specific one to the default.
+func bindata_locale_chain(locale string) []string {
+	var chain []string
+	tag := strings.ToLower(strings.Replace(locale, "_", "-", -1))
+	for tag != "" {
+		chain = append(chain, tag)
+
+		n := strings.LastIndexByte(tag, '-')
+		if n < 0 {
+			break
+		}
+		tag = tag[:n]
+
+		// Extensions and private use subtags are introduced
+		// by a single letter, which is dropped along with them.
+		if n := strings.LastIndexByte(tag, '-'); n >= 0 && n == len(tag)-2 {
+			tag = tag[:n]
+		}
+	}
+	return %s
+}
+
+// _bindata_locales holds the locale directories, mapped to their
+// normalized language tags.
+var _bindata_locales = map[string]string{
+`, path.Clean(c.Locales), defaultLocaleDoc(c), c.nameArg(fmt.Sprintf("path.Join(%q, dir, name)", path.Clean(c.Locales))), defaultChain(c))
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(locales))
+	for key := range locales {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		_, err = fmt.Fprintf(w, "\t%q: %q,\n", key, locales[key])
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
+
+// defaultChain returns the expression for the chain of language tags in
+// bindata_locale_chain, followed by those of the default locale.
+func defaultChain(c *Config) string {
+	tags := localeChain(c.DefaultLocale)
+	if len(tags) == 0 {
+		return "chain"
+	}
+	for i, tag := range tags {
+		tags[i] = strconv.Quote(tag)
+	}
+	return "append(chain, " + strings.Join(tags, ", ") + ")"
+}
+
+// defaultLocaleDoc names the default locale in the
+// documentation of AssetLocalized.
+func defaultLocaleDoc(c *Config) string {
+	if len(c.DefaultLocale) == 0 {
+		return ", if one is configured"
+	}
+	return fmt.Sprintf(", %q", c.DefaultLocale)
+}
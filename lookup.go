@@ -0,0 +1,177 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// validateLookup ensures only one behaviour is
+// selected for lookups of missing assets.
+func validateLookup(c *Config) error {
+	if len(c.FallbackAsset) > 0 && c.PanicOnMissing {
+		return fmt.Errorf("Fallback asset cannot be combined with PanicOnMissing")
+	}
+
+	// Obfuscated names are hashes, which cannot be folded.
+	if c.IgnoreCase && c.obfuscate() {
+		return fmt.Errorf("Case insensitive lookups cannot be combined with name obfuscation")
+	}
+
+	return nil
+}
+
+// fallbackName returns the name of the fallback asset, as it is
+// used in the generated code. With obfuscated names, the asset is
+// found by its original name.
+func fallbackName(c *Config, toc []Asset) (string, error) {
+	name := strings.TrimPrefix(c.FallbackAsset, "/")
+	for i := range toc {
+		if toc[i].Name == name || len(toc[i].label) > 0 && toc[i].label == name {
+			return toc[i].Name, nil
+		}
+	}
+
+	return "", fmt.Errorf("Fallback asset %s is not an asset", name)
+}
+
+// foldedNames returns the names of the assets by their lower case
+// form, for those which are not lower case already. It is an error
+// for two assets to differ by case only.
+func foldedNames(toc []Asset) (map[string]string, error) {
+	seen := make(map[string]string, len(toc))
+	folded := make(map[string]string)
+	for i := range toc {
+		name := toc[i].Name
+		lower := strings.ToLower(name)
+		if other, ok := seen[lower]; ok && other != name {
+			return nil, fmt.Errorf("Assets %s and %s differ only by case", other, name)
+		}
+
+		seen[lower] = name
+		if lower != name {
+			folded[lower] = name
+		}
+	}
+
+	return folded, nil
+}
+
+// writeFolded writes _bindata_folded, which maps the lower case form
+// of asset names to the names, where they differ.
+func writeFolded(w io.Writer, toc []Asset) error {
+	folded, err := foldedNames(toc)
+	if err != nil {
+		return err
+	}
+
+	lower := make([]string, 0, len(folded))
+	for name := range folded {
+		lower = append(lower, name)
+	}
+	sort.Strings(lower)
+
+	_, err = fmt.Fprintf(w, "// _bindata_folded maps lower case asset names to the names of the assets.\nvar _bindata_folded = map[string]string{\n")
+	if err != nil {
+		return err
+	}
+
+	for _, name := range lower {
+		_, err = fmt.Fprintf(w, "\t%q: %q,\n", name, folded[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
+
+// writeFind writes bindata_find, which the lookup functions use to
+// locate an asset. By default, it reports a missing asset, which
+// results in ErrAssetNotFound. With IgnoreCase, names differing by
+// case are tried first. With FallbackAsset, the fallback is returned
+// instead, and with PanicOnMissing, it panics.
+func writeFind(w io.Writer, c *Config, toc []Asset) error {
+	if c.IgnoreCase {
+		err := writeFolded(w, toc)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, `// bindata_clean converts the given name to the form of the asset names,
+// with forward slashes, and without a leading one or elements like "..",
+// so names like "\\css\\app.css" or "./css/app.css" find css/app.css.
+func bindata_clean(name string) string {
+	return path.Clean("/" + strings.Replace(name, "\\", "/", -1))[1:]
+}
+
+// bindata_find returns the generator for the asset with the given name,
+// after converting path separators.
+func bindata_find(name string) (func() (*asset, error), bool) {
+	cannonicalName := bindata_clean(name)
+	if f, ok := bindata_lookup(cannonicalName); ok {
+		return f, true
+	}
+`)
+	if err != nil {
+		return err
+	}
+
+	if c.IgnoreCase {
+		err = writeFindFolded(w, c)
+		if err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case len(c.FallbackAsset) > 0:
+		fallback, err := fallbackName(c, toc)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "\n\t// Missing assets fall back to this one.\n\treturn bindata_lookup(%q)\n}\n\n", fallback)
+		return err
+	case c.PanicOnMissing:
+		_, err = fmt.Fprintf(w, "\n\t// All assets are expected to exist.\n\tpanic(fmt.Errorf(\"%%w: %%s\", ErrAssetNotFound, name))\n}\n\n")
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "\treturn nil, false\n}\n\n")
+	return err
+}
+
+// writeFindFolded writes the part of bindata_find matching names
+// regardless of case. Debug builds match assets added since the
+// generation as well.
+func writeFindFolded(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `
+	// Names are matched regardless of case.
+	folded := strings.ToLower(cannonicalName)
+	if n, ok := _bindata_folded[folded]; ok {
+		folded = n
+	}
+	if f, ok := bindata_lookup(folded); ok {
+		return f, true
+	}
+`)
+	if err != nil || !c.Debug {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `	for n, f := range bindata_toc() {
+		if strings.EqualFold(n, cannonicalName) {
+			return f, true
+		}
+	}
+`)
+	return err
+}
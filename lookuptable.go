@@ -0,0 +1,162 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Lookup selects how the generated code finds assets by name.
+type Lookup int
+
+// Known lookup methods.
+const (
+	LookupMap    Lookup = iota // Keep the assets in a map. This is the default.
+	LookupSorted               // Search the sorted names of the assets.
+	LookupSwitch               // Switch over the names of the assets.
+)
+
+func (v Lookup) String() string {
+	switch v {
+	case LookupMap:
+		return "map"
+	case LookupSorted:
+		return "sorted"
+	case LookupSwitch:
+		return "switch"
+	}
+	return fmt.Sprintf("Lookup(%d)", int(v))
+}
+
+// ParseLookup returns the lookup method with the given name,
+// as returned by its String method.
+func ParseLookup(name string) (Lookup, error) {
+	for _, v := range []Lookup{LookupMap, LookupSorted, LookupSwitch} {
+		if v.String() == name {
+			return v, nil
+		}
+	}
+	return LookupMap, fmt.Errorf("Unknown lookup method %q", name)
+}
+
+// validateLookupMethod ensures the lookup method is known, and
+// is not combined with options expecting the map.
+func validateLookupMethod(c *Config) error {
+	switch c.Lookup {
+	case LookupMap:
+		return nil
+	case LookupSorted, LookupSwitch:
+	default:
+		return fmt.Errorf("Unknown lookup method %s", c.Lookup)
+	}
+
+	if c.Compat {
+		return fmt.Errorf("Compatibility mode requires the map lookup")
+	}
+
+	return nil
+}
+
+// lookup returns the lookup method of the generated code.
+// Debug builds always use a map, as assets may be added.
+func (c *Config) lookup() Lookup {
+	if c.Debug {
+		return LookupMap
+	}
+	return c.Lookup
+}
+
+// writeTOCTable writes the table of contents. With the map lookup, it is
+// a map literal. Otherwise, it consists of the sorted names and, in the
+// same order, the generators of the assets, which need no initialization
+// at runtime. The switch lookup additionally gets its bindata_lookup.
+func writeTOCTable(w io.Writer, c *Config, toc []Asset) error {
+	if c.lookup() == LookupMap {
+		_, err := fmt.Fprintf(w, `// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+`)
+		if err != nil {
+			return err
+		}
+
+		for i := range toc {
+			err = writeTOCAsset(w, &toc[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return writeTOCFooter(w)
+	}
+
+	// Obfuscated names are no longer in order.
+	sorted := make([]*Asset, len(toc))
+	for i := range toc {
+		sorted[i] = &toc[i]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	_, err := fmt.Fprintf(w, `// _bindata_names holds the names of the assets, sorted.
+var _bindata_names = []string{
+`)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range sorted {
+		_, err = fmt.Fprintf(w, "\t%q,\n", asset.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, `}
+
+// _bindata_funcs holds the generators of the assets,
+// in the order of their names.
+var _bindata_funcs = []func() (*asset, error){
+`)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range sorted {
+		_, err = fmt.Fprintf(w, "\t%s,\n", asset.Func)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeTOCFooter(w)
+	if err != nil || c.lookup() != LookupSwitch {
+		return err
+	}
+
+	return writeLookupSwitch(w, sorted)
+}
+
+// writeLookupSwitch writes bindata_lookup for the switch lookup. The
+// compiler turns the switch into a binary search over the names.
+func writeLookupSwitch(w io.Writer, sorted []*Asset) error {
+	_, err := fmt.Fprintf(w, `// bindata_lookup returns the generator for the asset with the given name.
+func bindata_lookup(name string) (func() (*asset, error), bool) {
+	switch name {
+`)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range sorted {
+		_, err = fmt.Fprintf(w, "\tcase %q:\n\t\treturn %s, true\n", asset.Name, asset.Func)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\t}\n\treturn nil, false\n}\n\n")
+	return err
+}
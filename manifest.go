@@ -0,0 +1,89 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+)
+
+// ManifestEntry describes a single asset in the manifest
+// written to Config.ManifestPath.
+type ManifestEntry struct {
+	Name    string `json:"name"`   // Name by which the asset is referenced.
+	Path    string `json:"path"`   // Full path of the source file.
+	Size    int64  `json:"size"`   // Size of the asset contents.
+	Mode    uint32 `json:"mode"`   // File mode bits.
+	ModTime int64  `json:"mtime"`  // Modification time as Unix seconds.
+	SHA256  string `json:"sha256"` // Hex encoded SHA-256 sum of the contents.
+
+	// CompressedSize is the size of the data embedded for the asset.
+	// It is omitted for uncompressed assets and debug builds.
+	CompressedSize int64 `json:"compressed_size,omitempty"`
+}
+
+// writeManifest writes a JSON manifest of the given assets to
+// the configured manifest path. It must be called after the
+// assets were written, so their digests and sizes are known.
+func writeManifest(c *Config, toc []Asset) error {
+	entries := make([]ManifestEntry, len(toc))
+	for i := range toc {
+		err := manifestAsset(c, &toc[i], &entries[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeFile(c, c.ManifestPath, func(w io.Writer) error {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		return enc.Encode(entries)
+	})
+}
+
+// manifestAsset fills in the manifest entry for the given asset.
+// Debug builds do not read the assets, so they are hashed here.
+func manifestAsset(c *Config, asset *Asset, e *ManifestEntry) error {
+	fi, err := asset.stat()
+	if err != nil {
+		return err
+	}
+
+	e.Name = asset.Name
+	e.Path = asset.origin()
+	e.Size = asset.Size
+	e.Mode = uint32(fi.Mode())
+	e.ModTime = fi.ModTime().Unix()
+	if c.ModTime != 0 {
+		e.ModTime = c.ModTime
+	}
+
+	if asset.Compressed {
+		e.CompressedSize = asset.EmbeddedSize
+	}
+
+	if !c.Debug {
+		e.SHA256 = hex.EncodeToString(asset.Digest[:])
+		return nil
+	}
+
+	fd, err := asset.open()
+	if err != nil {
+		return err
+	}
+
+	defer fd.Close()
+
+	h := sha256.New()
+	e.Size, err = io.Copy(h, fd)
+	if err != nil {
+		return err
+	}
+
+	e.SHA256 = hex.EncodeToString(h.Sum(nil))
+	return nil
+}
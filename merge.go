@@ -0,0 +1,267 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"go/ast"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// validateMerge ensures merging is only used with split release output,
+// whose asset files can be kept, and whose names are known.
+func validateMerge(c *Config) error {
+	if !c.Merge {
+		return nil
+	}
+
+	if !c.SplitOutput {
+		return fmt.Errorf("Merging requires split output")
+	}
+
+	if c.Debug {
+		return fmt.Errorf("Merging cannot be used in debug builds")
+	}
+
+	if c.obfuscate() {
+		return fmt.Errorf("Merging cannot be combined with name obfuscation")
+	}
+
+	if c.SharedDictionary {
+		return fmt.Errorf("Merging cannot be combined with a shared dictionary")
+	}
+
+	return nil
+}
+
+// mergeAssets adds the assets of the existing output, which are not
+// covered by the inputs of the configuration, to the given ones, and
+// returns all of them sorted by name. The files of these assets are
+// kept as they are, unless they share the data of an asset which is
+// regenerated. New assets are renamed if their function is taken.
+func mergeAssets(c *Config, toc []Asset) ([]Asset, error) {
+	dir := c.splitDir()
+	_, err := os.Stat(filepath.Join(dir, splitTOCFile))
+	if os.IsNotExist(err) {
+		return toc, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := parseGenerated(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, ok := g.toc()
+	if !ok {
+		return nil, fmt.Errorf("No table of contents found in %s", dir)
+	}
+
+	sources, err := g.sources()
+	if err != nil {
+		return nil, fmt.Errorf("Invalid source manifest in %s: %v", dir, err)
+	}
+
+	found := make(map[string]bool)
+	for i := range toc {
+		found[toc[i].Name] = true
+	}
+
+	roots := ownedRoots(c)
+	fsys := make(embeddedFS)
+	keptFuncs := make(map[string]bool)
+
+	var kept []Asset
+	for _, kv := range entries {
+		name, err := stringValue(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		if found[name] || roots.owns(name) {
+			continue
+		}
+
+		id, ok := kv.Value.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("Asset %s: Unexpected expression in table of contents", name)
+		}
+
+		embedded, err := g.asset(name, id)
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s: %v", name, err)
+		}
+
+		fsys[name] = embedded
+		keptFuncs[id.Name] = true
+		kept = append(kept, Asset{
+			Path:         name,
+			Name:         name,
+			Func:         id.Name,
+			Size:         embedded.Size,
+			EmbeddedSize: embedded.embedded,
+			Compressed:   embedded.Compressed,
+			fsys:         fsys,
+			kept:         true,
+		})
+	}
+
+	for i := range kept {
+		asset := &kept[i]
+
+		// Data shared with an asset which is regenerated,
+		// or dropped, is written anew.
+		if source := g.dataSource(asset.Func); source != asset.Func {
+			if !keptFuncs[source] {
+				asset.kept = false
+			} else {
+				asset.EmbeddedSize = 0
+			}
+		}
+
+		asset.Digest, err = assetDigest(asset)
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s: %v", asset.Name, err)
+		}
+
+		if sum, ok := sources[asset.Name]; ok && sum != asset.Digest {
+			asset.sum = &sum
+		}
+	}
+
+	knownFuncs := make(map[string]int)
+	for i := range kept {
+		knownFuncs[kept[i].Func] = 2
+	}
+	for i := range toc {
+		knownFuncs[toc[i].Func] = 2
+	}
+	for i := range toc {
+		if keptFuncs[toc[i].Func] {
+			toc[i].Func = uniqueFuncName(toc[i].Func, knownFuncs)
+		}
+	}
+
+	toc = append(toc, kept...)
+	sort.Stable(assetsByName(toc))
+	return toc, nil
+}
+
+// sources returns the SHA-256 sums of the source files recorded in the
+// generated code, by asset name. It is empty for output without them.
+func (g *generated) sources() (map[string][sha256.Size]byte, error) {
+	expr, ok := g.vars["_bindata_sources"]
+	if !ok {
+		return nil, nil
+	}
+
+	list, err := stringValue(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseSources(list)
+}
+
+// assetRoots holds the names of the directories searched by the inputs.
+// Assets below them are covered by the inputs, whether found or not.
+type assetRoots struct {
+	recursive []string
+	flat      []string
+}
+
+// ownedRoots returns the directories searched by the local inputs of the
+// configuration, named like the assets found in them. Inputs naming a
+// single file, an archive or a URL only cover the assets they produce.
+func ownedRoots(c *Config) assetRoots {
+	var roots assetRoots
+	for i := range c.Input {
+		input := &c.Input[i]
+		if isRemote(input.Path) || isArchive(input.Path) || len(input.Name) > 0 {
+			continue
+		}
+
+		dir, prefix := resolvePrefix(input.Path, c.prefix(input))
+		fi, err := os.Stat(dir)
+		if err != nil || !fi.IsDir() {
+			continue
+		}
+
+		root := filepath.ToSlash(filepath.Clean(dir))
+		if root == "." {
+			root = ""
+		}
+		if strings.HasPrefix(root, prefix) {
+			root = root[len(prefix):]
+		}
+		root = strings.TrimPrefix(root, "/")
+
+		if input.Recursive {
+			roots.recursive = append(roots.recursive, root)
+		} else {
+			roots.flat = append(roots.flat, root)
+		}
+	}
+	return roots
+}
+
+// owns reports whether the asset with the given name is below one of
+// the directories, directly for those which are not searched recursively.
+func (r assetRoots) owns(name string) bool {
+	for _, root := range r.recursive {
+		if len(root) == 0 || strings.HasPrefix(name, root+"/") {
+			return true
+		}
+	}
+
+	for _, root := range r.flat {
+		dir := path.Dir(name)
+		if dir == "." {
+			dir = ""
+		}
+		if dir == root {
+			return true
+		}
+	}
+
+	return false
+}
+
+// embeddedFS is a file system holding the assets of generated code by
+// name, for merging them into new output.
+type embeddedFS map[string]EmbeddedAsset
+
+func (m embeddedFS) Open(name string) (fs.File, error) {
+	info, err := m.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	asset := m[name]
+	data, err := asset.Bytes()
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+
+	return memFile{Reader: bytes.NewReader(data), info: info}, nil
+}
+
+func (m embeddedFS) Stat(name string) (fs.FileInfo, error) {
+	asset, ok := m[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return memInfo{name: path.Base(name), size: asset.Size, mode: asset.Mode, modTime: asset.ModTime}, nil
+}
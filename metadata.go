@@ -0,0 +1,54 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Version is the version of bindata, which is recorded in the generated
+// code with Config.Metadata. Releases set it when building, with
+// -ldflags "-X bindata.Version=v1.2.3".
+var Version = "devel"
+
+// writeMetadata writes the GeneratedAt, GeneratorVersion and SourceHash
+// constants, and the AssetsVersion function. The source hash is derived
+// from the sums of the source files, so debug builds, which do not read
+// them during generation, leave it empty.
+func writeMetadata(w io.Writer, c *Config, toc []Asset) error {
+	var hash, version string
+	if c.Debug {
+		version = `"debug"`
+	} else {
+		hash = fmt.Sprintf("%x", sha256.Sum256([]byte(sourceList(toc))))
+		version = "SourceHash[:12]"
+	}
+
+	_, err := fmt.Fprintf(w, `// Metadata of the generated code, so programs can report
+// which snapshot of the assets they embed.
+const (
+	// GeneratedAt is the time the code was generated, in RFC 3339 format.
+	GeneratedAt = %q
+
+	// GeneratorVersion is the version of bindata which generated the code.
+	GeneratorVersion = %q
+
+	// SourceHash is the SHA-256 sum of the names and contents of all assets,
+	// hex encoded. It is empty for debug builds.
+	SourceHash = %q
+)
+
+// AssetsVersion returns a short identifier of the embedded assets, which
+// changes whenever any asset is added, removed or changed.
+func AssetsVersion() string {
+	return %s
+}
+
+`, c.generatedAt().Format(time.RFC3339), Version, hash, version)
+	return err
+}
@@ -0,0 +1,157 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// migrationPattern matches the names of migration files, as used by
+// golang-migrate: {version}_{title}.up.{extension} and .down alike.
+var migrationPattern = regexp.MustCompile(`^([0-9]+)_(.*)\.(down|up)\.(.*)$`)
+
+// migration is a database migration found among the assets.
+type migration struct {
+	version  uint64
+	title    string
+	up, down string
+}
+
+// findMigrations returns the migrations held by the assets in
+// the configured directory, ordered by version.
+func findMigrations(c *Config, toc []Asset) ([]migration, error) {
+	dir := path.Clean(c.Migrations)
+	byVersion := make(map[uint64]*migration)
+	for i := range toc {
+		name := toc[i].Name
+		if path.Dir(name) != dir {
+			continue
+		}
+
+		m := migrationPattern.FindStringSubmatch(path.Base(name))
+		if m == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid migration version in %s: %v", name, err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &migration{version: version, title: m[2]}
+			byVersion[version] = mig
+		}
+
+		file := &mig.up
+		if m[3] == "down" {
+			file = &mig.down
+		}
+		if len(*file) > 0 || mig.title != m[2] {
+			other := mig.up
+			if len(other) == 0 {
+				other = mig.down
+			}
+			return nil, fmt.Errorf("Duplicate migration version %d: %s and %s", version, other, name)
+		}
+		*file = name
+	}
+
+	list := make([]migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		list = append(list, *mig)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].version < list[j].version
+	})
+	return list, nil
+}
+
+// writeMigrations writes the Migration type, and the functions
+// listing the embedded migrations.
+func writeMigrations(w io.Writer, c *Config, toc []Asset) error {
+	list, err := findMigrations(c, toc)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `// Migration is a database migration, embedded as a pair of files
+// named like 0001_create_users.up.sql and 0001_create_users.down.sql.
+type Migration struct {
+	Version uint64 // The number the file names start with.
+	Title   string // The part of the file names following the version.
+	Up      string // The asset name of the up migration, if any.
+	Down    string // The asset name of the down migration, if any.
+}
+
+// ReadUp returns the contents of the up migration.
+func (m Migration) ReadUp() ([]byte, error) {
+	if m.Up == "" {
+		return nil, fmt.Errorf("%%w: no up migration for version %%d", ErrAssetNotFound, m.Version)
+	}
+	return Asset(%s)
+}
+
+// ReadDown returns the contents of the down migration.
+func (m Migration) ReadDown() ([]byte, error) {
+	if m.Down == "" {
+		return nil, fmt.Errorf("%%w: no down migration for version %%d", ErrAssetNotFound, m.Version)
+	}
+	return Asset(%s)
+}
+
+// Migrations returns the embedded migrations, ordered by version.
+func Migrations() []Migration {
+	list := make([]Migration, len(_bindata_migrations))
+	copy(list, _bindata_migrations)
+	return list
+}
+
+// MigrationNames returns the file names of the migrations, without their
+// directory. Along with MigrationAsset, it serves as the source of the
+// go_bindata driver of golang-migrate:
+//
+//	bindata.Resource(MigrationNames(), MigrationAsset)
+func MigrationNames() []string {
+	var names []string
+	for _, m := range _bindata_migrations {
+		for _, name := range []string{m.Up, m.Down} {
+			if name != "" {
+				names = append(names, path.Base(name))
+			}
+		}
+	}
+	return names
+}
+
+// MigrationAsset returns the contents of the migration file with the
+// given name, as returned by MigrationNames.
+func MigrationAsset(name string) ([]byte, error) {
+	return Asset(%s)
+}
+
+// _bindata_migrations holds the embedded migrations, ordered by version.
+var _bindata_migrations = []Migration{
+`, c.nameArg("m.Up"), c.nameArg("m.Down"), c.nameArg(fmt.Sprintf("path.Join(%q, name)", path.Clean(c.Migrations))))
+	if err != nil {
+		return err
+	}
+
+	for _, m := range list {
+		_, err = fmt.Fprintf(w, "\t{Version: %d, Title: %q, Up: %q, Down: %q},\n", m.version, m.title, m.up, m.down)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
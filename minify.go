@@ -0,0 +1,372 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"strings"
+)
+
+// minifiers maps the file extensions which can be minified
+// to the function doing so.
+var minifiers = map[string]func([]byte) ([]byte, error){
+	".css":  minifyCSS,
+	".htm":  minifyHTML,
+	".html": minifyHTML,
+	".js":   minifyJS,
+	".json": minifyJSON,
+}
+
+// minifyExt returns the normalized form of an extension given
+// in Config.Minify or Config.PerExtension, with a leading dot.
+func minifyExt(ext string) string {
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// validateMinify ensures minification is supported
+// for all of the configured extensions.
+func validateMinify(c *Config) error {
+	for _, ext := range c.Minify {
+		if _, ok := minifiers[minifyExt(ext)]; !ok {
+			return fmt.Errorf("Unsupported minify extension %q", ext)
+		}
+	}
+	return nil
+}
+
+// transforms returns the transforms to apply to the assets.
+// The transforms configured per extension follow the others,
+// and minification comes last.
+func (c *Config) transforms() []TransformFunc {
+	list := make([]TransformFunc, 0, len(c.Transforms)+2)
+	list = append(list, c.Transforms...)
+	if fn := c.extTransform(); fn != nil {
+		list = append(list, fn)
+	}
+
+	enabled := make(map[string]bool, len(c.Minify))
+	for _, ext := range c.Minify {
+		enabled[minifyExt(ext)] = true
+	}
+	for ext, opts := range c.PerExtension {
+		if opts.Minify {
+			enabled[minifyExt(ext)] = true
+		}
+	}
+	if len(enabled) == 0 {
+		return list
+	}
+
+	minify := func(name string, r io.Reader) (io.Reader, error) {
+		ext := strings.ToLower(path.Ext(name))
+		if !enabled[ext] {
+			return r, nil
+		}
+
+		b, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err = minifiers[ext](b)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to minify %s: %v", name, err)
+		}
+
+		return bytes.NewReader(b), nil
+	}
+
+	return append(list, minify)
+}
+
+// minifyJSON removes insignificant whitespace from JSON documents.
+func minifyJSON(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	err := json.Compact(&buf, b)
+	return buf.Bytes(), err
+}
+
+// minifyCSS removes comments and insignificant whitespace from style
+// sheets. Whitespace before colons is kept, as it is significant in
+// selectors like "a :hover".
+func minifyCSS(b []byte) ([]byte, error) {
+	var out bytes.Buffer
+	space := false
+
+	for i := 0; i < len(b); i++ {
+		ch := b[i]
+		switch {
+		case ch == '"' || ch == '\'':
+			end := quoted(b, i)
+			writeSpace(&out, space, "{};,>:(")
+			out.Write(b[i:end])
+			space = false
+			i = end - 1
+
+		case ch == '/' && i+1 < len(b) && b[i+1] == '*':
+			end := bytes.Index(b[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 3
+			space = true
+
+		case isSpace(ch):
+			space = true
+
+		case strings.IndexByte("{};,>", ch) >= 0:
+			if ch == '}' && out.Len() > 0 && out.Bytes()[out.Len()-1] == ';' {
+				out.Truncate(out.Len() - 1)
+			}
+			out.WriteByte(ch)
+			space = false
+
+		default:
+			writeSpace(&out, space, "{};,>:(")
+			out.WriteByte(ch)
+			space = false
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+// minifyJS removes comments, indentation and blank lines from scripts.
+// Line breaks are kept, so automatic semicolon insertion still applies.
+func minifyJS(b []byte) ([]byte, error) {
+	var out bytes.Buffer
+	space := false
+	newline := false
+
+	for i := 0; i < len(b); i++ {
+		ch := b[i]
+		switch {
+		case ch == '"' || ch == '\'' || ch == '`':
+			end := quoted(b, i)
+			writeBreak(&out, &space, &newline)
+			out.Write(b[i:end])
+			i = end - 1
+
+		case ch == '/' && i+1 < len(b) && b[i+1] == '/':
+			end := bytes.IndexByte(b[i:], '\n')
+			if end < 0 {
+				end = len(b) - i
+			}
+			i += end - 1
+
+		case ch == '/' && i+1 < len(b) && b[i+1] == '*':
+			end := bytes.Index(b[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			if bytes.IndexByte(b[i:i+end+4], '\n') >= 0 {
+				newline = true
+			} else {
+				space = true
+			}
+			i += end + 3
+
+		case ch == '/' && regexAllowed(out.Bytes()):
+			end := regexEnd(b, i)
+			writeBreak(&out, &space, &newline)
+			out.Write(b[i:end])
+			i = end - 1
+
+		case ch == '\n':
+			newline = true
+
+		case isSpace(ch):
+			space = true
+
+		default:
+			writeBreak(&out, &space, &newline)
+			out.WriteByte(ch)
+		}
+	}
+
+	if out.Len() > 0 {
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes(), nil
+}
+
+// minifyHTML removes comments from documents, and collapses runs of
+// whitespace into a single space or line break. Conditional comments
+// and the contents of pre, textarea, script and style elements are
+// kept as they are.
+func minifyHTML(b []byte) ([]byte, error) {
+	var out bytes.Buffer
+	var ws []byte
+
+	for i := 0; i < len(b); i++ {
+		ch := b[i]
+		switch {
+		case isSpace(ch):
+			ws = append(ws, ch)
+			continue
+
+		case bytes.HasPrefix(b[i:], []byte("<!--")) && !bytes.HasPrefix(b[i:], []byte("<!--[")):
+			end := bytes.Index(b[i+4:], []byte("-->"))
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 6
+			continue
+		}
+
+		if len(ws) > 0 {
+			if bytes.IndexByte(ws, '\n') >= 0 {
+				out.WriteByte('\n')
+			} else {
+				out.WriteByte(' ')
+			}
+			ws = ws[:0]
+		}
+
+		if ch == '<' {
+			if tag := rawElement(b[i:]); len(tag) > 0 {
+				end := bytes.Index(bytes.ToLower(b[i:]), []byte("</"+tag))
+				if end < 0 {
+					end = len(b) - i
+				}
+				out.Write(b[i : i+end])
+				i += end - 1
+				continue
+			}
+		}
+
+		out.WriteByte(ch)
+	}
+
+	if len(ws) > 0 && bytes.IndexByte(ws, '\n') >= 0 {
+		out.WriteByte('\n')
+	}
+
+	return out.Bytes(), nil
+}
+
+// rawElement returns the name of the element opened at the start of b,
+// if its contents must be kept as they are.
+func rawElement(b []byte) string {
+	for _, tag := range []string{"pre", "textarea", "script", "style"} {
+		if len(b) > len(tag)+1 && strings.EqualFold(string(b[1:len(tag)+1]), tag) {
+			switch b[len(tag)+1] {
+			case '>', ' ', '\t', '\n', '\r', '/':
+				return tag
+			}
+		}
+	}
+	return ""
+}
+
+// quoted returns the offset following the quoted string starting at b[i].
+func quoted(b []byte, i int) int {
+	quote := b[i]
+	for j := i + 1; j < len(b); j++ {
+		switch b[j] {
+		case '\\':
+			j++
+		case quote:
+			return j + 1
+		}
+	}
+	return len(b)
+}
+
+// regexAllowed reports whether a slash following the given output
+// starts a regular expression literal, rather than a division.
+func regexAllowed(out []byte) bool {
+	out = bytes.TrimRight(out, " \n")
+	if len(out) == 0 {
+		return true
+	}
+
+	if strings.IndexByte("(,=:[!&|?{};+-*%<>~^", out[len(out)-1]) >= 0 {
+		return true
+	}
+
+	for _, keyword := range []string{"return", "typeof", "case", "do", "else", "in", "of", "void", "yield"} {
+		if bytes.HasSuffix(out, []byte(keyword)) {
+			n := len(out) - len(keyword)
+			if n == 0 || !isIdent(out[n-1]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// regexEnd returns the offset following the regular
+// expression literal starting at b[i], including flags.
+func regexEnd(b []byte, i int) int {
+	class := false
+	for j := i + 1; j < len(b); j++ {
+		switch b[j] {
+		case '\\':
+			j++
+		case '[':
+			class = true
+		case ']':
+			class = false
+		case '\n':
+			return j
+		case '/':
+			if !class {
+				j++
+				for j < len(b) && isIdent(b[j]) {
+					j++
+				}
+				return j
+			}
+		}
+	}
+	return len(b)
+}
+
+// writeSpace writes a single space to out, if whitespace was skipped
+// and neither the previous output nor the next character make it
+// redundant.
+func writeSpace(out *bytes.Buffer, space bool, redundant string) {
+	if !space || out.Len() == 0 {
+		return
+	}
+	if strings.IndexByte(redundant, out.Bytes()[out.Len()-1]) >= 0 {
+		return
+	}
+	out.WriteByte(' ')
+}
+
+// writeBreak writes the line break or space skipped before the next token.
+func writeBreak(out *bytes.Buffer, space, newline *bool) {
+	if out.Len() > 0 {
+		if *newline {
+			out.WriteByte('\n')
+		} else if *space {
+			out.WriteByte(' ')
+		}
+	}
+	*space = false
+	*newline = false
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r' || ch == '\f'
+}
+
+func isIdent(ch byte) bool {
+	return ch == '_' || ch == '$' || ch >= '0' && ch <= '9' ||
+		ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= 0x80
+}
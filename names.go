@@ -0,0 +1,192 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// reservedNames holds the identifiers of the generated API,
+// which asset name constants and accessors must not clash with.
+// TestConstantName checks that it holds every exported identifier
+// of the generated code.
+var reservedNames = []string{
+	"Asset", "AssetName", "AssetNames", "AssetNamesWithPrefix", "AssetDir",
+	"AssetInfo", "AssetCompressed", "AssetGzip", "AssetDigest", "AssetFS",
+	"AssetHandler", "AssetGlob", "AssetLocales", "AssetLocalized",
+	"AssetNode", "AssetTree", "AssetString", "AssetUnsafe", "AssetsCount",
+	"AssetsSize", "AssetsCompressedSize", "AssetsVersion",
+	"ContentType", "Digests", "ErrAssetNotFound", "ExtractAll",
+	"FlushAssetCache", "GeneratedAt", "GeneratorVersion", "HashedName",
+	"UnhashedName", "Migration", "MigrationAsset", "MigrationNames",
+	"Migrations", "MustAsset", "OverlayFS", "ParseTemplates",
+	"ParseTextTemplates", "Register", "RestoreAsset", "RestoreAssets",
+	"RootCAs", "SetAssetCacheSize", "SetAssetData", "SetAssetKey",
+	"SourceHash", "VerifyAssets", "WalkAssets",
+}
+
+// reservedFuncs returns the reserved names as a set, for
+// constantName and accessorName.
+func reservedFuncs() map[string]bool {
+	known := make(map[string]bool, len(reservedNames))
+	for _, name := range reservedNames {
+		known[name] = true
+	}
+	return known
+}
+
+// typedHooks holds wrappers of the generated functions accepting an
+// AssetName, which let them be assigned to grate hooks taking a string.
+var typedHooks = map[string]string{
+	"Asset":           "func(name string) ([]byte, error) { return Asset(AssetName(name)) }",
+	"MustAsset":       "func(name string) []byte { return MustAsset(AssetName(name)) }",
+	"AssetInfo":       "func(name string) (os.FileInfo, error) { return AssetInfo(AssetName(name)) }",
+	"AssetCompressed": "func(name, encoding string) ([]byte, error) { return AssetCompressed(AssetName(name), encoding) }",
+	"AssetGzip":       "func(name string) ([]byte, error) { return AssetGzip(AssetName(name)) }",
+	"AssetDigest":     "func(name string) ([32]byte, error) { return AssetDigest(AssetName(name)) }",
+}
+
+// grateHook returns the expression assigned to the
+// grate hook for the given generated function.
+func (c *Config) grateHook(fn string) string {
+	if hook, ok := typedHooks[fn]; ok && c.typedNames() {
+		return hook
+	}
+	return fn
+}
+
+// assetName converts the given name to the form of the asset names, with
+// forward slashes, even for backslashes on systems other than Windows,
+// and without a leading one or elements like "..". The generated code
+// converts the names passed to its lookup functions in the same way.
+func assetName(name string) string {
+	return path.Clean("/" + strings.Replace(name, "\\", "/", -1))[1:]
+}
+
+// typedNames reports whether the AssetName type and constants are
+// generated. Obfuscated names are only available through them.
+func (c *Config) typedNames() bool {
+	return c.TypedNames || c.obfuscate()
+}
+
+// obfuscate reports whether asset names are replaced by salted hashes.
+func (c *Config) obfuscate() bool {
+	return len(c.NameSalt) > 0
+}
+
+// obfuscateNames replaces the names of the assets by an HMAC of them,
+// keyed with the configured salt. The function names are derived from
+// the hashes as well, as they end up in the symbol table of a binary.
+// The original names are kept for the constants.
+func obfuscateNames(c *Config, toc []Asset) error {
+	knownFuncs := make(map[string]int)
+	names := make(map[string]string, len(toc))
+
+	for i := range toc {
+		asset := &toc[i]
+
+		mac := hmac.New(sha256.New, []byte(c.NameSalt))
+		mac.Write([]byte(asset.Name))
+		hash := hex.EncodeToString(mac.Sum(nil)[:10])
+
+		if other, ok := names[hash]; ok {
+			return fmt.Errorf("Obfuscated names of %s and %s collide", other, asset.Name)
+		}
+
+		names[hash] = asset.Name
+		asset.label = asset.Name
+		asset.Name = hash
+		asset.Func = safeFunctionName(hash, knownFuncs)
+	}
+
+	return nil
+}
+
+// nameType returns the type of the asset names accepted
+// by the generated lookup functions.
+func (c *Config) nameType() string {
+	if c.typedNames() {
+		return "AssetName"
+	}
+	return "string"
+}
+
+// nameArg converts the given string expression for
+// passing it to a generated lookup function.
+func (c *Config) nameArg(expr string) string {
+	if c.typedNames() {
+		return "AssetName(" + expr + ")"
+	}
+	return expr
+}
+
+// stringArg converts the given asset name expression to a string.
+func (c *Config) stringArg(expr string) string {
+	if c.typedNames() {
+		return "string(" + expr + ")"
+	}
+	return expr
+}
+
+// writeNameConstants writes the AssetName type along
+// with a constant for the name of every asset.
+func writeNameConstants(w io.Writer, toc []Asset) error {
+	_, err := fmt.Fprintf(w, `// AssetName is the name of an embedded asset.
+// The constants below hold the names of all assets.
+type AssetName string
+
+const (
+`)
+	if err != nil {
+		return err
+	}
+
+	known := reservedFuncs()
+	for i := range toc {
+		label := toc[i].Name
+		if len(toc[i].label) > 0 {
+			label = toc[i].label
+		}
+
+		_, err = fmt.Fprintf(w, "\t%s AssetName = %q\n", constantName(label, known), toc[i].Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, ")\n\n")
+	return err
+}
+
+// constantName converts the given asset name into an exported
+// identifier, like AssetCssAppCss for "css/app.css". Names
+// which would clash with known identifiers are numbered.
+func constantName(name string, known map[string]bool) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	ident := "Asset"
+	for _, word := range words {
+		r := []rune(word)
+		r[0] = unicode.ToUpper(r[0])
+		ident += string(r)
+	}
+
+	base := ident
+	for num := 2; known[ident]; num++ {
+		ident = fmt.Sprintf("%s%d", base, num)
+	}
+
+	known[ident] = true
+	return ident
+}
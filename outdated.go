@@ -0,0 +1,159 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Outdated reports whether the generated file, or directory in split
+// mode, is out of date with the inputs of the given configuration, and
+// the names of the assets which were added, removed or changed since.
+// It compares the SHA-256 sums of the source files with those recorded
+// in the generated code, which is cheaper than Diff, as no embedded data
+// is decoded. Only release builds record the sums. An empty file name
+// stands for the output of the configuration.
+func Outdated(c *Config, generatedFile string) (bool, []string, error) {
+	if len(generatedFile) == 0 {
+		generatedFile = c.Output
+		if c.SplitOutput {
+			generatedFile = c.splitDir()
+		}
+	}
+
+	g, err := parseGenerated(generatedFile)
+	if err != nil {
+		return false, nil, err
+	}
+
+	expr, ok := g.vars["_bindata_sources"]
+	if !ok {
+		return false, nil, fmt.Errorf("No source manifest found in %s", generatedFile)
+	}
+
+	list, err := stringValue(expr)
+	if err != nil {
+		return false, nil, fmt.Errorf("Invalid source manifest in %s: %v", generatedFile, err)
+	}
+
+	recorded, err := parseSources(list)
+	if err != nil {
+		return false, nil, fmt.Errorf("Invalid source manifest in %s: %v", generatedFile, err)
+	}
+
+	toc, _, cleanup, err := findAssets(c)
+	defer cleanup()
+	if err != nil {
+		return false, nil, err
+	}
+
+	var changed []string
+	for i := range toc {
+		asset := &toc[i]
+		name := asset.sourceName()
+
+		sum, err := asset.sourceDigest()
+		if err != nil {
+			return false, nil, err
+		}
+
+		if prev, ok := recorded[name]; !ok || prev != sum {
+			changed = append(changed, name)
+		}
+		delete(recorded, name)
+	}
+
+	for name := range recorded {
+		changed = append(changed, name)
+	}
+
+	sort.Strings(changed)
+	return len(changed) > 0, changed, nil
+}
+
+// sourceName returns the name of the asset before obfuscation.
+func (a *Asset) sourceName() string {
+	if len(a.label) > 0 {
+		return a.label
+	}
+	return a.Name
+}
+
+// sourceDigest returns the SHA-256 sum of the source file of the asset.
+// It differs from Digest for assets whose contents were transformed.
+func (a *Asset) sourceDigest() ([sha256.Size]byte, error) {
+	if a.sum != nil {
+		return *a.sum, nil
+	}
+	return assetDigest(a)
+}
+
+// writeSources writes the SHA-256 sums of the source files of the assets
+// as a constant, for Outdated. Constants which are not referred to are
+// left out of binaries, so the list only takes space in the source. It
+// must be called after the assets were written, so their digests are known.
+func writeSources(w io.Writer, toc []Asset) error {
+	_, err := fmt.Fprintf(w, "// _bindata_sources lists the SHA-256 sums of the source files of the assets.\nconst _bindata_sources = `\n%s`\n\n", sourceList(toc))
+	return err
+}
+
+// sourceList returns a line for each asset, holding the SHA-256 sum of
+// its source file and its name. Names are quoted, with backquotes
+// escaped, so the list can be written as a raw string literal.
+func sourceList(toc []Asset) string {
+	var b strings.Builder
+	for i := range toc {
+		asset := &toc[i]
+		sum := asset.Digest
+		if asset.sum != nil {
+			sum = *asset.sum
+		}
+
+		name := strings.Replace(strconv.Quote(asset.sourceName()), "`", `\x60`, -1)
+		fmt.Fprintf(&b, "%x %s\n", sum, name)
+	}
+	return b.String()
+}
+
+// parseSources parses the list written by writeSources.
+func parseSources(list string) (map[string][sha256.Size]byte, error) {
+	sources := make(map[string][sha256.Size]byte)
+
+	s := bufio.NewScanner(strings.NewReader(list))
+	for s.Scan() {
+		line := s.Text()
+		if len(line) == 0 {
+			continue
+		}
+
+		i := strings.IndexByte(line, ' ')
+		if i < 0 {
+			return nil, fmt.Errorf("malformed line %q", line)
+		}
+
+		digest, err := hex.DecodeString(line[:i])
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("malformed sum in line %q", line)
+		}
+
+		name, err := strconv.Unquote(line[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("malformed name in line %q", line)
+		}
+
+		var sum [sha256.Size]byte
+		copy(sum[:], digest)
+		sources[name] = sum
+	}
+
+	return sources, s.Err()
+}
@@ -0,0 +1,246 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"runtime"
+)
+
+const (
+	// defaultParallelChunk is the amount of data compressed
+	// by each worker, unless configured otherwise.
+	defaultParallelChunk = 1 << 20
+
+	// minParallelChunk is the smallest chunk size accepted. Smaller
+	// chunks would hardly benefit from the data before them.
+	minParallelChunk = 64 << 10
+
+	// parallelWindow is the amount of data before a chunk, which
+	// is passed as the dictionary for compressing it. It is the
+	// largest distance deflate refers back to.
+	parallelWindow = 32 << 10
+)
+
+// parallel reports whether the given asset is compressed
+// in chunks by several workers.
+func (c *Config) parallel(asset *Asset) bool {
+	if c.ParallelSize <= 0 || c.Debug || c.compression() != CompressGzip || c.sharedDictionary() {
+		return false
+	}
+
+	fi, err := asset.stat()
+	return err == nil && fi.Size() >= c.ParallelSize
+}
+
+// parallelWorkers returns the number of chunks of an
+// asset compressed concurrently.
+func (c *Config) parallelWorkers() int {
+	if c.ParallelWorkers > 0 {
+		return c.ParallelWorkers
+	}
+
+	return runtime.NumCPU()
+}
+
+// parallelChunk returns the amount of data compressed by each worker.
+func (c *Config) parallelChunk() int {
+	if c.ParallelChunkSize > 0 {
+		return c.ParallelChunkSize
+	}
+
+	return defaultParallelChunk
+}
+
+// validateParallel ensures the settings for parallel
+// compression are valid, if it is enabled.
+func validateParallel(c *Config) error {
+	if c.ParallelSize < 0 || c.ParallelWorkers < 0 || c.ParallelChunkSize < 0 {
+		return fmt.Errorf("Parallel compression settings must not be negative")
+	}
+
+	if c.ParallelSize == 0 || c.Debug {
+		return nil
+	}
+
+	if c.compression() != CompressGzip {
+		return fmt.Errorf("Parallel compression requires gzip compression")
+	}
+
+	if c.SharedDictionary {
+		return fmt.Errorf("Parallel compression cannot be combined with a shared dictionary")
+	}
+
+	if c.ParallelChunkSize > 0 && c.ParallelChunkSize < minParallelChunk {
+		return fmt.Errorf("Parallel chunk size must be at least %d bytes", minParallelChunk)
+	}
+
+	return nil
+}
+
+// parallelResult holds the compressed data of a chunk.
+type parallelResult struct {
+	data []byte
+	err  error
+}
+
+// parallelGzip is a gzip encoder, which compresses chunks of the data
+// concurrently, the way pigz does. Every chunk is compressed with the
+// data before it as the dictionary, and flushed to a byte boundary, so
+// the concatenated chunks form a single deflate stream. The output does
+// not depend on the number of workers, only on the chunk size.
+type parallelGzip struct {
+	w       io.Writer
+	level   int
+	workers int
+	buf     []byte
+	window  []byte
+	pending []chan parallelResult
+	crc     uint32
+	size    uint32
+	err     error
+}
+
+// newParallelGzip returns a gzip encoder writing to w,
+// which uses the parallel compression settings of c.
+func newParallelGzip(w io.Writer, c *Config) (io.WriteCloser, error) {
+	level := c.CompressionLevel
+	if level == 0 {
+		level = gzip.DefaultCompression
+	}
+
+	// The header is the one written by gzip.Writer, without a name
+	// or timestamp, so the output only depends on the contents.
+	header := []byte{0x1f, 0x8b, 8, 0, 0, 0, 0, 0, 0, 255}
+	switch level {
+	case gzip.BestCompression:
+		header[8] = 2
+	case gzip.BestSpeed:
+		header[8] = 4
+	}
+
+	_, err := w.Write(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parallelGzip{
+		w:       w,
+		level:   level,
+		workers: c.parallelWorkers(),
+		buf:     make([]byte, 0, c.parallelChunk()),
+	}, nil
+}
+
+func (z *parallelGzip) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 && z.err == nil {
+		m := copy(z.buf[len(z.buf):cap(z.buf)], p)
+		z.buf = z.buf[:len(z.buf)+m]
+		p = p[m:]
+		n += m
+
+		if len(z.buf) == cap(z.buf) {
+			z.flushChunk(false)
+		}
+	}
+
+	return n, z.err
+}
+
+// flushChunk starts compressing the buffered data. Once as many
+// chunks as there are workers are in progress, the first of them
+// is waited for and written.
+func (z *parallelGzip) flushChunk(last bool) {
+	z.crc = crc32.Update(z.crc, crc32.IEEETable, z.buf)
+	z.size += uint32(len(z.buf))
+
+	data := z.buf
+	dict := z.window
+	if len(data) >= parallelWindow {
+		z.window = append([]byte(nil), data[len(data)-parallelWindow:]...)
+	} else {
+		z.window = append(z.window, data...)
+		if len(z.window) > parallelWindow {
+			z.window = z.window[len(z.window)-parallelWindow:]
+		}
+	}
+	z.buf = make([]byte, 0, cap(z.buf))
+
+	done := make(chan parallelResult, 1)
+	z.pending = append(z.pending, done)
+	go func() {
+		data, err := compressChunk(data, dict, z.level, last)
+		done <- parallelResult{data, err}
+	}()
+
+	for len(z.pending) >= z.workers || last && len(z.pending) > 0 {
+		z.writeResult()
+	}
+}
+
+// writeResult waits for the first chunk in progress and writes it.
+func (z *parallelGzip) writeResult() {
+	r := <-z.pending[0]
+	z.pending = z.pending[1:]
+	if z.err != nil {
+		return
+	}
+
+	z.err = r.err
+	if z.err == nil {
+		_, z.err = z.w.Write(r.data)
+	}
+}
+
+// Close compresses the remaining data and writes the gzip trailer.
+func (z *parallelGzip) Close() error {
+	if z.err != nil {
+		for len(z.pending) > 0 {
+			z.writeResult()
+		}
+		return z.err
+	}
+
+	z.flushChunk(true)
+	if z.err != nil {
+		return z.err
+	}
+
+	var trailer [8]byte
+	binary.LittleEndian.PutUint32(trailer[:4], z.crc)
+	binary.LittleEndian.PutUint32(trailer[4:], z.size)
+	_, z.err = z.w.Write(trailer[:])
+	return z.err
+}
+
+// compressChunk returns the raw deflate data of a chunk, compressed
+// with the data before it as the dictionary. All chunks except the
+// last end in a sync flush, rather than the final block.
+func compressChunk(data, dict []byte, level int, last bool) ([]byte, error) {
+	var buf bytes.Buffer
+	fw, err := flate.NewWriterDict(&buf, level, dict)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = fw.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if last {
+		err = fw.Close()
+	} else {
+		err = fw.Flush()
+	}
+	return buf.Bytes(), err
+}
@@ -0,0 +1,62 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"io"
+	"io/ioutil"
+	"time"
+)
+
+// Plan locates the assets of the given configuration, and encodes them
+// as a release build would, without writing any output. This shows what
+// would be embedded, to check the filters of the inputs, or find large
+// assets before they end up in a binary. The assets are returned sorted
+// by name, with their Size, EmbeddedSize and Compressed fields set.
+func Plan(c *Config) ([]Asset, *Stats, error) {
+	start := time.Now()
+
+	err := c.validateSettings()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toc, _, cleanup, err := findAssets(c)
+	defer cleanup()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if c.dedupe() {
+		err = findDuplicates(toc)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	warnings, err := checkSizes(c, toc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = trainDictionary(c, toc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = encodeAssets(ioutil.Discard, len(toc), c.jobs(), func(w io.Writer, i int) error {
+		return writeReleaseAsset(w, c, &toc[i])
+	}, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	copyDuplicates(toc)
+
+	stats := newStats(toc)
+	stats.Duration = time.Since(start)
+	stats.Warnings = warnings
+	return toc, stats, nil
+}
@@ -0,0 +1,246 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// platformPattern matches the target platforms of inputs,
+// given as "goos" or "goos/goarch".
+var platformPattern = regexp.MustCompile(`^[a-z0-9]+(/[a-z0-9]+)?$`)
+
+// validatePlatforms ensures the target platforms of the inputs are
+// well formed, and the output can be written once for each of them.
+func validatePlatforms(c *Config) error {
+	for _, input := range c.Input {
+		for _, p := range input.Platforms {
+			if !platformPattern.MatchString(p) {
+				return fmt.Errorf("Invalid platform %q of input %s, want goos or goos/goarch", p, input.Path)
+			}
+		}
+	}
+
+	if len(c.platforms()) == 0 {
+		return nil
+	}
+
+	if c.SplitOutput {
+		return fmt.Errorf("Platform specific inputs cannot be combined with split output")
+	}
+
+	if len(c.IncrementalCache) > 0 {
+		return fmt.Errorf("Platform specific inputs cannot be combined with incremental regeneration")
+	}
+
+	return nil
+}
+
+// platforms returns the distinct target platforms of all inputs, sorted.
+func (c *Config) platforms() []string {
+	seen := make(map[string]bool)
+	var list []string
+	for _, input := range c.Input {
+		for _, p := range input.Platforms {
+			if !seen[p] {
+				seen[p] = true
+				list = append(list, p)
+			}
+		}
+	}
+	sort.Strings(list)
+	return list
+}
+
+// platformCovers reports whether platform p includes platform q,
+// which is the case if both are the same, or p names the operating
+// system of q without an architecture.
+func platformCovers(p, q string) bool {
+	return p == q || !strings.Contains(p, "/") && strings.HasPrefix(q, p+"/")
+}
+
+// platformsOverlap reports whether there is a platform targeted by
+// both lists of platforms. An empty list targets all of them.
+func platformsOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+
+	for _, p := range a {
+		for _, q := range b {
+			if platformCovers(p, q) || platformCovers(q, p) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// platformsCollide reports whether some of the given assets, which share
+// the same name, are built for the same platform. Assets of inputs for
+// different platforms may use the same name, as only one of them is
+// built at a time.
+func platformsCollide(list []Asset) bool {
+	for i := range list {
+		for j := i + 1; j < len(list); j++ {
+			if platformsOverlap(list[i].platforms, list[j].platforms) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// platformExpr returns the build constraint matching the given platform.
+func platformExpr(p string) constraint.Expr {
+	n := strings.IndexByte(p, '/')
+	if n < 0 {
+		return &constraint.TagExpr{Tag: p}
+	}
+
+	return &constraint.AndExpr{
+		X: &constraint.TagExpr{Tag: p[:n]},
+		Y: &constraint.TagExpr{Tag: p[n+1:]},
+	}
+}
+
+// platformConstraint returns the build constraint of the output for the
+// given platform, or for all other platforms if it is empty. Platforms
+// covered by a more specific one in the list are left to that one's
+// output, so exactly one of the outputs is built for any target.
+func platformConstraint(p string, list []string) string {
+	var expr constraint.Expr
+	if len(p) > 0 {
+		expr = platformExpr(p)
+	}
+
+	for _, q := range list {
+		if q == p {
+			continue
+		}
+
+		// The output for all other platforms excludes only those
+		// not covered by another listed platform already.
+		if len(p) == 0 {
+			covered := false
+			for _, r := range list {
+				covered = covered || r != q && platformCovers(r, q)
+			}
+			if covered {
+				continue
+			}
+		} else if !platformCovers(p, q) {
+			continue
+		}
+
+		not := &constraint.NotExpr{X: platformExpr(q)}
+		if expr == nil {
+			expr = not
+		} else {
+			expr = &constraint.AndExpr{X: expr, Y: not}
+		}
+	}
+
+	if expr == nil {
+		return ""
+	}
+	return expr.String()
+}
+
+// platformOutput returns the name of the output file for the given
+// platform. The platform is appended to the base name of the output,
+// as in bindata_linux_amd64.go, which the go tool recognizes as well.
+func platformOutput(output, p string) string {
+	ext := filepath.Ext(output)
+	return strings.TrimSuffix(output, ext) + "_" + strings.Replace(p, "/", "_", -1) + ext
+}
+
+// platformBuilt reports whether an input for the given list of platforms
+// is built into the output for platform p, or for all other platforms if
+// p is empty.
+func platformBuilt(list []string, p string) bool {
+	found := len(list) == 0
+	for _, q := range list {
+		found = found || len(p) > 0 && platformCovers(q, p)
+	}
+	return found
+}
+
+// platformAssets returns copies of the assets built for the given
+// platform, or for all other platforms if it is empty. These are the
+// assets of all inputs without platforms, along with those of inputs
+// whose platforms include the given one. It returns the positions of
+// the copies in the original list as well.
+func platformAssets(list []Asset, p string) ([]Asset, []int) {
+	var out []Asset
+	var index []int
+	for i := range list {
+		if !platformBuilt(list[i].platforms, p) {
+			continue
+		}
+
+		asset := list[i]
+		asset.original = nil
+		out = append(out, asset)
+		index = append(index, i)
+	}
+	return out, index
+}
+
+// writePlatforms writes the output once for all platforms without
+// platform specific inputs, and once more for each platform which has
+// some. Every output holds all of the assets built for its platforms,
+// and is limited to them by its build constraint. Identical assets
+// share their data within an output only.
+//
+// The results of encoding the assets are copied back to the table
+// of contents, to be reported in the statistics and manifest.
+func writePlatforms(c *Config, toc, dirs []Asset) error {
+	list := c.platforms()
+	for _, p := range append([]string{""}, list...) {
+		pc := *c
+		pc.Tags = append(append([]string(nil), c.Tags...), platformConstraint(p, list))
+		if len(p) > 0 {
+			pc.Output = platformOutput(c.Output, p)
+		}
+
+		// Debug builds walk the inputs at runtime.
+		pc.Input = nil
+		for _, input := range c.Input {
+			if platformBuilt(input.Platforms, p) {
+				pc.Input = append(pc.Input, input)
+			}
+		}
+
+		assets, index := platformAssets(toc, p)
+		found, _ := platformAssets(dirs, p)
+
+		if c.dedupe() {
+			err := findDuplicates(assets)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := writeOutput(&pc, assets, found)
+		if err != nil {
+			return err
+		}
+
+		for i, asset := range assets {
+			toc[index[i]].Digest = asset.Digest
+			toc[index[i]].Size = asset.Size
+			toc[index[i]].EmbeddedSize = asset.EmbeddedSize
+			toc[index[i]].Compressed = asset.Compressed
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// validateProvenance ensures provenance comments do not reveal
+// obfuscated names.
+func validateProvenance(c *Config) error {
+	if c.Provenance && c.obfuscate() {
+		return fmt.Errorf("Provenance comments cannot be combined with name obfuscation")
+	}
+	return nil
+}
+
+// provenance returns the comment lines describing the source of the
+// asset, each starting with the given indent, if Config.Provenance is
+// set. The digest of the asset must be known.
+func provenance(c *Config, asset *Asset, indent string) (string, error) {
+	if !c.Provenance {
+		return "", nil
+	}
+
+	fi, err := asset.stat()
+	if err != nil {
+		return "", err
+	}
+
+	modTime := fi.ModTime()
+	if c.ModTime != 0 {
+		modTime = time.Unix(c.ModTime, 0)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s// Source: %s\n", indent, sourcePath(c, asset))
+	fmt.Fprintf(&b, "%s// Size: %d\n", indent, fi.Size())
+	fmt.Fprintf(&b, "%s// SHA-256: %x\n", indent, asset.Digest)
+	fmt.Fprintf(&b, "%s// Modified: %s\n", indent, modTime.UTC().Format(time.RFC3339))
+	return b.String(), nil
+}
+
+// sourcePath returns the file the asset was read from, relative to the
+// directory of the output, so it is the same on every machine. Other
+// sources, like URLs, are returned as they are.
+func sourcePath(c *Config, asset *Asset) string {
+	source := asset.origin()
+	if !filepath.IsAbs(source) {
+		return source
+	}
+
+	dir := filepath.Dir(c.Output)
+	if c.SplitOutput {
+		dir = c.splitDir()
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.ToSlash(source)
+	}
+
+	rel, err := filepath.Rel(dir, source)
+	if err != nil {
+		return filepath.ToSlash(source)
+	}
+	return filepath.ToSlash(rel)
+}
+
+// assetDoc returns the doc comment of the function of the asset,
+// holding its provenance, if Config.Provenance is set.
+func assetDoc(c *Config, asset *Asset) (string, error) {
+	lines, err := provenance(c, asset, "")
+	if err != nil || len(lines) == 0 {
+		return "", err
+	}
+	return fmt.Sprintf("// %s returns the asset %q.\n//\n%s", asset.Func, asset.Name, lines), nil
+}
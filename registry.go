@@ -0,0 +1,142 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// ErrAssetNotFound is returned by a Registry, wrapped along with the
+// requested name, if no package registered an asset of that name.
+var ErrAssetNotFound = errors.New("Asset not found")
+
+// Registry is a central lookup for the assets of several generated
+// packages, like those carried by plugins. Each package adds its assets
+// by passing the registry to the Register function generated for
+// Config.Register. All packages share one space of asset names, and
+// a package whose assets would take a name used by another one is
+// rejected. The zero value is an empty registry ready to use. It is
+// safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	assets map[string]*registryPackage
+}
+
+// registryPackage holds the lookup functions of a registered package.
+type registryPackage struct {
+	name  string
+	asset func(string) ([]byte, error)
+	info  func(string) (os.FileInfo, error)
+}
+
+// AddPackage registers the named assets of a generated package, whose
+// contents and file info are returned by the given functions. It fails
+// without registering any of them, if one of the names is registered
+// already. The generated Register functions call it.
+func (r *Registry) AddPackage(pkg string, names []string, asset func(string) ([]byte, error), info func(string) (os.FileInfo, error)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, name := range names {
+		if other, ok := r.assets[name]; ok {
+			return fmt.Errorf("Asset %s of package %s is registered by package %s already", name, pkg, other.name)
+		}
+	}
+
+	if r.assets == nil {
+		r.assets = make(map[string]*registryPackage)
+	}
+
+	p := &registryPackage{name: pkg, asset: asset, info: info}
+	for _, name := range names {
+		r.assets[name] = p
+	}
+	return nil
+}
+
+// lookup returns the package which registered the named asset.
+func (r *Registry) lookup(name string) (*registryPackage, string, error) {
+	cannonicalName := assetName(name)
+
+	r.mu.RLock()
+	p, ok := r.assets[cannonicalName]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s", ErrAssetNotFound, name)
+	}
+	return p, cannonicalName, nil
+}
+
+// Asset returns the contents of the named asset,
+// as returned by the package which registered it.
+func (r *Registry) Asset(name string) ([]byte, error) {
+	p, name, err := r.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.asset(name)
+}
+
+// AssetInfo returns the file info of the named asset,
+// as returned by the package which registered it.
+func (r *Registry) AssetInfo(name string) (os.FileInfo, error) {
+	p, name, err := r.lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return p.info(name)
+}
+
+// AssetNames returns the names of all registered assets, sorted.
+func (r *Registry) AssetNames() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.assets))
+	for name := range r.assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Package returns the name of the package which registered the named
+// asset. It reports whether there is such an asset.
+func (r *Registry) Package(name string) (string, bool) {
+	p, _, err := r.lookup(name)
+	if err != nil {
+		return "", false
+	}
+	return p.name, true
+}
+
+// writeRegister writes the Register function, which adds the assets
+// to a Registry. The generated code does not import this package, but
+// accepts any registry with a matching AddPackage method.
+func writeRegister(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// Register adds the assets to the given registry, usually a
+// *bindata.Registry shared by several generated packages, which then
+// serves them along with those of the other packages. The assets are
+// registered for the package %q. Register fails without adding any
+// asset, if one of the names is registered by another package already.
+func Register(registry interface {
+	AddPackage(pkg string, names []string, asset func(string) ([]byte, error), info func(string) (os.FileInfo, error)) error
+}) error {
+	return registry.AddPackage(%q, AssetNames(), func(name string) ([]byte, error) {
+		return Asset(%s)
+	}, func(name string) (os.FileInfo, error) {
+		return AssetInfo(%s)
+	})
+}
+
+`, c.Register, c.Register, c.nameArg("name"), c.nameArg("name"))
+	return err
+}
@@ -6,404 +6,576 @@ package bindata
 
 import (
 	"bytes"
-	"compress/gzip"
+	"crypto/sha256"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"os"
-	"unicode/utf8"
 )
 
-// writeRelease writes the release code file.
-func writeRelease(w io.Writer, c *Config, toc []Asset) error {
+// writeRelease writes the release code file. If inc is not nil,
+// the code of unchanged assets is taken from the previous run.
+func writeRelease(w io.Writer, c *Config, toc []Asset, inc *incremental) error {
 	err := writeReleaseHeader(w, c)
 	if err != nil {
 		return err
 	}
 
-	for i := range toc {
-		err = writeReleaseAsset(w, c, &toc[i])
+	switch {
+	case c.SingleBlob:
+		err = writeBlob(w, c, toc)
+	case inc != nil:
+		err = inc.writeAssets(w, c, toc)
+	case c.Assembly:
+		err = writeAsmAssets(w, c, toc)
+	default:
+		err = encodeAssets(w, len(toc), c.jobs(), func(w io.Writer, i int) error {
+			return writeReleaseAsset(w, c, &toc[i])
+		}, c.assetEvents(toc))
+	}
+
+	copyDuplicates(toc)
+	return err
+}
+
+// encodeResult holds the generated code for an asset.
+type encodeResult struct {
+	buf bytes.Buffer
+	err error
+}
+
+// encodeAssets calls fn for each of the n assets, using the given number
+// of workers. Each result is buffered until the results of all assets
+// before it have been written, so the output is the same as for a serial
+// run. At most jobs assets are encoded ahead of the one being written,
+// which bounds the memory used for buffering. With a single worker,
+// fn writes to w directly. If done is not nil, it is called with the
+// index of each asset once written, in order, from the calling goroutine.
+func encodeAssets(w io.Writer, n, jobs int, fn func(w io.Writer, i int) error, done func(i int)) error {
+	if jobs == 1 || n < 2 {
+		for i := 0; i < n; i++ {
+			err := fn(w, i)
+			if err != nil {
+				return err
+			}
+			if done != nil {
+				done(i)
+			}
+		}
+
+		return nil
+	}
+
+	pending := make(chan chan *encodeResult, jobs-1)
+	stop := make(chan struct{})
+
+	go func() {
+		defer close(pending)
+
+		for i := 0; i < n; i++ {
+			done := make(chan *encodeResult, 1)
+			select {
+			case pending <- done:
+			case <-stop:
+				return
+			}
+
+			go func(i int) {
+				r := new(encodeResult)
+				r.err = fn(&r.buf, i)
+				done <- r
+			}(i)
+		}
+	}()
+
+	var err error
+	i := 0
+	for result := range pending {
+		r := <-result
 		if err != nil {
-			return err
+			continue
 		}
+
+		err = r.err
+		if err == nil {
+			_, err = r.buf.WriteTo(w)
+		}
+		if err != nil {
+			close(stop)
+		} else if done != nil {
+			done(i)
+		}
+		i++
 	}
 
-	return nil
+	return err
 }
 
 // writeReleaseHeader writes output file headers.
 // This targets release builds.
 func writeReleaseHeader(w io.Writer, c *Config) error {
 	var err error
-	if c.NoCompress {
-		if c.NoMemCopy {
-			err = header_uncompressed_nomemcopy(w)
-		} else {
-			err = header_uncompressed_memcopy(w)
+	if c.NoUnsafe {
+		err = header_nounsafe(w, c)
+		if err != nil {
+			return err
 		}
-	} else {
-		if c.NoMemCopy {
-			err = header_compressed_nomemcopy(w)
+	} else if c.NoMemCopy || c.extNoMemCopy() {
+		err = header_nomemcopy(w, c)
+		if err != nil {
+			return err
+		}
+	}
+	if c.encrypt() {
+		err = writeDecrypt(w, c)
+		if err != nil {
+			return err
+		}
+	}
+	if c.authenticate() {
+		err = header_verify(w, c)
+		if err != nil {
+			return err
+		}
+	}
+	if c.Checksums {
+		err = header_checksum(w)
+		if err != nil {
+			return err
+		}
+	}
+	if c.MaxLiteralSize > 0 {
+		err = header_join(w)
+		if err != nil {
+			return err
+		}
+	}
+	if c.compression() != CompressNone {
+		if c.stringData() {
+			err = header_compressed_nomemcopy(w, c)
 		} else {
-			err = header_compressed_memcopy(w)
+			err = header_compressed_memcopy(w, c)
+		}
+		if err != nil {
+			return err
+		}
+		err = writeDecompress(w, c)
+		if err != nil {
+			return err
+		}
+		if c.extNoMemCopy() {
+			err = header_read_string(w)
+			if err != nil {
+				return err
+			}
+		}
+		if c.CacheDecompressed {
+			err = header_cache(w, c)
+			if err != nil {
+				return err
+			}
 		}
 	}
+	err = header_release_common(w, c)
 	if err != nil {
 		return err
 	}
-	return header_release_common(w)
+	return header_release_lookup(w, c)
 }
 
 // writeReleaseAsset write a release entry for the given asset.
 // A release entry is a function which embeds and returns
 // the file's byte content.
 func writeReleaseAsset(w io.Writer, c *Config, asset *Asset) error {
-	fd, err := os.Open(asset.Path)
+	if asset.original != nil {
+		return writeDuplicateAsset(w, c, asset)
+	}
+
+	fd, err := openAsset(c, asset)
 	if err != nil {
 		return err
 	}
 
 	defer fd.Close()
 
-	if c.NoCompress {
-		if c.NoMemCopy {
-			err = uncompressed_nomemcopy(w, asset, fd)
+	// Text is embedded as a raw string, which needs an extra pass to
+	// find out whether the asset is valid UTF-8 a raw string can hold.
+	// Anything else is escaped in an interpreted string.
+	text := false
+	stringData := c.assetStringData(asset)
+	if !asset.Compressed && !stringData && !c.encrypt() {
+		text, err = rawText(fd)
+		if err != nil {
+			return err
+		}
+
+		_, err = fd.Seek(0, io.SeekStart)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Hash and count the contents as they are being encoded.
+	// The contents are streamed, so they are never held in memory.
+	h := sha256.New()
+	var size countWriter
+	r := io.TeeReader(fd, io.MultiWriter(h, &size))
+
+	if !asset.Compressed {
+		if stringData {
+			err = uncompressed_nomemcopy(w, c, asset, r)
 		} else {
-			err = uncompressed_memcopy(w, asset, fd)
+			err = uncompressed_memcopy(w, c, asset, r, text)
 		}
 	} else {
-		if c.NoMemCopy {
-			err = compressed_nomemcopy(w, asset, fd)
+		if stringData {
+			err = compressed_nomemcopy(w, c, asset, r)
 		} else {
-			err = compressed_memcopy(w, asset, fd)
+			err = compressed_memcopy(w, c, asset, r)
 		}
 	}
 	if err != nil {
 		return err
 	}
-	return asset_release_common(w, asset)
-}
-
-// sanitize prepares a valid UTF-8 string as a raw string constant.
-// Based on https://code.google.com/p/go/source/browse/godoc/static/makestatic.go?repo=tools
-func sanitize(b []byte) []byte {
-	// Replace ` with `+"`"+`
-	b = bytes.Replace(b, []byte("`"), []byte("`+\"`\"+`"), -1)
 
-	// Replace BOM with `+"\xEF\xBB\xBF"+`
-	// (A BOM is valid UTF-8 but not permitted in Go source files.
-	// I wouldn't bother handling this, but for some insane reason
-	// jquery.js has a BOM somewhere in the middle.)
-	return bytes.Replace(b, []byte("\xEF\xBB\xBF"), []byte("`+\"\\xEF\\xBB\\xBF\"+`"), -1)
-}
-
-func header_compressed_nomemcopy(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `import (
-	"bytes"
-	"compress/gzip"
-	"fmt"
-	"io"
-	"reflect"
-	"strings"
-	"unsafe"
-	"os"
-	"time"
-	"io/ioutil"
-	"path"
-	"path/filepath"
-	"grate"
-)
+	copy(asset.Digest[:], h.Sum(nil))
+	asset.Size = size.n
+	if text {
+		asset.EmbeddedSize = asset.Size
+	}
 
-func init() {
-	grate.Asset = Asset
-	grate.AssetDir = AssetDir
-	grate.AssetNames = AssetNames
+	return asset_release_common(w, c, asset)
 }
 
-func bindata_read(data, name string) ([]byte, error) {
-	var empty [0]byte
-	sx := (*reflect.StringHeader)(unsafe.Pointer(&data))
-	b := empty[:]
-	bx := (*reflect.SliceHeader)(unsafe.Pointer(&b))
-	bx.Data = sx.Data
-	bx.Len = len(data)
-	bx.Cap = bx.Len
-
-	gz, err := gzip.NewReader(bytes.NewBuffer(b))
+// openAsset opens the given asset for reading and decides
+// whether it is compressed.
+func openAsset(c *Config, asset *Asset) (io.ReadSeekCloser, error) {
+	fd, err := asset.open()
 	if err != nil {
-		return nil, fmt.Errorf("Read %%q: %%v", name, err)
+		return nil, err
 	}
 
-	var buf bytes.Buffer
-	_, err = io.Copy(&buf, gz)
-	gz.Close()
-
-	if err != nil {
-		return nil, fmt.Errorf("Read %%q: %%v", name, err)
+	opts := c.assetOptions(asset)
+	asset.Compressed = c.compression() != CompressNone && !opts.NoCompress
+	if asset.Compressed && !c.ForceCompress && !opts.ForceCompress {
+		asset.Compressed, err = worthCompressing(c, asset, fd)
+		if err != nil {
+			fd.Close()
+			return nil, err
+		}
 	}
 
-	return buf.Bytes(), nil
-}
-
-`)
-	return err
+	return fd, nil
 }
 
-func header_compressed_memcopy(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `import (
-	"bytes"
-	"compress/gzip"
-	"fmt"
-	"io"
-	"strings"
-	"os"
-	"time"
-	"io/ioutil"
-	"path"
-	"path/filepath"
-	"grate"
-)
-
-func init() {
-	grate.Asset = Asset
-	grate.AssetDir = AssetDir
-	grate.AssetNames = AssetNames
-}
-
-func bindata_read(data []byte, name string) ([]byte, error) {
-	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+// writeEncoded writes the data of the asset read from r to w,
+// compressing it with the configured codec and encrypting it if
+// enabled. The size of the data written is recorded in the asset.
+func writeEncoded(w io.Writer, c *Config, asset *Asset, r io.Reader) error {
+	out := &offsetWriter{Writer: w}
+	ew, err := newEncryptWriter(out, c)
 	if err != nil {
-		return nil, fmt.Errorf("Read %%q: %%v", name, err)
+		return err
 	}
 
-	var buf bytes.Buffer
-	_, err = io.Copy(&buf, gz)
-	gz.Close()
+	var enc io.WriteCloser
+	if c.parallel(asset) {
+		enc, err = newParallelGzip(ew, c)
+	} else {
+		enc, err = newEncoder(ew, c)
+	}
+	if err != nil {
+		return err
+	}
 
+	_, err = io.Copy(enc, r)
 	if err != nil {
-		return nil, fmt.Errorf("Read %%q: %%v", name, err)
+		return err
 	}
 
-	return buf.Bytes(), nil
-}
+	err = enc.Close()
+	if err != nil {
+		return err
+	}
 
-`)
+	err = ew.Close()
+	asset.EmbeddedSize = out.n
 	return err
 }
 
-func header_uncompressed_nomemcopy(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `import (
-	"fmt"
-	"reflect"
-	"strings"
-	"unsafe"
-	"os"
-	"time"
-	"io/ioutil"
-	"path"
-	"path/filepath"
-	"grate"
-)
-
-func init() {
-	grate.Asset = Asset
-	grate.AssetDir = AssetDir
-	grate.AssetNames = AssetNames
-}
+// sanitize prepares a valid UTF-8 string as a raw string constant.
+// Based on https://code.google.com/p/go/source/browse/godoc/static/makestatic.go?repo=tools
+func sanitize(b []byte) []byte {
+	// Replace ` with `+"`"+`
+	b = bytes.Replace(b, []byte("`"), []byte("`+\"`\"+`"), -1)
 
-func bindata_read(data, name string) ([]byte, error) {
-	var empty [0]byte
-	sx := (*reflect.StringHeader)(unsafe.Pointer(&data))
-	b := empty[:]
-	bx := (*reflect.SliceHeader)(unsafe.Pointer(&b))
-	bx.Data = sx.Data
-	bx.Len = len(data)
-	bx.Cap = bx.Len
-	return b, nil
+	// Replace BOM with `+"\xEF\xBB\xBF"+`
+	// (A BOM is valid UTF-8 but not permitted in Go source files.
+	// I wouldn't bother handling this, but for some insane reason
+	// jquery.js has a BOM somewhere in the middle.)
+	return bytes.Replace(b, []byte("\xEF\xBB\xBF"), []byte("`+\"\\xEF\\xBB\\xBF\"+`"), -1)
 }
 
-`)
-	return err
+func header_compressed_nomemcopy(w io.Writer, c *Config) error {
+	return executeCode(w, c, "read_string.tmpl", &codeData{
+		DataType: stringDataType(c),
+		Decrypt:  decryptStep(c),
+	})
 }
 
-func header_uncompressed_memcopy(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `import (
-	"fmt"
-	"strings"
-	"os"
-	"time"
-	"io/ioutil"
-	"path"
-	"path/filepath"
-	"grate"
-)
-
-func init() {
-	grate.Asset = Asset
-	grate.AssetDir = AssetDir
-	grate.AssetNames = AssetNames
-}
-`)
-	return err
+func header_compressed_memcopy(w io.Writer, c *Config) error {
+	return executeCode(w, c, "read_bytes.tmpl", &codeData{Encrypt: c.encrypt()})
 }
 
-func header_release_common(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `type asset struct {
-	bytes []byte
-	info  os.FileInfo
+// header_nomemcopy writes bindata_read_raw, which returns the
+// contents of a string constant without copying. It is used directly
+// for uncompressed assets.
+func header_nomemcopy(w io.Writer, c *Config) error {
+	return executeCode(w, c, "read_raw_unsafe.tmpl", &codeData{StringData: c.goAtLeast(20)})
 }
 
-type bindata_file_info struct {
-	name string
-	size int64
-	mode os.FileMode
-	modTime time.Time
+// header_nounsafe writes bindata_read_raw for NoUnsafe mode. Instead
+// of using unsafe, it converts the string once and keeps the result.
+func header_nounsafe(w io.Writer, c *Config) error {
+	return executeCode(w, c, "read_raw_string.tmpl", &codeData{})
 }
 
-func (fi bindata_file_info) Name() string {
-	return fi.name
-}
-func (fi bindata_file_info) Size() int64 {
-	return fi.size
-}
-func (fi bindata_file_info) Mode() os.FileMode {
-	return fi.mode
-}
-func (fi bindata_file_info) ModTime() time.Time {
-	return fi.modTime
-}
-func (fi bindata_file_info) IsDir() bool {
-	return false
-}
-func (fi bindata_file_info) Sys() interface{} {
-	return nil
+// stringDataType returns the type of the variables holding
+// asset data in string form.
+func stringDataType(c *Config) string {
+	if c.NoUnsafe {
+		return "*bindata_string"
+	}
+	return "string"
 }
 
-`)
-	return err
-}
+// writeStringData writes the variable holding the asset data in string
+// form. The data itself is written by fn, which receives a writer
+// escaping it for use in an interpreted string literal.
+func writeStringData(w io.Writer, c *Config, asset *Asset, fn func(w io.Writer) error) error {
+	open, close := `"`, `"`
+	if c.NoUnsafe {
+		open, close = `&bindata_string{data: "`, `"}`
+	}
 
-func compressed_nomemcopy(w io.Writer, asset *Asset, r io.Reader) error {
-	_, err := fmt.Fprintf(w, `var _%s = "`, asset.Func)
+	_, err := fmt.Fprintf(w, "var _%s = %s", asset.Func, open)
 	if err != nil {
 		return err
 	}
 
-	gz := gzip.NewWriter(&StringWriter{Writer: w})
-	_, err = io.Copy(gz, r)
-	gz.Close()
-
+	sw := newStringWriter(w, c)
+	sw.parts = newLiteralParts(w, c, asset, open, close)
+	err = fn(sw)
 	if err != nil {
 		return err
 	}
 
-	_, err = fmt.Fprintf(w, `"
+	_, err = fmt.Fprintf(w, "%s\n\n", close)
+	return err
+}
 
-func %s_bytes() ([]byte, error) {
-	return bindata_read(
-		_%s,
-		%q,
-	)
+func header_release_common(w io.Writer, c *Config) error {
+	return executeCode(w, c, "asset_types.tmpl", &codeData{Any: c.anyType()})
 }
 
-`, asset.Func, asset.Func, asset.Name)
-	return err
+// header_release_lookup writes the accessors for the table of contents.
+// In release builds, these go through the tables generated from the
+// input files, in the way selected by Config.Lookup.
+func header_release_lookup(w io.Writer, c *Config) error {
+	return executeCode(w, c, "lookup.tmpl", &codeData{Lookup: c.lookup().String()})
 }
 
-func compressed_memcopy(w io.Writer, asset *Asset, r io.Reader) error {
-	_, err := fmt.Fprintf(w, `var _%s = []byte("`, asset.Func)
+func compressed_nomemcopy(w io.Writer, c *Config, asset *Asset, r io.Reader) error {
+	err := writeStringData(w, c, asset, func(sw io.Writer) error {
+		return writeEncoded(sw, c, asset, r)
+	})
 	if err != nil {
 		return err
 	}
 
-	gz := gzip.NewWriter(&StringWriter{Writer: w})
-	_, err = io.Copy(gz, r)
-	gz.Close()
+	return writeCompressedBytes(w, c, asset)
+}
 
+func compressed_memcopy(w io.Writer, c *Config, asset *Asset, r io.Reader) error {
+	_, err := fmt.Fprintf(w, `var _%s = []byte("`, asset.Func)
+	if err != nil {
+		return err
+	}
+
+	sw := newStringWriter(w, c)
+	sw.parts = newLiteralParts(w, c, asset, `[]byte("`, `")`)
+	err = writeEncoded(sw, c, asset, r)
 	if err != nil {
 		return err
 	}
 
-	_, err = fmt.Fprintf(w, `")
+	_, err = fmt.Fprintf(w, "\")\n\n")
+	if err != nil {
+		return err
+	}
 
-func %s_bytes() ([]byte, error) {
-	return bindata_read(
-		_%s,
-		%q,
-	)
+	return writeCompressedBytes(w, c, asset)
 }
 
-`, asset.Func, asset.Func, asset.Name)
-	return err
+func uncompressed_nomemcopy(w io.Writer, c *Config, asset *Asset, r io.Reader) error {
+	err := writeStringData(w, c, asset, func(sw io.Writer) error {
+		return writeRaw(sw, c, asset, r)
+	})
+	if err != nil {
+		return err
+	}
+
+	read := "bindata_read_raw"
+	if c.encrypt() {
+		read = "bindata_decrypt_raw"
+	}
+
+	return executeCode(w, c, "bytes_string.tmpl", &codeData{
+		Func: asset.Func,
+		Name: asset.Name,
+		Data: dataExpr(c, asset),
+		Read: read,
+	})
 }
 
-func uncompressed_nomemcopy(w io.Writer, asset *Asset, r io.Reader) error {
-	_, err := fmt.Fprintf(w, `var _%s = "`, asset.Func)
+// uncompressed_memcopy writes the asset as a byte slice. Text is
+// written as a raw string, so it remains readable in the output.
+func uncompressed_memcopy(w io.Writer, c *Config, asset *Asset, r io.Reader, text bool) error {
+	quote := `"`
+	if text {
+		quote = "`"
+	}
+
+	_, err := fmt.Fprintf(w, `var _%s = []byte(%s`, asset.Func, quote)
 	if err != nil {
 		return err
 	}
 
-	_, err = io.Copy(&StringWriter{Writer: w}, r)
+	parts := newLiteralParts(w, c, asset, "[]byte("+quote, quote+")")
+	if text {
+		err = writeRawString(w, r, c.lineLength(), parts)
+	} else {
+		sw := newStringWriter(w, c)
+		sw.parts = parts
+		err = writeRaw(sw, c, asset, r)
+	}
 	if err != nil {
 		return err
 	}
 
-	_, err = fmt.Fprintf(w, `"
+	read := fmt.Sprintf("%s, nil", dataExpr(c, asset))
+	if c.encrypt() {
+		read = fmt.Sprintf("bindata_decrypt(%s, %q)", dataExpr(c, asset), asset.Name)
+	}
 
-func %s_bytes() ([]byte, error) {
-	return bindata_read(
-		_%s,
-		%q,
-	)
+	return executeCode(w, c, "bytes_slice.tmpl", &codeData{
+		Func:  asset.Func,
+		Quote: quote,
+		Read:  read,
+	})
+}
+
+// sharedData reports whether the data of the asset is kept by the
+// generated code, rather than allocated when it is read, so Asset must
+// copy it. This is the case for uncompressed data, unless it is
+// decrypted, and for cached data. Duplicates may be written before
+// their original is encoded, so they are assumed to be shared.
+func sharedData(c *Config, asset *Asset) bool {
+	cached := c.CacheDecompressed && c.compression() != CompressNone
+	switch {
+	case asset.original != nil:
+		return cached || !c.encrypt()
+	case asset.Compressed:
+		return cached
+	}
+	return !c.encrypt()
 }
 
-`, asset.Func, asset.Func, asset.Name)
-	return err
+// textData returns an expression for the data of the asset as a string
+// constant, or nothing if it is not kept in one. Duplicates may be
+// written before their original is encoded, so their data is only
+// known to be a single constant without compression or literal limit.
+func textData(c *Config, asset *Asset) string {
+	if c.encrypt() {
+		return ""
+	}
+
+	if original := asset.original; original != nil {
+		if c.compression() != CompressNone || c.MaxLiteralSize > 0 || !c.assetStringData(original) {
+			return ""
+		}
+	} else if asset.Compressed || asset.parts > 1 || !c.assetStringData(asset) {
+		return ""
+	}
+
+	if c.NoUnsafe {
+		return dataExpr(c, asset) + ".data"
+	}
+	return dataExpr(c, asset)
 }
 
-func uncompressed_memcopy(w io.Writer, asset *Asset, r io.Reader) error {
-	_, err := fmt.Fprintf(w, `var _%s = []byte(`, asset.Func)
+func asset_release_common(w io.Writer, c *Config, asset *Asset) error {
+	info, err := fileInfo(c, asset)
 	if err != nil {
 		return err
 	}
 
-	b, err := ioutil.ReadAll(r)
+	fields := ""
+	if sharedData(c, asset) {
+		fields = ", shared: true"
+	}
+	if text := textData(c, asset); len(text) > 0 {
+		fields += ", text: " + text
+	}
+
+	verify, err := verifyStep(c, asset)
 	if err != nil {
 		return err
 	}
-	if utf8.Valid(b) {
-		fmt.Fprintf(w, "`%s`", sanitize(b))
-	} else {
-		fmt.Fprintf(w, "%q", b)
-	}
 
-	_, err = fmt.Fprintf(w, `)
+	checksum, err := checksumStep(c, asset)
+	if err != nil {
+		return err
+	}
 
-func %s_bytes() ([]byte, error) {
-	return _%s, nil
-}
+	doc, err := assetDoc(c, asset)
+	if err != nil {
+		return err
+	}
 
-`, asset.Func, asset.Func)
-	return err
+	return executeCode(w, c, "asset.tmpl", &codeData{
+		Func:     asset.Func,
+		Doc:      doc,
+		Name:     asset.Name,
+		Checksum: checksum,
+		Verify:   verify,
+		Info:     info,
+		Fields:   fields,
+	})
 }
 
-func asset_release_common(w io.Writer, asset *Asset) error {
-	fi, err := os.Stat(asset.Path)
+// fileInfo returns a bindata_file_info literal for the given asset.
+func fileInfo(c *Config, asset *Asset) (string, error) {
+	size, mode, modTime, err := infoValues(c, asset)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	_, err = fmt.Fprintf(w, `func %s() (*asset, error) {
-	bytes, err := %s_bytes()
+	return fmt.Sprintf("bindata_file_info{name: %q, size: %d, mode: os.FileMode(%d), modTime: time.Unix(%d, 0)}",
+		asset.Name, size, uint32(mode), modTime), nil
+}
+
+// infoValues returns the size, mode and modification time, in seconds,
+// recorded in the file info of the given asset.
+func infoValues(c *Config, asset *Asset) (int64, os.FileMode, int64, error) {
+	fi, err := asset.stat()
 	if err != nil {
-		return nil, err
+		return 0, 0, 0, err
 	}
 
-	info := bindata_file_info{name: %q, size: %d, mode: os.FileMode(%d), modTime: time.Unix(%d, 0)}
-	a := &asset{bytes: bytes, info:  info}
-	return a, nil
-}
+	modTime := fi.ModTime().Unix()
+	if c.ModTime != 0 {
+		modTime = c.ModTime
+	}
 
-`, asset.Func, asset.Func, asset.Name, fi.Size(), uint32(fi.Mode()), fi.ModTime().Unix())
-	return err
+	return fi.Size(), fi.Mode(), modTime, nil
 }
@@ -0,0 +1,152 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// isRemote reports whether the given input path is an HTTP(S) URL.
+func isRemote(name string) bool {
+	return strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://")
+}
+
+// validateRemote ensures a remote input names a file,
+// and comes with a valid checksum.
+func validateRemote(c *Config, input *InputConfig) error {
+	if c.Debug {
+		return fmt.Errorf("Remote input '%s' cannot be used in debug builds", input.Path)
+	}
+
+	u, err := url.Parse(input.Path)
+	if err != nil {
+		return fmt.Errorf("Invalid remote input '%s': %v", input.Path, err)
+	}
+
+	if base := path.Base(u.Path); base == "/" || base == "." {
+		return fmt.Errorf("Remote input '%s' does not name a file", input.Path)
+	}
+
+	sum, err := hex.DecodeString(input.SHA256)
+	if err != nil || len(sum) != sha256.Size {
+		return fmt.Errorf("Remote input '%s' requires a hex encoded SHA-256 checksum", input.Path)
+	}
+
+	return nil
+}
+
+// findRemoteFiles downloads the file of the given remote input into
+// a temporary directory, which is returned and must be removed by the
+// caller. The asset is named after the last element of the URL path.
+// Archives are unpacked, and their entries are named as if the archive
+// were a directory of the same name.
+func findRemoteFiles(c *Config, input *InputConfig, toc *[]Asset, knownFuncs map[string]int) (string, error) {
+	dir, file, err := download(input.Path, input.SHA256)
+	if err != nil {
+		return dir, err
+	}
+
+	base := filepath.Base(file)
+	found := []Asset{{Path: file, Name: base, source: input.Path}}
+
+	if isArchive(base) {
+		var archiveDir string
+		archiveDir, found, err = archiveFiles(c, input, file)
+		os.RemoveAll(dir)
+		dir = archiveDir
+		if err != nil {
+			return dir, err
+		}
+
+		for i := range found {
+			found[i].Name = path.Join(base, found[i].Name)
+			found[i].source = input.Path + "/" + found[i].source
+		}
+	}
+
+	for _, asset := range found {
+		asset.Func = safeFunctionName(asset.Name, knownFuncs)
+		*toc = append(*toc, asset)
+	}
+
+	return dir, nil
+}
+
+// download fetches the given URL into a new temporary directory, and
+// verifies its contents against the hex encoded SHA-256 checksum. The
+// modification time of the file is taken from the Last-Modified header,
+// so it does not change between runs.
+func download(rawurl, checksum string) (string, string, error) {
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return "", "", err
+	}
+
+	dir, err := ioutil.TempDir("", "bindata-remote")
+	if err != nil {
+		return "", "", err
+	}
+
+	file := filepath.Join(dir, path.Base(u.Path))
+	err = fetch(u.String(), file, checksum)
+	if err != nil {
+		return dir, "", fmt.Errorf("Failed to download %s: %v", rawurl, err)
+	}
+
+	return dir, file, nil
+}
+
+// fetch writes the contents at the given URL to the named file.
+func fetch(rawurl, file, checksum string) error {
+	resp, err := http.Get(rawurl)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s", resp.Status)
+	}
+
+	fd, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(fd, hash), resp.Body)
+	if err != nil {
+		fd.Close()
+		return err
+	}
+
+	err = fd.Close()
+	if err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(hash.Sum(nil)); sum != strings.ToLower(checksum) {
+		return fmt.Errorf("checksum mismatch, got %s, want %s", sum, checksum)
+	}
+
+	modTime := time.Unix(0, 0)
+	if lm, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		modTime = lm
+	}
+
+	return os.Chtimes(file, time.Now(), modTime)
+}
@@ -9,54 +9,67 @@ import (
 	"io"
 )
 
-func writeRestore(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `
-// Restore an asset under the given directory
+// writeRestore writes the procedures restoring assets to disk.
+func writeRestore(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// RestoreAsset restores an asset under the given directory.
+// The file is written with the recorded mode and modification time.
 func RestoreAsset(dir, name string) error {
-        data, err := Asset(name)
-        if err != nil {
-                return err
-        }
-        info, err := AssetInfo(name)
-        if err != nil {
-                return err
-        }
-        err = os.MkdirAll(_filePath(dir, path.Dir(name)), os.FileMode(0755))
-        if err != nil {
-                return err
-        }
-        err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
-        if err != nil {
-                return err
-        }
-        err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
-        if err != nil {
-                return err
-        }
-        return nil
+	data, err := Asset(%s)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(%s)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, path.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	file := _filePath(dir, name)
+	err = %s(file, data, info.Mode())
+	if err != nil {
+		return err
+	}
+	// WriteFile only applies the mode to newly created files.
+	err = os.Chmod(file, info.Mode())
+	if err != nil {
+		return err
+	}
+	return os.Chtimes(file, info.ModTime(), info.ModTime())
 }
 
-// Restore assets under the given directory recursively
-func RestoreAssets(dir, name string) error {
-        children, err := AssetDir(name)
-        if err != nil { // File
-                return RestoreAsset(dir, name)
-        } else { // Dir
-                for _, child := range children {
-                        err = RestoreAssets(dir, path.Join(name, child))
-                        if err != nil {
-                                return err
-                        }
-                }
-        }
-        return nil
+// RestoreAssets restores the asset or directory of assets with the
+// given name under the given directory, recursing through AssetDir.
+// An empty root restores all assets. Directories are created even if
+// they are empty, and are given their recorded mode once restored.
+func RestoreAssets(dir, root string) error {
+	children, err := AssetDir(root)
+	if err != nil { // File
+		return RestoreAsset(dir, root)
+	}
+	// Dir
+	err = os.MkdirAll(_filePath(dir, root), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		err = RestoreAssets(dir, path.Join(root, child))
+		if err != nil {
+			return err
+		}
+	}
+	if mode, ok := _bindata_dirs[bindata_clean(root)]; ok {
+		return os.Chmod(_filePath(dir, root), mode)
+	}
+	return nil
 }
 
 func _filePath(dir, name string) string {
-        cannonicalName := strings.Replace(name, "\\", "/", -1)
-        return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+	cannonicalName := bindata_clean(name)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
 }
 
-`)
+`, c.nameArg("name"), c.nameArg("name"), c.ioutil("WriteFile"))
 	return err
 }
@@ -0,0 +1,86 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+)
+
+// Rewrite replaces the parts of asset names matching Pattern by
+// Replace, which may refer to submatches as in $1 or ${name}, like
+// regexp.Regexp.ReplaceAllString.
+type Rewrite struct {
+	Pattern *regexp.Regexp
+	Replace string
+}
+
+// rewriteName applies the rewrites to the given asset name in turn.
+// The result is cleaned by assetName, so rewrites may leave a leading
+// slash, and names are converted to forward slashes without rewrites.
+func (c *Config) rewriteName(name string) string {
+	for _, r := range c.Rewrites {
+		name = r.Pattern.ReplaceAllString(name, r.Replace)
+	}
+
+	return assetName(name)
+}
+
+// rewriteNames applies the rewrites to the names of the given assets
+// and directories, and returns the directories which keep a name.
+// Assets with a new name are given a new function name as well.
+func rewriteNames(c *Config, toc, dirs []Asset, knownFuncs map[string]int) ([]Asset, error) {
+	for i := range toc {
+		name := c.rewriteName(toc[i].Name)
+		if len(name) == 0 {
+			return nil, fmt.Errorf("Rewrites leave no name for asset %s", toc[i].Name)
+		}
+
+		if name != toc[i].Name {
+			toc[i].Name = name
+			toc[i].Func = safeFunctionName(name, knownFuncs)
+		}
+	}
+
+	kept := dirs[:0]
+	for _, dir := range dirs {
+		dir.Name = c.rewriteName(dir.Name)
+		if len(dir.Name) > 0 {
+			kept = append(kept, dir)
+		}
+	}
+
+	return kept, nil
+}
+
+// writeDebugRewrites writes the rewrites and bindata_rewrite, which
+// applies them to the names of the assets found at runtime.
+func writeDebugRewrites(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, "var _bindata_rewrites = []struct {\n\tpattern *regexp.Regexp\n\treplace string\n}{\n")
+	if err != nil {
+		return err
+	}
+
+	for _, r := range c.Rewrites {
+		_, err = fmt.Fprintf(w, "\t{regexp.MustCompile(%q), %q},\n", r.Pattern.String(), r.Replace)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, `}
+
+// bindata_rewrite applies the rewrites to the given asset name.
+func bindata_rewrite(name string) string {
+	for _, r := range _bindata_rewrites {
+		name = r.pattern.ReplaceAllString(name, r.replace)
+	}
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
+
+`)
+	return err
+}
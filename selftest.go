@@ -0,0 +1,189 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// selfTestFile is the name of the test file written
+// into the output directory in split mode.
+const selfTestFile = "bindata_test.go"
+
+// selfTestPath returns the name of the test file written for the
+// output: the output file with a _test suffix, or bindata_test.go
+// in the output directory in split mode.
+func (c *Config) selfTestPath() string {
+	if c.SplitOutput {
+		return filepath.Join(c.splitDir(), selfTestFile)
+	}
+	return strings.TrimSuffix(c.Output, ".go") + "_test.go"
+}
+
+// writeSelfTest writes a test file next to the output, which checks
+// the generated code with go test.
+func writeSelfTest(c *Config) error {
+	return writeSource(c, c.selfTestPath(), func(w io.Writer) error {
+		err := writeHeader(w, c)
+		if err != nil {
+			return err
+		}
+
+		list := []string{"bytes", "path", "sync", "testing"}
+		if c.encrypt() {
+			list = append(list, "os")
+		}
+
+		err = writeImportList(w, list)
+		if err != nil {
+			return err
+		}
+
+		return writeSelfTestFuncs(w, c)
+	})
+}
+
+// writeSelfTestFuncs writes the tests reading all assets, and comparing
+// the tree of AssetDir with the table of contents, along with the one
+// reading the assets concurrently.
+func writeSelfTestFuncs(w io.Writer, c *Config) error {
+	// Encrypted assets can only be read with the key.
+	var skip string
+	if c.encrypt() {
+		skip = fmt.Sprintf(`	if os.Getenv(%q) == "" {
+		t.Skip("The key in %s is required to read the assets")
+	}
+
+`, c.EncryptKeyEnv, c.EncryptKeyEnv)
+	}
+
+	_, err := fmt.Fprintf(w, `// TestBindataAssets checks that every asset listed by AssetNames
+// can be read, and that its size matches the recorded file info.
+func TestBindataAssets(t *testing.T) {
+%s	for _, name := range AssetNames() {
+		data, err := Asset(%s)
+		if err != nil {
+			t.Errorf("Asset(%%q): %%v", name, err)
+			continue
+		}
+
+		info, err := AssetInfo(%s)
+		if err != nil {
+			t.Errorf("AssetInfo(%%q): %%v", name, err)
+			continue
+		}
+
+		if info.Size() != int64(len(data)) {
+			t.Errorf("Asset(%%q) returned %%d bytes, AssetInfo reports %%d", name, len(data), info.Size())
+		}
+	}
+}
+
+// TestBindataAssetDir checks that the tree walked with
+// AssetDir holds exactly the assets listed by AssetNames.
+func TestBindataAssetDir(t *testing.T) {
+	found := make(map[string]bool)
+
+	var walk func(dir string)
+	walk = func(dir string) {
+		children, err := AssetDir(dir)
+		if err != nil {
+			found[dir] = true
+			return
+		}
+
+		for _, child := range children {
+			walk(path.Join(dir, child))
+		}
+	}
+	walk("")
+
+	for _, name := range AssetNames() {
+		if !found[name] {
+			t.Errorf("Asset %%s is missing from AssetDir", name)
+		}
+		delete(found, name)
+	}
+
+	for name := range found {
+		t.Errorf("AssetDir lists %%s, which is missing from AssetNames", name)
+	}
+}
+`, skip, c.nameArg("name"), c.nameArg("name"))
+	if err != nil {
+		return err
+	}
+
+	return writeConcurrentTest(w, c, skip)
+}
+
+// writeConcurrentTest writes a test reading all assets from several
+// goroutines at once, flushing the cache of decompressed data in between
+// if there is one. Run with the race detector, it finds unguarded state
+// in the generated code.
+func writeConcurrentTest(w io.Writer, c *Config, skip string) error {
+	var flush string
+	if c.CacheDecompressed {
+		flush = "\n\t\t\t\tif i%2 == 0 {\n\t\t\t\t\tFlushAssetCache()\n\t\t\t\t}\n"
+	}
+
+	_, err := fmt.Fprintf(w, `
+// TestBindataConcurrent reads the assets from several goroutines at once.
+// Run it with go test -race to check the generated code for data races.
+func TestBindataConcurrent(t *testing.T) {
+%s	want := make(map[string][]byte)
+	for _, name := range AssetNames() {
+		data, err := Asset(%s)
+		if err != nil {
+			t.Fatalf("Asset(%%q): %%v", name, err)
+		}
+		want[name] = data
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for name, data := range want {
+				got, err := Asset(%s)
+				if err != nil {
+					t.Errorf("Asset(%%q): %%v", name, err)
+					continue
+				}
+				if !bytes.Equal(got, data) {
+					t.Errorf("Asset(%%q) returned other data when read concurrently", name)
+				}
+
+				// The data belongs to the caller, so altering
+				// it must not affect other goroutines.
+				for j := range got {
+					got[j] = 0
+				}
+
+				_, err = AssetInfo(%s)
+				if err != nil {
+					t.Errorf("AssetInfo(%%q): %%v", name, err)
+				}
+
+				dir := path.Dir(name)
+				if dir == "." {
+					dir = ""
+				}
+				_, err = AssetDir(dir)
+				if err != nil {
+					t.Errorf("AssetDir(%%q): %%v", dir, err)
+				}
+%s			}
+		}(i)
+	}
+	wg.Wait()
+}
+`, skip, c.nameArg("name"), c.nameArg("name"), c.nameArg("name"), flush)
+	return err
+}
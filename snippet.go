@@ -0,0 +1,167 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Snippet is code inserted into the generated file, as configured by
+// Config.Prologue and Config.Epilogue. Code holds Go declarations. With
+// Template set, it is executed as a text/template first, with the
+// variables of Config.HeaderTemplate, .Assets holding the names of all
+// assets, and .Debug telling debug builds apart. Imports lists the
+// packages the code refers to, like "net/http", or "tt text/template"
+// to import a package under another name. They are merged with the
+// imports of the generated code.
+type Snippet struct {
+	Code     string
+	Imports  []string
+	Template bool
+}
+
+// ParseSnippet returns a snippet holding the given code, whose import
+// declarations, if it starts with any, are moved to the Imports of the
+// snippet. Files holding snippets can import packages this way.
+func ParseSnippet(code string) (Snippet, error) {
+	s := Snippet{Code: code}
+
+	// Only the imports are parsed, so the rest may be a template.
+	src := "package snippet\n" + code
+	f, err := parser.ParseFile(token.NewFileSet(), "snippet", src, parser.ImportsOnly)
+	if err != nil {
+		return s, err
+	}
+	if len(f.Decls) == 0 {
+		return s, nil
+	}
+
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return s, err
+		}
+		if spec.Name != nil {
+			path = spec.Name.Name + " " + path
+		}
+		s.Imports = append(s.Imports, path)
+	}
+
+	// Positions count from one, and include the added package clause.
+	end := int(f.Decls[len(f.Decls)-1].End()) - 1 - len("package snippet\n")
+	s.Code = strings.TrimLeft(code[end:], "\r\n")
+	return s, nil
+}
+
+// validateSnippets ensures the imports of the snippets are given,
+// and their templates parse.
+func validateSnippets(c *Config) error {
+	for _, s := range append(c.Prologue, c.Epilogue...) {
+		for _, imp := range s.Imports {
+			if len(strings.TrimSpace(imp)) == 0 || strings.ContainsAny(imp, "\"`") {
+				return fmt.Errorf("Invalid snippet import %q", imp)
+			}
+		}
+
+		if s.Template {
+			_, err := parseSnippet(&s)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseSnippet parses the template of a snippet.
+func parseSnippet(s *Snippet) (*template.Template, error) {
+	t, err := template.New("snippet").Option("missingkey=error").Parse(s.Code)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid snippet template: %v", err)
+	}
+	return t, nil
+}
+
+// prologue returns the snippets written before the assets, starting
+// with the init function hooking release builds up with grate.
+func (c *Config) prologue() []Snippet {
+	if c.Debug || !c.hasGrateInit() {
+		return c.Prologue
+	}
+
+	return append([]Snippet{c.grateSnippet()}, c.Prologue...)
+}
+
+// snippetImports returns the imports of all snippets.
+func (c *Config) snippetImports() []string {
+	var list []string
+	for _, s := range append(c.prologue(), c.Epilogue...) {
+		list = append(list, s.Imports...)
+	}
+	return list
+}
+
+// writeSnippets writes the given snippets, separated by blank lines.
+func writeSnippets(w io.Writer, c *Config, toc []Asset, list []Snippet) error {
+	for i := range list {
+		s := &list[i]
+		code := s.Code
+
+		if s.Template {
+			t, err := parseSnippet(s)
+			if err != nil {
+				return err
+			}
+
+			var buf bytes.Buffer
+			err = t.Execute(&buf, c.templateData(toc))
+			if err != nil {
+				return fmt.Errorf("Invalid snippet template: %v", err)
+			}
+			code = buf.String()
+		}
+
+		code = strings.TrimSpace(code)
+		if len(code) == 0 {
+			continue
+		}
+
+		_, err := fmt.Fprintf(w, "%s\n\n", code)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// grateSnippet returns an init function, which assigns the generated
+// functions to the variables of the grate package, as configured by
+// GrateHooks.
+func (c *Config) grateSnippet() Snippet {
+	funcs := make([]string, 0, len(c.GrateHooks))
+	for fn := range c.GrateHooks {
+		funcs = append(funcs, fn)
+	}
+	sort.Strings(funcs)
+
+	var b strings.Builder
+	b.WriteString("func init() {\n")
+	for _, fn := range funcs {
+		fmt.Fprintf(&b, "\t%s.%s = %s\n", c.gratePackage(), c.GrateHooks[fn], c.grateHook(fn))
+	}
+	b.WriteString("}")
+
+	return Snippet{Code: b.String(), Imports: []string{c.GrateImport}}
+}
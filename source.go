@@ -0,0 +1,149 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"time"
+)
+
+// AssetsFromFS returns the assets for all files below root in the given
+// file system, for use with TranslateTo. Their names are relative to root.
+func AssetsFromFS(fsys fs.FS, root string) ([]Asset, error) {
+	var toc []Asset
+	knownFuncs := make(map[string]int)
+
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel := name
+		if root != "." {
+			rel = name[len(root)+1:]
+		}
+
+		toc = append(toc, Asset{
+			Path: name,
+			Name: rel,
+			Func: safeFunctionName(rel, knownFuncs),
+			fsys: fsys,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toc, nil
+}
+
+// AssetsFromMap returns assets holding the given contents by name, for
+// use with TranslateTo. They have mode 0644 and a zero Unix timestamp,
+// unless Config.ModTime is set.
+func AssetsFromMap(files map[string][]byte) []Asset {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	toc := make([]Asset, len(names))
+	knownFuncs := make(map[string]int)
+	for i, name := range names {
+		toc[i] = Asset{
+			Path: name,
+			Name: name,
+			Func: safeFunctionName(name, knownFuncs),
+			fsys: memFS(files),
+		}
+	}
+
+	return toc
+}
+
+// open opens the contents of the asset for reading.
+// Assets from file systems, which do not support
+// seeking, are read into memory.
+func (a *Asset) open() (io.ReadSeekCloser, error) {
+	if a.fsys == nil {
+		return os.Open(a.Path)
+	}
+
+	fd, err := a.fsys.Open(a.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	if rs, ok := fd.(io.ReadSeekCloser); ok {
+		return rs, nil
+	}
+
+	defer fd.Close()
+
+	data, err := ioutil.ReadAll(fd)
+	if err != nil {
+		return nil, err
+	}
+
+	return memFile{Reader: bytes.NewReader(data)}, nil
+}
+
+// stat returns the file info of the asset.
+func (a *Asset) stat() (os.FileInfo, error) {
+	if a.fsys == nil {
+		return os.Stat(a.Path)
+	}
+	return fs.Stat(a.fsys, a.Path)
+}
+
+// memFS is a file system holding the contents of files by name.
+type memFS map[string][]byte
+
+func (m memFS) Open(name string) (fs.File, error) {
+	data, ok := m[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	info := memInfo{name: path.Base(name), size: int64(len(data)), mode: 0644, modTime: time.Unix(0, 0)}
+	return memFile{Reader: bytes.NewReader(data), info: info}, nil
+}
+
+// memFile is an open file of a memFS.
+type memFile struct {
+	*bytes.Reader
+	info fs.FileInfo
+}
+
+func (f memFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f memFile) Close() error {
+	return nil
+}
+
+// memInfo describes a file of a memFS.
+type memInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (fi memInfo) Name() string       { return fi.name }
+func (fi memInfo) Size() int64        { return fi.size }
+func (fi memInfo) Mode() fs.FileMode  { return fi.mode }
+func (fi memInfo) ModTime() time.Time { return fi.modTime }
+func (fi memInfo) IsDir() bool        { return false }
+func (fi memInfo) Sys() interface{}   { return nil }
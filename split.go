@@ -0,0 +1,137 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// splitTOCFile is the name of the file holding the table of contents
+// and all shared code in split mode.
+const splitTOCFile = "bindata_toc.go"
+
+// writeSplit writes one file per asset, plus a shared file holding
+// the table of contents, into the output directory. Files from
+// earlier runs, which no longer belong to an asset, are removed.
+func writeSplit(c *Config, toc, dirs []Asset) error {
+	dir := c.splitDir()
+	keep := make(map[string]bool)
+
+	for i := range toc {
+		asset := &toc[i]
+		name := splitFileName(asset)
+		keep[name] = true
+
+		// Kept files hold their own data, or that of other kept ones.
+		if asset.kept {
+			asset.original = nil
+			c.assetWritten(toc, i)
+			continue
+		}
+
+		err := writeSource(c, filepath.Join(dir, name), func(w io.Writer) error {
+			err := writeHeader(w, c)
+			if err != nil {
+				return err
+			}
+
+			if c.Debug {
+				return writeDebugAsset(w, asset)
+			}
+
+			err = writeImportList(w, []string{"os", "time"})
+			if err != nil {
+				return err
+			}
+
+			return writeReleaseAsset(w, c, asset)
+		})
+		if err != nil {
+			return err
+		}
+		c.assetWritten(toc, i)
+	}
+
+	copyDuplicates(toc)
+
+	err := writeSource(c, filepath.Join(dir, splitTOCFile), func(w io.Writer) error {
+		err := writeHeader(w, c)
+		if err != nil {
+			return err
+		}
+
+		err = writeImports(w, c)
+		if err != nil {
+			return err
+		}
+
+		err = writeSnippets(w, c, toc, c.prologue())
+		if err != nil {
+			return err
+		}
+
+		if c.Debug {
+			err = writeDebugHeader(w, c)
+		} else {
+			err = writeReleaseHeader(w, c)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return writeAPI(w, c, toc, dirs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return removeStale(dir, keep)
+}
+
+// splitFileName returns the name of the file holding the given asset.
+// The trailing "_asset" keeps names like "foo_test" or "foo_linux"
+// from being interpreted by the go tool.
+func splitFileName(asset *Asset) string {
+	return "bindata_" + asset.Func + "_asset.go"
+}
+
+// removeStale removes asset files written by an earlier run, which
+// are not part of the current output. Only files carrying the marker
+// of generated code are considered.
+func removeStale(dir string, keep map[string]bool) error {
+	list, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, fi := range list {
+		name := fi.Name()
+		if keep[name] || fi.IsDir() ||
+			!strings.HasPrefix(name, "bindata_") ||
+			!strings.HasSuffix(name, "_asset.go") {
+			continue
+		}
+
+		file := filepath.Join(dir, name)
+		generated, err := hasGeneratedMarker(file)
+		if err != nil {
+			return err
+		}
+
+		if generated {
+			err = os.Remove(file)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,168 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Stats summarizes the assets written by TranslateStats.
+type Stats struct {
+	Assets     int   // Number of assets.
+	Duplicates int   // Number of assets sharing the data of another one.
+	Saved      int64 // Size of the data not embedded for duplicates.
+	Collisions int   // Number of assets dropped or renamed, as their name was taken.
+
+	Size     int64         // Total size of the asset contents.
+	Embedded int64         // Total size of the embedded data, not counting duplicates.
+	Duration time.Duration // Time taken to generate the code.
+
+	// Files holds the statistics of every asset, sorted by name.
+	// Sizes are only known for release builds.
+	Files []AssetStats
+
+	// Warnings holds the size limits which were exceeded,
+	// if Config.SizeLimitWarn is set.
+	Warnings []string
+
+	// Failures holds the files and directories left out as they
+	// could not be read, if Config.ContinueOnError is set.
+	Failures AssetErrors
+
+	// Bundles holds the statistics of each bundle by name, if
+	// Config.Bundles is set. Duration covers all of them.
+	Bundles map[string]*Stats
+}
+
+// AssetStats describes the data embedded for a single asset.
+type AssetStats struct {
+	Name         string
+	Size         int64  // Size of the asset contents.
+	EmbeddedSize int64  // Size of the data embedded for them.
+	Compressed   bool   // Whether the data is compressed.
+	DuplicateOf  string // Name of the asset whose data is shared, if any.
+}
+
+// Ratio returns the embedded size relative to the size of the asset.
+// It is 1 for empty assets.
+func (s *AssetStats) Ratio() float64 {
+	if s.Size == 0 {
+		return 1
+	}
+	return float64(s.EmbeddedSize) / float64(s.Size)
+}
+
+// newStats counts the assets, duplicates and name collisions
+// in the given table of contents, and sums up their sizes.
+func newStats(toc []Asset) *Stats {
+	stats := &Stats{Assets: len(toc), Files: make([]AssetStats, len(toc))}
+	for i := range toc {
+		asset := &toc[i]
+		if asset.original != nil {
+			stats.Duplicates++
+			stats.Saved += asset.EmbeddedSize
+		} else {
+			stats.Embedded += asset.EmbeddedSize
+		}
+		stats.Size += asset.Size
+		stats.Collisions += asset.collisions
+		stats.Files[i] = newAssetStats(asset)
+	}
+	return stats
+}
+
+// newAssetStats returns the statistics of a single asset.
+func newAssetStats(asset *Asset) AssetStats {
+	return AssetStats{
+		Name:         asset.Name,
+		Size:         asset.Size,
+		EmbeddedSize: asset.EmbeddedSize,
+		Compressed:   asset.Compressed,
+		DuplicateOf:  asset.DuplicateOf(),
+	}
+}
+
+// Largest returns the n assets with the most embedded data, largest
+// first. Duplicates do not embed any data of their own, and are left out.
+func (s *Stats) Largest(n int) []AssetStats {
+	var list []AssetStats
+	for _, file := range s.Files {
+		if len(file.DuplicateOf) == 0 {
+			list = append(list, file)
+		}
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].EmbeddedSize > list[j].EmbeddedSize
+	})
+
+	if len(list) > n {
+		list = list[:n]
+	}
+	return list
+}
+
+// WriteReport writes a readable report of the statistics to w. It lists
+// the size, embedded size and compression ratio of every asset, followed
+// by the n largest assets, if n is positive, and the totals.
+func (s *Stats) WriteReport(w io.Writer, n int) error {
+	_, err := fmt.Fprintf(w, "%10s %10s %6s  %s\n", "size", "embedded", "ratio", "name")
+	if err != nil {
+		return err
+	}
+
+	for i := range s.Files {
+		file := &s.Files[i]
+		note := ""
+		if len(file.DuplicateOf) > 0 {
+			note = " (duplicate of " + file.DuplicateOf + ")"
+		} else if file.Compressed {
+			note = " (compressed)"
+		}
+
+		_, err = fmt.Fprintf(w, "%10d %10d %5.1f%%  %s%s\n", file.Size, file.EmbeddedSize, 100*file.Ratio(), file.Name, note)
+		if err != nil {
+			return err
+		}
+	}
+
+	if largest := s.Largest(n); n > 0 && len(largest) > 0 {
+		_, err = fmt.Fprintf(w, "\nLargest assets:\n")
+		if err != nil {
+			return err
+		}
+
+		for i := range largest {
+			_, err = fmt.Fprintf(w, "%10d  %s\n", largest[i].EmbeddedSize, largest[i].Name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	ratio := 100.0
+	if s.Size > 0 {
+		ratio = 100 * float64(s.Embedded) / float64(s.Size)
+	}
+
+	_, err = fmt.Fprintf(w, "\n%d assets, %d bytes, %d bytes embedded (%.1f%%), %d duplicates saving %d bytes, %d name collisions",
+		s.Assets, s.Size, s.Embedded, ratio, s.Duplicates, s.Saved, s.Collisions)
+	if err != nil {
+		return err
+	}
+
+	if s.Duration > 0 {
+		_, err = fmt.Fprintf(w, ", generated in %v", s.Duration.Round(time.Millisecond))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\n")
+	return err
+}
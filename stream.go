@@ -0,0 +1,141 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// chunkSize is the amount of asset data read at once. Assets are
+// streamed in chunks of this size, so memory usage does not depend
+// on the size of the assets.
+const chunkSize = 32 * 1024
+
+// byteOrderMark is the UTF-8 encoded byte order mark.
+var byteOrderMark = []byte("\xEF\xBB\xBF")
+
+// readChunks reads r in chunks, passing each of them to fn. Chunks
+// are only cut at the start of a UTF-8 sequence, so an encoded
+// character is never split in two.
+func readChunks(r io.Reader, fn func(p []byte) error) error {
+	buf := make([]byte, chunkSize)
+	n := 0
+
+	for {
+		m, err := r.Read(buf[n:])
+		n += m
+
+		end := n
+		if err == nil {
+			end = runeBoundary(buf[:n])
+		}
+
+		if end > 0 {
+			ferr := fn(buf[:end])
+			if ferr != nil {
+				return ferr
+			}
+		}
+
+		n = copy(buf, buf[end:n])
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// runeBoundary returns the length of p without a trailing incomplete
+// UTF-8 sequence.
+func runeBoundary(p []byte) int {
+	for i := len(p) - 1; i >= 0 && i >= len(p)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(p[i]) {
+			if utf8.FullRune(p[i:]) {
+				return len(p)
+			}
+			return i
+		}
+	}
+	return len(p)
+}
+
+// rawText reports whether all of r is valid UTF-8, which a raw string
+// literal can hold. Carriage returns are dropped from raw strings by the
+// compiler, and NUL bytes are not allowed in Go source, so text holding
+// either of them is not.
+func rawText(r io.Reader) (bool, error) {
+	valid := true
+	err := readChunks(r, func(p []byte) error {
+		if !utf8.Valid(p) || bytes.IndexByte(p, '\r') >= 0 || bytes.IndexByte(p, 0) >= 0 {
+			valid = false
+			return io.EOF
+		}
+		return nil
+	})
+	if err == io.EOF {
+		err = nil
+	}
+	return valid, err
+}
+
+// writeRawString copies the UTF-8 text from r to w as the contents of
+// a raw string literal. It escapes the same characters as sanitize,
+// without allocating a copy of each chunk. Lines longer than limit
+// are continued in another literal, unless limit is zero. The text is
+// split into several variables by parts, if it is not nil.
+func writeRawString(w io.Writer, r io.Reader, limit int, parts *literalParts) error {
+	lw := &rawLineWriter{Writer: w, limit: limit, parts: parts}
+	return readChunks(r, func(p []byte) error {
+		tick := bytes.IndexByte(p, '`')
+		bom := bytes.Index(p, byteOrderMark)
+
+		for len(p) > 0 {
+			i, n, esc := len(p), 0, ""
+			if tick >= 0 && tick < i {
+				i, n, esc = tick, 1, "`+\"`\"+`"
+			}
+			if bom >= 0 && bom < i {
+				i, n, esc = bom, len(byteOrderMark), "`+\"\\xEF\\xBB\\xBF\"+`"
+			}
+
+			_, err := lw.Write(p[:i])
+			if err != nil {
+				return err
+			}
+
+			if n == 0 {
+				return nil
+			}
+
+			_, err = io.WriteString(w, esc)
+			if err != nil {
+				return err
+			}
+
+			// Move past the escaped character and find the next
+			// occurrence, if this one was consumed.
+			p = p[i+n:]
+			if tick >= 0 {
+				tick -= i + n
+				if tick < 0 {
+					tick = bytes.IndexByte(p, '`')
+				}
+			}
+			if bom >= 0 {
+				bom -= i + n
+				if bom < 0 {
+					bom = bytes.Index(p, byteOrderMark)
+				}
+			}
+		}
+
+		return nil
+	})
+}
@@ -6,13 +6,41 @@ package bindata
 
 import (
 	"io"
+	"unicode/utf8"
 )
 
 const lowerHex = "0123456789abcdef"
 
+var (
+	// continued ends an interpreted string literal,
+	// and continues it on the next line.
+	continued = []byte("\" +\n\t\"")
+
+	// rawContinued does the same for raw string literals.
+	rawContinued = []byte("` +\n\t`")
+)
+
 type StringWriter struct {
 	io.Writer
 	c int
+
+	// limit is the number of bytes after which the literal is
+	// continued on the next line. Zero writes a single line.
+	limit int
+
+	// parts splits the data into several variables, if set.
+	parts *literalParts
+}
+
+// newStringWriter returns a StringWriter, which
+// limits the length of lines as configured.
+func newStringWriter(w io.Writer, c *Config) *StringWriter {
+	sw := &StringWriter{Writer: w}
+	if n := c.lineLength(); n > 0 {
+		// Every byte is written as a four character escape.
+		sw.limit = (n + 3) / 4
+	}
+	return sw
 }
 
 func (w *StringWriter) Write(p []byte) (n int, err error) {
@@ -24,13 +52,98 @@ func (w *StringWriter) Write(p []byte) (n int, err error) {
 	var b byte
 
 	for n, b = range p {
+		if w.parts.full() {
+			err = w.parts.next()
+			if err != nil {
+				return
+			}
+			w.c = 0
+		}
+
+		if w.limit > 0 && w.c > 0 && w.c%w.limit == 0 {
+			w.Writer.Write(continued)
+		}
+
 		buf[2] = lowerHex[b/16]
 		buf[3] = lowerHex[b%16]
 		w.Writer.Write(buf)
 		w.c++
+		w.parts.add(1)
 	}
 
 	n++
 
 	return
 }
+
+// rawLineWriter writes the contents of a raw string literal, and
+// continues the literal on the next line whenever a line exceeds the
+// limit. Literals are only split between runes, so the output remains
+// valid UTF-8.
+type rawLineWriter struct {
+	io.Writer
+	col   int
+	limit int
+
+	// parts splits the text into several variables, if set.
+	// Like lines, they are only split between runes.
+	parts *literalParts
+}
+
+func (w *rawLineWriter) Write(p []byte) (int, error) {
+	if w.limit <= 0 && w.parts == nil {
+		return w.Writer.Write(p)
+	}
+
+	start := 0
+	for i, b := range p {
+		if w.parts.full() && utf8.RuneStart(b) {
+			_, err := w.Writer.Write(p[start:i])
+			if err != nil {
+				return start, err
+			}
+
+			err = w.parts.next()
+			if err != nil {
+				return i, err
+			}
+
+			start = i
+			w.col = 0
+		}
+		w.parts.add(1)
+
+		if w.limit <= 0 {
+			continue
+		}
+
+		if b == '\n' {
+			w.col = 0
+			continue
+		}
+
+		if w.col >= w.limit && utf8.RuneStart(b) {
+			_, err := w.Writer.Write(p[start:i])
+			if err != nil {
+				return start, err
+			}
+
+			_, err = w.Writer.Write(rawContinued)
+			if err != nil {
+				return i, err
+			}
+
+			start = i
+			w.col = 0
+		}
+
+		w.col++
+	}
+
+	_, err := w.Writer.Write(p[start:])
+	if err != nil {
+		return start, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,103 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// buildConstraint combines the given tags into a single constraint,
+// which holds if all of them hold. Each tag is either an expression in
+// //go:build syntax, such as "linux && !cgo", or in the older // +build
+// syntax, such as "linux,386 darwin".
+func buildConstraint(tags []string) (constraint.Expr, error) {
+	var expr constraint.Expr
+
+	for _, tag := range tags {
+		x, err := constraint.Parse("//go:build " + tag)
+		if err != nil {
+			if !isPlusBuild(tag) {
+				return nil, fmt.Errorf("Invalid build tag %q: %v", tag, err)
+			}
+
+			x, err = constraint.Parse("// +build " + tag)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid build tag %q: %v", tag, err)
+			}
+		}
+
+		if expr == nil {
+			expr = x
+		} else {
+			expr = &constraint.AndExpr{X: expr, Y: x}
+		}
+	}
+
+	return expr, nil
+}
+
+// isPlusBuild reports whether the given tag is a valid expression
+// in // +build syntax: space separated alternatives of comma separated
+// terms, each of which is a tag name, optionally negated.
+func isPlusBuild(tag string) bool {
+	words := strings.FieldsFunc(tag, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	if len(words) == 0 {
+		return false
+	}
+
+	for _, word := range words {
+		word = strings.TrimPrefix(word, "!")
+		if word == "" {
+			return false
+		}
+
+		for _, r := range word {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '.' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// writeBuildConstraint writes the //go:build line for the given tags,
+// followed by the equivalent // +build lines if plusBuild is set, which
+// Go versions before 1.17 need.
+func writeBuildConstraint(w io.Writer, tags []string, plusBuild bool) error {
+	expr, err := buildConstraint(tags)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "//go:build %s\n", expr)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	if plusBuild {
+		lines, err = constraint.PlusBuildLines(expr)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, line := range lines {
+		_, err = fmt.Fprintf(w, "%s\n", line)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "\n")
+	return err
+}
@@ -0,0 +1,65 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+)
+
+// textTemplateImport imports text/template under a name,
+// which does not clash with html/template.
+const textTemplateImport = "texttemplate text/template"
+
+// writeTemplates writes the ParseTemplates and ParseTextTemplates
+// functions, which parse the matching assets as templates.
+func writeTemplates(w io.Writer, c *Config) error {
+	for _, v := range []struct{ fn, pkg, doc string }{
+		{"ParseTemplates", "template", "html/template"},
+		{"ParseTextTemplates", "texttemplate", "text/template"},
+	} {
+		_, err := fmt.Fprintf(w, `// %s parses the assets matching the pattern, using the syntax of
+// path.Match, as %s templates. Like ParseFS, each template is named
+// after the base name of its asset, and the first one is returned. The
+// functions are added before parsing, so the templates can use them.
+func %s(pattern string, funcs %s.FuncMap) (*%s.Template, error) {
+	names, err := AssetGlob(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%%w: no asset matches %%s", ErrAssetNotFound, pattern)
+	}
+
+	var root *%s.Template
+	for _, name := range names {
+		data, err := Asset(%s)
+		if err != nil {
+			return nil, err
+		}
+
+		var t *%s.Template
+		if root == nil {
+			root = %s.New(path.Base(name)).Funcs(funcs)
+			t = root
+		} else {
+			t = root.New(path.Base(name))
+		}
+
+		_, err = t.Parse(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("Parse %%s: %%w", name, err)
+		}
+	}
+	return root, nil
+}
+
+`, v.fn, v.doc, v.fn, v.pkg, v.pkg, v.pkg, c.nameArg("name"), v.pkg, v.pkg)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+)
+
+// timeZoneImport is the import of the unsafe package, which is
+// required for the linkname directive of the time zone loader.
+const timeZoneImport = "_ unsafe"
+
+// findTimeZones returns the asset configured as the time zone database,
+// after checking that it is a zip archive. Obfuscated assets are found
+// by their original name.
+func findTimeZones(c *Config, toc []Asset) (*Asset, error) {
+	var asset *Asset
+	for i := range toc {
+		if toc[i].Name == c.TimeZones || toc[i].label == c.TimeZones {
+			asset = &toc[i]
+			break
+		}
+	}
+
+	if asset == nil {
+		return nil, fmt.Errorf("Time zone database %s not found among the assets", c.TimeZones)
+	}
+
+	fd, err := asset.open()
+	if err != nil {
+		return nil, err
+	}
+
+	defer fd.Close()
+
+	data, err := ioutil.ReadAll(fd)
+	if err != nil {
+		return nil, fmt.Errorf("Read %s: %v", asset.origin(), err)
+	}
+
+	_, err = zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid time zone database %s: %v", asset.origin(), err)
+	}
+
+	return asset, nil
+}
+
+// writeTimeZones writes an init function registering the embedded
+// time zone database with the time package, the way time/tzdata does.
+func writeTimeZones(w io.Writer, c *Config, toc []Asset) error {
+	asset, err := findTimeZones(c, toc)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `// bindata_register_tzdata is provided by the time package, for
+// time/tzdata to register its embedded time zone database.
+//
+//go:linkname bindata_register_tzdata time.registerLoadFromEmbeddedTZData
+func bindata_register_tzdata(func(string) (string, error))
+
+func init() {
+	bindata_register_tzdata(bindata_load_tzdata)
+}
+
+// bindata_load_tzdata returns the named time zone from the embedded
+// zoneinfo.zip. The time package only asks for it, if a time zone
+// cannot be found in the ZONEINFO variable or the system directories.
+func bindata_load_tzdata(name string) (string, error) {
+	data, err := Asset(%s)
+	if err != nil {
+		return "", err
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return "", err
+	}
+
+	for _, f := range r.File {
+		if f.Name != name {
+			continue
+		}
+
+		fd, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+
+		defer fd.Close()
+
+		zone, err := %s(fd)
+		if err != nil {
+			return "", err
+		}
+		return string(zone), nil
+	}
+
+	return "", syscall.ENOENT
+}
+
+`, c.nameArg(strconv.Quote(asset.Name)), c.ioutil("ReadAll"))
+	return err
+}
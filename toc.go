@@ -39,6 +39,18 @@ func (root *assetTree) Add(route []string, asset Asset) {
 	root.Asset = asset
 }
 
+// AddDir adds the directory with the given route, unless an asset is
+// found along it. It reports whether the directory was added.
+func (root *assetTree) AddDir(route []string) bool {
+	for _, name := range route {
+		if root.Asset.Func != "" {
+			return false
+		}
+		root = root.child(name)
+	}
+	return root.Asset.Func == ""
+}
+
 func ident(w io.Writer, n int) {
 	for i := 0; i < n; i++ {
 		w.Write([]byte{'\t'})
@@ -88,7 +100,7 @@ var _bintree = `)
 	return err
 }
 
-func writeTOCTree(w io.Writer, toc []Asset) error {
+func writeTOCTree(w io.Writer, toc, dirs []Asset) error {
 	_, err := fmt.Fprintf(w, `// AssetDir returns the file names below a certain
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
@@ -103,19 +115,19 @@ func writeTOCTree(w io.Writer, toc []Asset) error {
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
 // AssetDir("") will return []string{"data"}.
 func AssetDir(name string) ([]string, error) {
-	node := _bintree
-	if len(name) != 0 {
-		cannonicalName := strings.Replace(name, "\\", "/", -1)
+	node := bindata_tree()
+	cannonicalName := bindata_clean(name)
+	if len(cannonicalName) != 0 {
 		pathList := strings.Split(cannonicalName, "/")
 		for _, p := range pathList {
 			node = node.Children[p]
 			if node == nil {
-				return nil, fmt.Errorf("Asset %%s not found", name)
+				return nil, fmt.Errorf("%%w: %%s", ErrAssetNotFound, name)
 			}
 		}
 	}
 	if node.Func != nil {
-		return nil, fmt.Errorf("Asset %%s not found", name)
+		return nil, fmt.Errorf("%%w: %%s", ErrAssetNotFound, name)
 	}
 	rv := make([]string, 0, len(node.Children))
 	for name := range node.Children {
@@ -130,72 +142,269 @@ func AssetDir(name string) ([]string, error) {
 	}
 	tree := newAssetTree()
 	for i := range toc {
-		pathList := strings.Split(toc[i].Name, string(os.PathSeparator))
+		pathList := strings.Split(toc[i].Name, "/")
 		tree.Add(pathList, toc[i])
 	}
-	return tree.WriteAsGoMap(w)
+
+	modes := make(map[string]os.FileMode, len(dirs))
+	for i := range dirs {
+		if _, ok := modes[dirs[i].Name]; ok {
+			continue
+		}
+		fi, err := os.Stat(dirs[i].Path)
+		if err != nil {
+			return err
+		}
+		if tree.AddDir(strings.Split(dirs[i].Name, "/")) {
+			modes[dirs[i].Name] = fi.Mode().Perm()
+		}
+	}
+
+	err = tree.WriteAsGoMap(w)
+	if err != nil {
+		return err
+	}
+	return writeDirModes(w, modes)
 }
 
-// writeTOC writes the table of contents file.
-func writeTOC(w io.Writer, toc []Asset) error {
-	err := writeTOCHeader(w)
+// writeDirModes writes the table of directory modes, which are
+// applied when restoring directories.
+func writeDirModes(w io.Writer, modes map[string]os.FileMode) error {
+	names := make([]string, 0, len(modes))
+	for name := range modes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	_, err := fmt.Fprintf(w, `
+// _bindata_dirs holds the modes of the directories recorded in the
+// asset tree, including empty ones.
+var _bindata_dirs = map[string]os.FileMode{
+`)
 	if err != nil {
 		return err
 	}
 
-	for i := range toc {
-		err = writeTOCAsset(w, &toc[i])
+	for _, name := range names {
+		_, err = fmt.Fprintf(w, "\t%q: %#o,\n", name, modes[name])
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "}\n\n")
+	return err
+}
+
+// writeTOC writes the table of contents file.
+func writeTOC(w io.Writer, c *Config, toc []Asset) error {
+	if c.typedNames() {
+		err := writeNameConstants(w, toc)
 		if err != nil {
 			return err
 		}
 	}
 
-	return writeTOCFooter(w)
+	err := writeFind(w, c, toc)
+	if err != nil {
+		return err
+	}
+
+	err = writeNames(w, c)
+	if err != nil {
+		return err
+	}
+
+	err = writeTOCHeader(w, c)
+	if err != nil {
+		return err
+	}
+
+	return writeTOCTable(w, c, toc)
 }
 
 // writeTOCHeader writes the table of contents file header.
-func writeTOCHeader(w io.Writer) error {
-	_, err := fmt.Fprintf(w, `// Asset loads and returns the asset for the given name.
+func writeTOCHeader(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// ErrAssetNotFound is returned, wrapped along with the requested name,
+// when there is no asset of that name. Use errors.Is to test for it.
+var ErrAssetNotFound = errors.New("Asset not found")
+
+// Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
-// could not be loaded.
-func Asset(name string) ([]byte, error) {
-	cannonicalName := strings.Replace(name, "\\", "/", -1)
-	if f, ok := _bindata[cannonicalName]; ok {
+// could not be loaded. The data belongs to the caller.
+func Asset(name %s) ([]byte, error) {
+	a, err := bindata_asset(%s)
+	if err != nil {
+		return nil, err
+	}
+	if a.shared {
+		return bindata_copy(a.bytes), nil
+	}
+	return a.bytes, nil
+}
+
+// AssetUnsafe is like Asset, but avoids copying data, which is kept by
+// the package. This is the case for uncompressed assets, or cached ones.
+// The data is shared between all callers, and may be read only memory,
+// so it must never be altered.
+func AssetUnsafe(name %s) ([]byte, error) {
+	a, err := bindata_asset(%s)
+	if err != nil {
+		return nil, err
+	}
+	return a.bytes, nil
+}
+
+// AssetString returns the data of the named asset as a string. Data
+// kept in a string constant, which is the case for uncompressed assets
+// with NoMemCopy or NoUnsafe, is returned without copying.
+func AssetString(name %s) (string, error) {
+	a, err := bindata_asset(%s)
+	if err != nil {
+		return "", err
+	}
+	if len(a.text) > 0 {
+		return a.text, nil
+	}
+	return string(a.bytes), nil
+}
+
+// bindata_asset loads the named asset.
+func bindata_asset(name string) (*asset, error) {
+	if f, ok := bindata_find(name); ok {
 		a, err := f()
 		if err != nil {
-			return nil, fmt.Errorf("Asset %%s can't read by error: %%v", name, err)
+			return nil, fmt.Errorf("Asset %%s can't read by error: %%w", name, err)
 		}
-		return a.bytes, nil
+		return a, nil
 	}
-	return nil, fmt.Errorf("Asset %%s not found", name)
+	return nil, fmt.Errorf("%%w: %%s", ErrAssetNotFound, name)
+}
+
+// bindata_copy returns a copy of the data.
+func bindata_copy(data []byte) []byte {
+	b := make([]byte, len(data))
+	copy(b, data)
+	return b
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name %s) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + %s + "): " + err.Error())
+	}
+
+	return a
 }
 
 // AssetInfo loads and returns the asset info for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
-func AssetInfo(name string) (os.FileInfo, error) {
-	cannonicalName := strings.Replace(name, "\\", "/", -1)
-	if f, ok := _bindata[cannonicalName]; ok {
+func AssetInfo(name %s) (os.FileInfo, error) {
+	if f, ok := bindata_find(%s); ok {
 		a, err := f()
 		if err != nil {
-			return nil, fmt.Errorf("AssetInfo %%s can't read by error: %%v", name, err)
+			return nil, fmt.Errorf("AssetInfo %%s can't read by error: %%w", name, err)
 		}
 		return a.info, nil
 	}
-	return nil, fmt.Errorf("AssetInfo %%s not found", name)
+	return nil, fmt.Errorf("%%w: %%s", ErrAssetNotFound, name)
 }
 
-// AssetNames returns the names of the assets.
+// AssetNames returns the names of the assets, sorted.
+// The slice belongs to the caller.
 func AssetNames() []string {
-	names := make([]string, 0, len(_bindata))
-	for name := range _bindata {
+	names := bindata_names()
+	return append(make([]string, 0, len(names)), names...)
+}
+
+// AssetNamesWithPrefix returns the sorted names of the assets
+// starting with the given prefix, like "css/". The slice belongs
+// to the caller.
+func AssetNamesWithPrefix(prefix string) []string {
+	names := bindata_names()
+	i := sort.SearchStrings(names, prefix)
+	j := i
+	for j < len(names) && strings.HasPrefix(names[j], prefix) {
+		j++
+	}
+	return append([]string(nil), names[i:j]...)
+}
+
+// AssetGlob returns the sorted names of all assets matching the pattern,
+// using the syntax of path.Match. The only possible error is
+// path.ErrBadPattern, when the pattern is malformed.
+func AssetGlob(pattern string) ([]string, error) {
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range bindata_toc() {
+		if ok, _ := path.Match(pattern, name); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+`, c.nameType(), c.stringArg("name"), c.nameType(), c.stringArg("name"),
+		c.nameType(), c.stringArg("name"), c.nameType(), c.stringArg("name"),
+		c.nameType(), c.stringArg("name"))
+	return err
+}
+
+// writeNames writes bindata_names, which returns the sorted names
+// of the assets. Release builds sort them once, debug builds
+// whenever they are asked for, as the assets may change. Without
+// the map lookup, the names are generated in order.
+func writeNames(w io.Writer, c *Config) error {
+	if c.lookup() != LookupMap {
+		_, err := fmt.Fprintf(w, `// bindata_names returns the sorted names of the assets.
+func bindata_names() []string {
+	return _bindata_names
+}
+
+`)
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, `// bindata_sorted_names returns the names of the table of contents, sorted.
+func bindata_sorted_names() []string {
+	toc := bindata_toc()
+	names := make([]string, 0, len(toc))
+	for name := range toc {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
-// _bindata is a table, holding each asset generator, mapped to its name.
-var _bindata = map[string]func() (*asset, error){
+`)
+	if err != nil {
+		return err
+	}
+
+	if c.Debug {
+		_, err = fmt.Fprintf(w, `// bindata_names returns the sorted names of the assets.
+func bindata_names() []string {
+	return bindata_sorted_names()
+}
+
+`)
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `// _bindata_names holds the sorted names of the assets.
+var _bindata_names = bindata_sorted_names()
+
+// bindata_names returns the sorted names of the assets.
+func bindata_names() []string {
+	return _bindata_names
+}
+
 `)
 	return err
 }
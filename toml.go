@@ -0,0 +1,318 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML decodes the given document into maps, slices and scalars.
+// It supports the subset of TOML used by configuration files: tables,
+// arrays of tables, dotted keys, strings on a single line, integers,
+// floats, booleans, arrays and inline tables. Dates and multi-line
+// strings are not supported.
+func parseTOML(data []byte) (interface{}, error) {
+	root := map[string]interface{}{}
+	table := root
+
+	lines := strings.Split(string(data), "\n")
+	for i := 0; i < len(lines); i++ {
+		num := i + 1
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if len(line) == 0 {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			var err error
+			table, err = tomlTable(root, line, num)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		n := strings.IndexByte(line, '=')
+		if n < 0 {
+			return nil, fmt.Errorf("line %d: expected a key", num)
+		}
+
+		// Arrays may continue on the following lines.
+		text := strings.TrimSpace(line[n+1:])
+		for !tomlBalanced(text) && i+1 < len(lines) {
+			i++
+			text += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+
+		v, rest, err := tomlValue(text, num)
+		if err != nil {
+			return nil, err
+		}
+		if len(strings.TrimSpace(rest)) > 0 {
+			return nil, fmt.Errorf("line %d: unexpected %q after value", num, rest)
+		}
+
+		err = tomlSet(table, line[:n], v, num)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return root, nil
+}
+
+// tomlTable returns the table selected by the given header line,
+// creating it and its parents as needed.
+func tomlTable(root map[string]interface{}, line string, num int) (map[string]interface{}, error) {
+	array := strings.HasPrefix(line, "[[")
+	end := "]"
+	if array {
+		end = "]]"
+	}
+	if !strings.HasSuffix(line, end) {
+		return nil, fmt.Errorf("line %d: invalid table header %s", num, line)
+	}
+
+	keys, err := tomlKey(line[len(end):len(line)-len(end)], num)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := tomlParent(root, keys, num)
+	if err != nil {
+		return nil, err
+	}
+
+	key := keys[len(keys)-1]
+	table := map[string]interface{}{}
+	switch v := parent[key].(type) {
+	case nil:
+		if array {
+			parent[key] = []interface{}{table}
+		} else {
+			parent[key] = table
+		}
+	case []interface{}:
+		if !array {
+			return nil, fmt.Errorf("line %d: %s is an array of tables", num, key)
+		}
+		parent[key] = append(v, table)
+	case map[string]interface{}:
+		if array {
+			return nil, fmt.Errorf("line %d: %s is not an array of tables", num, key)
+		}
+		table = v
+	default:
+		return nil, fmt.Errorf("line %d: %s is not a table", num, key)
+	}
+
+	return table, nil
+}
+
+// tomlSet sets the value of the given, possibly dotted, key in the table.
+func tomlSet(table map[string]interface{}, key string, v interface{}, num int) error {
+	keys, err := tomlKey(key, num)
+	if err != nil {
+		return err
+	}
+
+	parent, err := tomlParent(table, keys, num)
+	if err != nil {
+		return err
+	}
+
+	last := keys[len(keys)-1]
+	if _, ok := parent[last]; ok {
+		return fmt.Errorf("line %d: duplicate key %q", num, last)
+	}
+	parent[last] = v
+	return nil
+}
+
+// tomlParent returns the table holding the last of the given keys,
+// creating the tables named by the others as needed. The last table
+// of an array of tables stands for the array.
+func tomlParent(table map[string]interface{}, keys []string, num int) (map[string]interface{}, error) {
+	for _, key := range keys[:len(keys)-1] {
+		switch v := table[key].(type) {
+		case nil:
+			next := map[string]interface{}{}
+			table[key] = next
+			table = next
+		case map[string]interface{}:
+			table = v
+		case []interface{}:
+			last, ok := v[len(v)-1].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("line %d: %s is not a table", num, key)
+			}
+			table = last
+		default:
+			return nil, fmt.Errorf("line %d: %s is not a table", num, key)
+		}
+	}
+	return table, nil
+}
+
+// tomlKey splits a dotted key into its parts, which are either
+// bare or quoted.
+func tomlKey(text string, num int) ([]string, error) {
+	var keys []string
+	for {
+		text = strings.TrimSpace(text)
+		if len(text) == 0 {
+			return nil, fmt.Errorf("line %d: missing key", num)
+		}
+
+		var key string
+		if text[0] == '"' || text[0] == '\'' {
+			v, rest, err := tomlValue(text, num)
+			if err != nil {
+				return nil, err
+			}
+			key, text = v.(string), strings.TrimSpace(rest)
+		} else {
+			n := strings.IndexFunc(text, func(r rune) bool {
+				return !(r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+			})
+			if n < 0 {
+				n = len(text)
+			}
+			if n == 0 {
+				return nil, fmt.Errorf("line %d: invalid key %q", num, text)
+			}
+			key, text = text[:n], strings.TrimSpace(text[n:])
+		}
+
+		keys = append(keys, key)
+		if len(text) == 0 {
+			return keys, nil
+		}
+		if text[0] != '.' {
+			return nil, fmt.Errorf("line %d: invalid key %q", num, text)
+		}
+		text = text[1:]
+	}
+}
+
+// tomlValue parses the value at the start of text,
+// and returns it along with the remaining text.
+func tomlValue(text string, num int) (interface{}, string, error) {
+	text = strings.TrimSpace(text)
+	if len(text) == 0 {
+		return nil, "", fmt.Errorf("line %d: missing value", num)
+	}
+
+	switch text[0] {
+	case '"':
+		end := quotedEnd(text)
+		if end < 0 || strings.HasPrefix(text, `"""`) {
+			return nil, "", fmt.Errorf("line %d: unsupported string %s", num, text)
+		}
+		s, err := strconv.Unquote(text[:end])
+		if err != nil {
+			return nil, "", fmt.Errorf("line %d: invalid string %s", num, text[:end])
+		}
+		return s, text[end:], nil
+
+	case '\'':
+		end := strings.IndexByte(text[1:], '\'')
+		if end < 0 || strings.HasPrefix(text, "'''") {
+			return nil, "", fmt.Errorf("line %d: unsupported string %s", num, text)
+		}
+		return text[1 : end+1], text[end+2:], nil
+
+	case '[':
+		list := []interface{}{}
+		text = strings.TrimSpace(text[1:])
+		for !strings.HasPrefix(text, "]") {
+			v, rest, err := tomlValue(text, num)
+			if err != nil {
+				return nil, "", err
+			}
+			list = append(list, v)
+
+			text = strings.TrimSpace(rest)
+			if strings.HasPrefix(text, ",") {
+				text = strings.TrimSpace(text[1:])
+			} else if !strings.HasPrefix(text, "]") {
+				return nil, "", fmt.Errorf("line %d: expected a comma in array", num)
+			}
+		}
+		return list, text[1:], nil
+
+	case '{':
+		table := map[string]interface{}{}
+		text = strings.TrimSpace(text[1:])
+		for !strings.HasPrefix(text, "}") {
+			n := strings.IndexByte(text, '=')
+			if n < 0 {
+				return nil, "", fmt.Errorf("line %d: expected a key in inline table", num)
+			}
+			v, rest, err := tomlValue(text[n+1:], num)
+			if err != nil {
+				return nil, "", err
+			}
+			err = tomlSet(table, text[:n], v, num)
+			if err != nil {
+				return nil, "", err
+			}
+
+			text = strings.TrimSpace(rest)
+			if strings.HasPrefix(text, ",") {
+				text = strings.TrimSpace(text[1:])
+			} else if !strings.HasPrefix(text, "}") {
+				return nil, "", fmt.Errorf("line %d: expected a comma in inline table", num)
+			}
+		}
+		return table, text[1:], nil
+	}
+
+	n := strings.IndexAny(text, ",]} \t")
+	if n < 0 {
+		n = len(text)
+	}
+
+	word, rest := text[:n], text[n:]
+	switch word {
+	case "true":
+		return true, rest, nil
+	case "false":
+		return false, rest, nil
+	}
+
+	number := strings.Replace(word, "_", "", -1)
+	if v, err := strconv.ParseInt(number, 0, 64); err == nil {
+		return v, rest, nil
+	}
+	if v, err := strconv.ParseFloat(number, 64); err == nil {
+		return v, rest, nil
+	}
+
+	return nil, "", fmt.Errorf("line %d: unsupported value %q", num, word)
+}
+
+// tomlBalanced reports whether all arrays and inline tables
+// opened in the given text are closed again.
+func tomlBalanced(text string) bool {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '"', '\'':
+			end := quotedEnd(text[i:])
+			if end < 0 {
+				return true
+			}
+			i += end - 1
+		case '[', '{':
+			depth++
+		case ']', '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
@@ -0,0 +1,143 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TransformFunc transforms the contents of the named asset before it
+// is embedded. It returns a reader for the new contents. Returning r
+// itself leaves the asset unchanged.
+type TransformFunc func(name string, r io.Reader) (io.Reader, error)
+
+// CommandTransform returns a TransformFunc, which passes the contents
+// of assets matching the given glob pattern through an external command.
+// The command reads the asset from its standard input, and writes the
+// new contents to its standard output. The name of the asset is passed
+// in the BINDATA_NAME environment variable. Patterns without a slash
+// are matched against the base name of the asset, like Config.Include.
+func CommandTransform(pattern string, command string, args ...string) TransformFunc {
+	return func(name string, r io.Reader) (io.Reader, error) {
+		match := name
+		if !strings.Contains(pattern, "/") {
+			match = path.Base(name)
+		}
+
+		if ok, _ := path.Match(pattern, match); !ok {
+			return r, nil
+		}
+
+		var stdout, stderr bytes.Buffer
+		cmd := exec.Command(command, args...)
+		cmd.Env = append(os.Environ(), "BINDATA_NAME="+name)
+		cmd.Stdin = r
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+
+		err := cmd.Run()
+		if msg := strings.TrimSpace(stderr.String()); err != nil && len(msg) > 0 {
+			return nil, fmt.Errorf("Filter %s failed for %s: %v: %s", command, name, err, msg)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Filter %s failed for %s: %v", command, name, err)
+		}
+
+		return &stdout, nil
+	}
+}
+
+// transformAssets passes the contents of all assets through the
+// configured transforms. The results are written to files in a new
+// temporary directory, which is returned and must be removed by the
+// caller. The assets then refer to these files, which keep the mode
+// and modification time of the originals.
+func transformAssets(c *Config, toc []Asset) (string, error) {
+	dir, err := ioutil.TempDir("", "bindata-transform")
+	if err != nil {
+		return "", err
+	}
+
+	for i := range toc {
+		asset := &toc[i]
+
+		// Keep the base name, as the extension is used to
+		// decide whether the asset is worth compressing.
+		file := filepath.Join(dir, strconv.Itoa(i), filepath.Base(asset.Path))
+		err = transformAsset(c, asset, file)
+		if err != nil {
+			return dir, err
+		}
+
+		// The sum of the source file is recorded for Outdated.
+		sum, err := assetDigest(asset)
+		if err != nil {
+			return dir, err
+		}
+		asset.sum = &sum
+
+		asset.source = asset.origin()
+		asset.Path = file
+		asset.fsys = nil
+	}
+
+	return dir, nil
+}
+
+// transformAsset writes the transformed contents of the asset to file.
+func transformAsset(c *Config, asset *Asset, file string) error {
+	fi, err := asset.stat()
+	if err != nil {
+		return err
+	}
+
+	src, err := asset.open()
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	var r io.Reader = src
+	for _, transform := range c.transforms() {
+		r, err = transform(asset.Name, r)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(file), 0755)
+	if err != nil {
+		return err
+	}
+
+	fd, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, fi.Mode().Perm())
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fd, r)
+	if err != nil {
+		fd.Close()
+		return fmt.Errorf("Failed to transform %s: %v", asset.Name, err)
+	}
+
+	err = fd.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Chtimes(file, time.Now(), fi.ModTime())
+}
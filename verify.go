@@ -0,0 +1,148 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+)
+
+// authenticate reports whether assets are checked against an HMAC.
+func (c *Config) authenticate() bool {
+	return len(c.HMACKeyEnv) > 0
+}
+
+// validateHMAC ensures the configured environment variable
+// holds a valid key, if HMAC checks are enabled.
+func validateHMAC(c *Config) error {
+	if !c.authenticate() || c.Debug {
+		return nil
+	}
+
+	_, err := hmacKey(c.HMACKeyEnv)
+	return err
+}
+
+// hmacKey returns the hex encoded HMAC key held by
+// the named environment variable.
+func hmacKey(env string) ([]byte, error) {
+	key, err := hex.DecodeString(os.Getenv(env))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid HMAC key in %s: %v", env, err)
+	}
+
+	if len(key) < 16 {
+		return nil, fmt.Errorf("Invalid HMAC key in %s: %d bytes, want at least 16", env, len(key))
+	}
+
+	return key, nil
+}
+
+// assetMAC returns the HMAC of the given asset as a string literal.
+// It is computed over the SHA-256 digest of the asset contents, so
+// it must be called once the digest is known.
+func assetMAC(c *Config, asset *Asset) (string, error) {
+	key, err := hmacKey(c.HMACKeyEnv)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(asset.Digest[:])
+	return bytesLiteral(mac.Sum(nil)), nil
+}
+
+// bytesLiteral returns the given bytes as an interpreted string literal.
+func bytesLiteral(b []byte) string {
+	lit := make([]byte, 0, 2+4*len(b))
+	lit = append(lit, '"')
+	for _, c := range b {
+		lit = append(lit, '\\', 'x', lowerHex[c/16], lowerHex[c%16])
+	}
+	return string(append(lit, '"'))
+}
+
+// verifyStep returns the generated statements, which check the
+// contents of the asset in variable bytes against its HMAC.
+func verifyStep(c *Config, asset *Asset) (string, error) {
+	if !c.authenticate() {
+		return "", nil
+	}
+
+	mac, err := assetMAC(c, asset)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`	err = bindata_verify(%q, bytes, %s)
+	if err != nil {
+		return nil, err
+	}
+
+`, asset.Name, mac), nil
+}
+
+// header_verify writes the HMAC key and the function
+// checking asset contents against it.
+func header_verify(w io.Writer, c *Config) error {
+	key, err := hmacKey(c.HMACKeyEnv)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, `var _bindata_hmac_key = []byte(%s)
+
+// bindata_verify checks the contents of the named asset against its HMAC,
+// which is computed over the SHA-256 digest of the contents.
+func bindata_verify(name string, data []byte, mac string) error {
+	sum := sha256.Sum256(data)
+	h := hmac.New(sha256.New, _bindata_hmac_key)
+	h.Write(sum[:])
+	if !hmac.Equal(h.Sum(nil), []byte(mac)) {
+		return fmt.Errorf("Asset %%s failed verification: the embedded data was modified", name)
+	}
+	return nil
+}
+
+`, bytesLiteral(key))
+	return err
+}
+
+// writeVerifyAssets writes the VerifyAssets function. Release builds
+// check every asset against its HMAC or checksum when it is loaded.
+// Debug builds read the assets from disk, so there is nothing to check
+// them against.
+func writeVerifyAssets(w io.Writer, c *Config) error {
+	check := "HMAC"
+	if !c.authenticate() {
+		check = "checksum"
+	}
+
+	_, err := fmt.Fprintf(w, `// VerifyAssets loads all assets and checks their contents against the
+// %s computed during generation, which is also done whenever an asset
+// is loaded. It returns an error for the first asset, whose embedded data
+// was modified. In debug builds, it only checks the assets can be read.
+func VerifyAssets() error {
+	toc := bindata_toc()
+	names := make([]string, 0, len(toc))
+	for name := range toc {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if _, err := toc[name](); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+`, check)
+	return err
+}
@@ -0,0 +1,222 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// writeWalk writes the WalkAssets function, which traverses
+// the asset tree like filepath.Walk.
+func writeWalk(w io.Writer, c *Config) error {
+	_, err := fmt.Fprintf(w, `// WalkAssets walks the tree of assets rooted at root, calling fn for every
+// asset and directory in lexical order, like filepath.Walk. An empty root
+// walks all assets. The asset contents are returned by data, which is nil
+// for directories. If fn returns filepath.SkipDir for a directory, it is
+// skipped. For an asset, the remaining entries of its directory are skipped.
+func WalkAssets(root string, fn func(name string, info os.FileInfo, data func() ([]byte, error)) error) error {
+	node := bindata_tree()
+	cannonicalName := bindata_clean(root)
+	if len(cannonicalName) != 0 {
+		for _, p := range strings.Split(cannonicalName, "/") {
+			node = node.Children[p]
+			if node == nil {
+				return fmt.Errorf("%%w: %%s", ErrAssetNotFound, root)
+			}
+		}
+	}
+	err := bindata_walk(cannonicalName, node, fn)
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+type bindata_walk_func func(name string, info os.FileInfo, data func() ([]byte, error)) error
+
+// bindata_walk calls fn for the given node, and walks its children.
+// The asset is loaded to obtain its file info.
+func bindata_walk(name string, node *_bintree_t, fn bindata_walk_func) error {
+	if node.Func != nil {
+		a, err := node.Func()
+		if err != nil {
+			return err
+		}
+		return fn(name, a.info, func() ([]byte, error) {
+			if a.shared {
+				return bindata_copy(a.bytes), nil
+			}
+			return a.bytes, nil
+		})
+	}
+	if len(name) != 0 {
+		err := fn(name, bindata_dir_info{name: path.Base(name)}, nil)
+		if err != nil {
+			return err
+		}
+	}
+	names := make([]string, 0, len(node.Children))
+	for child := range node.Children {
+		names = append(names, child)
+	}
+	sort.Strings(names)
+	for _, child := range names {
+		next := node.Children[child]
+		err := bindata_walk(path.Join(name, child), next, fn)
+		if err == filepath.SkipDir && next.Func == nil {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bindata_dir_info describes a directory of the asset tree.
+type bindata_dir_info struct {
+	name string
+}
+
+func (di bindata_dir_info) Name() string {
+	return di.name
+}
+func (di bindata_dir_info) Size() int64 {
+	return 0
+}
+func (di bindata_dir_info) Mode() os.FileMode {
+	return os.ModeDir | 0555
+}
+func (di bindata_dir_info) ModTime() time.Time {
+	return time.Time{}
+}
+func (di bindata_dir_info) IsDir() bool {
+	return true
+}
+func (di bindata_dir_info) Sys() %s {
+	return nil
+}
+
+`, c.anyType())
+	return err
+}
+
+// writeAssetTree writes the AssetTree function, which returns the
+// whole asset tree at once. Release builds take the file infos from a
+// table of the generation, so no asset is loaded. Debug builds read
+// the assets from disk.
+func writeAssetTree(w io.Writer, c *Config, toc []Asset) error {
+	doc := "The file infos are those\n// of the generation, so no asset is loaded."
+	info := "n.Info = bindata_info(name)"
+	if c.Debug {
+		doc = "Debug builds read the\n// assets from disk to obtain their file info."
+		info = `a, err := node.Func()
+		if err != nil {
+			return nil, err
+		}
+		n.Info = a.info`
+	}
+
+	_, err := fmt.Fprintf(w, `// AssetNode is an asset or directory of the tree returned by AssetTree.
+type AssetNode struct {
+	Name     string       // The base name, or "." for the root.
+	Path     string       // The name of the asset or directory, empty for the root.
+	Info     os.FileInfo  // The file info of the asset or directory.
+	Children []*AssetNode // The entries of a directory, sorted by name. Nil for assets.
+}
+
+// AssetTree returns the tree of all assets and directories, with their
+// file infos, in one call. %s
+// The tree belongs to the caller.
+func AssetTree() (*AssetNode, error) {
+	return bindata_node("", bindata_tree())
+}
+
+// bindata_node returns the AssetNode for the given node of the asset
+// tree, along with those of its children.
+func bindata_node(name string, node *_bintree_t) (*AssetNode, error) {
+	n := &AssetNode{Name: path.Base(name), Path: name}
+	if node.Func != nil {
+		%s
+		return n, nil
+	}
+	n.Info = bindata_dir_info{name: n.Name}
+	names := make([]string, 0, len(node.Children))
+	for child := range node.Children {
+		names = append(names, child)
+	}
+	sort.Strings(names)
+	n.Children = make([]*AssetNode, 0, len(names))
+	for _, child := range names {
+		c, err := bindata_node(path.Join(name, child), node.Children[child])
+		if err != nil {
+			return nil, err
+		}
+		n.Children = append(n.Children, c)
+	}
+	return n, nil
+}
+
+`, doc, info)
+	if err != nil || c.Debug {
+		return err
+	}
+
+	return writeInfos(w, c, toc)
+}
+
+// writeInfos writes the table of the file infos of the assets, in the
+// order of their sorted names, and bindata_info, which looks them up.
+// It only holds constants, so it takes no work at startup.
+func writeInfos(w io.Writer, c *Config, toc []Asset) error {
+	// Obfuscated names are no longer in order.
+	sorted := make([]*Asset, len(toc))
+	for i := range toc {
+		sorted[i] = &toc[i]
+	}
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	_, err := fmt.Fprintf(w, `// bindata_info_t holds the file info of an asset, as of the generation.
+type bindata_info_t struct {
+	size    int64
+	mode    os.FileMode
+	modTime int64
+}
+
+// _bindata_infos holds the file infos of the assets,
+// in the order of their names.
+var _bindata_infos = [...]bindata_info_t{
+`)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range sorted {
+		size, mode, modTime, err := infoValues(c, asset)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "\t{%d, %d, %d},\n", size, uint32(mode), modTime)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, `}
+
+// bindata_info returns the file info of the named asset,
+// without loading it.
+func bindata_info(name string) os.FileInfo {
+	i := sort.SearchStrings(bindata_names(), name)
+	e := _bindata_infos[i]
+	return bindata_file_info{name: name, size: e.size, mode: e.mode, modTime: time.Unix(e.modTime, 0)}
+}
+
+`)
+	return err
+}
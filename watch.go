@@ -0,0 +1,217 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WatchInterval is the interval at which WatchAndTranslate
+// polls the input paths for changes.
+var WatchInterval = 500 * time.Millisecond
+
+// notifyChanges, if set, returns a channel which receives a value
+// whenever a file in one of the given directories may have changed,
+// along with a function to add further directories, and one which
+// stops watching. The fsnotify build tag sets it, so the inputs are
+// not polled.
+var notifyChanges func(dirs []string) (<-chan struct{}, func(dirs []string), func(), error)
+
+// WatchAndTranslate runs Translate and then keeps watching all input
+// paths, including those of the bundles, regenerating the output
+// whenever assets are added, removed or modified. Changes are
+// debounced: regeneration waits until the inputs have not changed for
+// one polling interval, so a burst of writes results in a single run.
+//
+// The inputs are polled every WatchInterval. A generator built with the
+// fsnotify build tag is notified of changes by the operating system
+// instead, using github.com/fsnotify/fsnotify.
+//
+// A failed regeneration is reported as an EventError, or logged
+// without an events callback, and watching goes on, so the inputs can
+// be fixed. Watching stops, returning nil, when stop is closed. Only
+// the error of the initial translation is returned.
+func WatchAndTranslate(c *Config, stop <-chan struct{}) error {
+	err := Translate(c)
+	if err != nil {
+		return err
+	}
+
+	last := snapshot(c)
+	dirty := false
+
+	changes, add, done, err := watchInputs(last)
+	if err != nil {
+		return err
+	}
+	defer done()
+
+	// Without polling, the inputs are checked once more after the last
+	// change, to see whether they settled.
+	settle := time.NewTimer(WatchInterval)
+	defer settle.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-changes:
+		case <-settle.C:
+		}
+
+		current := snapshot(c)
+		if !current.equal(last) {
+			add(current.dirs())
+			last = current
+			dirty = true
+			if !settle.Stop() {
+				select {
+				case <-settle.C:
+				default:
+				}
+			}
+			settle.Reset(WatchInterval)
+			continue
+		}
+
+		if dirty {
+			dirty = false
+			err = Translate(c)
+			if err != nil {
+				watchFailed(c, err)
+			}
+		}
+	}
+}
+
+// watchInputs returns a channel receiving a value whenever the files
+// of the given state may have changed. Without notifyChanges, this is
+// the case every WatchInterval.
+func watchInputs(state inputState) (<-chan struct{}, func(dirs []string), func(), error) {
+	if notifyChanges != nil {
+		return notifyChanges(state.dirs())
+	}
+
+	changes := make(chan struct{}, 1)
+	done := make(chan struct{})
+	ticker := time.NewTicker(WatchInterval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	return changes, func([]string) {}, func() { close(done) }, nil
+}
+
+// watchFailed reports a failed regeneration in watch mode as an
+// EventError, or in the standard logger without an events callback.
+func watchFailed(c *Config, err error) {
+	if c.Events == nil {
+		log.Printf("bindata: %v", err)
+		return
+	}
+	c.event(Event{Kind: EventError, Err: err})
+}
+
+// fileState holds the properties of a file which indicate a change.
+type fileState struct {
+	size    int64
+	mode    os.FileMode
+	modTime int64
+	dir     bool
+}
+
+// inputState maps the paths below the input paths to their state.
+type inputState map[string]fileState
+
+// snapshot records the state of all files below the input paths of the
+// configuration and its bundles. The generated files are left out, in
+// case they live next to the assets.
+func snapshot(c *Config) inputState {
+	state := make(inputState)
+	configs := c.configs()
+
+	for _, bc := range configs {
+		for _, input := range bc.Input {
+			root := input.Path
+			filter := newFilter(bc, &input)
+			filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+				if err != nil {
+					return nil
+				}
+
+				if fi.IsDir() && path != root && !input.Recursive {
+					return filepath.SkipDir
+				}
+
+				// Changes to ignored files, like those below .git,
+				// do not change the output.
+				if path != root && filter.ignored(path, fi.IsDir()) {
+					if fi.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+
+				for _, oc := range configs {
+					if oc.isOutput(path) {
+						return nil
+					}
+				}
+
+				state[path] = fileState{fi.Size(), fi.Mode(), fi.ModTime().UnixNano(), fi.IsDir()}
+				return nil
+			})
+		}
+	}
+
+	return state
+}
+
+func (s inputState) equal(other inputState) bool {
+	if len(s) != len(other) {
+		return false
+	}
+
+	for path, fs := range s {
+		if ofs, ok := other[path]; !ok || ofs != fs {
+			return false
+		}
+	}
+
+	return true
+}
+
+// dirs returns the directories of the state, along with those holding
+// its files, which are watched for changes with notifyChanges.
+func (s inputState) dirs() []string {
+	seen := make(map[string]bool)
+	for path, fs := range s {
+		if !fs.dir {
+			path = filepath.Dir(path)
+		}
+		seen[path] = true
+	}
+
+	dirs := make([]string, 0, len(seen))
+	for dir := range seen {
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
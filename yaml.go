@@ -0,0 +1,341 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+package bindata
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// yamlLine is a line of a YAML document, without its indentation.
+type yamlLine struct {
+	num    int
+	indent int
+	text   string
+}
+
+// yamlParser parses the subset of YAML used by configuration files:
+// block mappings and sequences, flow sequences of scalars, and plain,
+// single or double quoted scalars. Anchors, tags, block scalars and
+// multiple documents are not supported.
+type yamlParser struct {
+	lines []yamlLine
+	pos   int
+}
+
+// parseYAML decodes the given document into maps, slices and scalars.
+func parseYAML(data []byte) (interface{}, error) {
+	var p yamlParser
+	for i, text := range strings.Split(string(data), "\n") {
+		text = strings.TrimRight(stripComment(text), " \t\r")
+		trimmed := strings.TrimLeft(text, " ")
+		if len(trimmed) == 0 || trimmed == "---" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, fmt.Errorf("line %d: tabs cannot be used for indentation", i+1)
+		}
+		p.lines = append(p.lines, yamlLine{num: i + 1, indent: len(text) - len(trimmed), text: trimmed})
+	}
+
+	if len(p.lines) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	v, err := p.parseNode(p.lines[0].indent)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.lines) {
+		return nil, fmt.Errorf("line %d: unexpected indentation", p.lines[p.pos].num)
+	}
+	return v, nil
+}
+
+// parseNode parses the block starting at the current line,
+// which must be indented by the given amount.
+func (p *yamlParser) parseNode(indent int) (interface{}, error) {
+	line := p.lines[p.pos]
+	if isSequenceItem(line.text) {
+		return p.parseSequence(indent)
+	}
+	return p.parseMapping(indent)
+}
+
+// parseSequence parses the items of a block sequence.
+func (p *yamlParser) parseSequence(indent int) (interface{}, error) {
+	list := []interface{}{}
+
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		line := &p.lines[p.pos]
+		if !isSequenceItem(line.text) {
+			return nil, fmt.Errorf("line %d: expected a sequence item", line.num)
+		}
+
+		rest := strings.TrimLeft(line.text[1:], " ")
+		if len(rest) == 0 {
+			p.pos++
+			v, err := p.parseNested(indent)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+			continue
+		}
+
+		// Items may hold a block themselves, as in "- path: x".
+		// The line is then parsed again without the dash.
+		if isSequenceItem(rest) || mappingKey(rest) >= 0 {
+			line.indent += len(line.text) - len(rest)
+			line.text = rest
+			v, err := p.parseNode(line.indent)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+			continue
+		}
+
+		v, err := parseYAMLScalar(rest, line.num)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+		p.pos++
+	}
+
+	return list, nil
+}
+
+// parseMapping parses the entries of a block mapping.
+func (p *yamlParser) parseMapping(indent int) (interface{}, error) {
+	m := map[string]interface{}{}
+
+	for p.pos < len(p.lines) && p.lines[p.pos].indent == indent {
+		line := p.lines[p.pos]
+		n := mappingKey(line.text)
+		if n < 0 {
+			return nil, fmt.Errorf("line %d: expected a key", line.num)
+		}
+
+		key, err := unquoteYAML(strings.TrimSpace(line.text[:n]), line.num)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := m[key]; ok {
+			return nil, fmt.Errorf("line %d: duplicate key %q", line.num, key)
+		}
+
+		p.pos++
+		value := strings.TrimSpace(line.text[n+1:])
+		if len(value) > 0 {
+			m[key], err = parseYAMLScalar(value, line.num)
+		} else {
+			m[key], err = p.parseNested(indent)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// parseNested parses the block following a key or dash without a value.
+// It is indented further, except for sequences in mappings, which may
+// start at the same indentation as their key.
+func (p *yamlParser) parseNested(indent int) (interface{}, error) {
+	if p.pos == len(p.lines) {
+		return nil, nil
+	}
+
+	next := p.lines[p.pos]
+	if next.indent > indent || next.indent == indent && isSequenceItem(next.text) && !p.inSequence(indent) {
+		return p.parseNode(next.indent)
+	}
+	return nil, nil
+}
+
+// inSequence reports whether the line before the current
+// one is an item of a sequence with the given indentation.
+func (p *yamlParser) inSequence(indent int) bool {
+	prev := p.lines[p.pos-1]
+	return prev.indent == indent && isSequenceItem(prev.text)
+}
+
+// isSequenceItem reports whether the text starts a sequence item.
+func isSequenceItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+// mappingKey returns the offset of the colon ending the key of
+// a mapping entry in the text, or -1 if it holds no entry.
+func mappingKey(text string) int {
+	if len(text) == 0 || strings.IndexByte("[{", text[0]) >= 0 {
+		return -1
+	}
+
+	i := 0
+	if text[0] == '"' || text[0] == '\'' {
+		i = quotedEnd(text)
+		if i < 0 {
+			return -1
+		}
+	}
+
+	for ; i < len(text); i++ {
+		if text[i] == ':' && (i+1 == len(text) || text[i+1] == ' ') {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseYAMLScalar parses a scalar or a flow sequence of scalars.
+func parseYAMLScalar(text string, num int) (interface{}, error) {
+	if len(text) == 0 {
+		return nil, fmt.Errorf("line %d: missing value", num)
+	}
+
+	switch text[0] {
+	case '[':
+		if !strings.HasSuffix(text, "]") {
+			return nil, fmt.Errorf("line %d: unterminated flow sequence", num)
+		}
+		list := []interface{}{}
+		items, err := splitFlow(text[1:len(text)-1], num)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			v, err := parseYAMLScalar(item, num)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+
+	case '{', '&', '*', '!', '|', '>':
+		return nil, fmt.Errorf("line %d: unsupported value %q", num, text)
+
+	case '"', '\'':
+		return unquoteYAML(text, num)
+	}
+
+	switch text {
+	case "~", "null", "Null", "NULL":
+		return nil, nil
+	case "true", "True", "TRUE":
+		return true, nil
+	case "false", "False", "FALSE":
+		return false, nil
+	}
+
+	if n, err := strconv.ParseInt(text, 0, 64); err == nil {
+		return n, nil
+	}
+
+	return text, nil
+}
+
+// unquoteYAML returns the value of a possibly quoted scalar.
+func unquoteYAML(text string, num int) (string, error) {
+	if len(text) == 0 || text[0] != '"' && text[0] != '\'' {
+		return text, nil
+	}
+
+	if quotedEnd(text) != len(text) {
+		return "", fmt.Errorf("line %d: invalid quoted string %s", num, text)
+	}
+
+	if text[0] == '\'' {
+		return strings.Replace(text[1:len(text)-1], "''", "'", -1), nil
+	}
+
+	s, err := strconv.Unquote(text)
+	if err != nil {
+		return "", fmt.Errorf("line %d: invalid quoted string %s", num, text)
+	}
+	return s, nil
+}
+
+// splitFlow splits the items of a flow sequence at commas
+// outside of quoted strings.
+func splitFlow(text string, num int) ([]string, error) {
+	var items []string
+	for len(strings.TrimSpace(text)) > 0 {
+		text = strings.TrimSpace(text)
+
+		end := strings.IndexByte(text, ',')
+		if text[0] == '"' || text[0] == '\'' {
+			end = quotedEnd(text)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted string", num)
+			}
+			if rest := strings.TrimSpace(text[end:]); len(rest) > 0 && rest[0] != ',' {
+				return nil, fmt.Errorf("line %d: expected a comma after %s", num, text[:end])
+			}
+			end += strings.IndexByte(text[end:]+",", ',')
+		}
+		if end < 0 {
+			end = len(text)
+		}
+
+		item := strings.TrimSpace(text[:end])
+		if len(item) == 0 {
+			return nil, fmt.Errorf("line %d: empty item in flow sequence", num)
+		}
+		items = append(items, item)
+		if end == len(text) {
+			break
+		}
+		text = text[end+1:]
+	}
+	return items, nil
+}
+
+// quotedEnd returns the offset following the single or double quoted
+// string at the start of text, or -1 if it is not terminated. Single
+// quotes are escaped by doubling them, double quotes by a backslash.
+func quotedEnd(text string) int {
+	quote := text[0]
+	for i := 1; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && quote == '"':
+			i++
+		case text[i] == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// stripComment removes a comment starting with a # outside of quoted
+// strings. It must follow whitespace, unless it starts the line. Quotes
+// only start a string at the start of a value.
+func stripComment(line string) string {
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '"', '\'':
+			if i > 0 && strings.IndexByte(" \t[{,:=-", line[i-1]) < 0 {
+				continue
+			}
+			end := quotedEnd(line[i:])
+			if end < 0 {
+				return line
+			}
+			i += end - 1
+		case '#':
+			if i == 0 || line[i-1] == ' ' || line[i-1] == '\t' {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
@@ -0,0 +1,34 @@
+// This work is subject to the CC0 1.0 Universal (CC0 1.0) Public Domain Dedication
+// license. Its contents can be found at:
+// http://creativecommons.org/publicdomain/zero/1.0/
+
+//go:build zstd
+// +build zstd
+
+package bindata
+
+import (
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func init() {
+	encoders[CompressZstd] = func(w io.Writer, c *Config) (io.WriteCloser, error) {
+		if c.CompressionLevel == 0 {
+			return zstd.NewWriter(w)
+		}
+		level := zstd.EncoderLevelFromZstd(c.CompressionLevel)
+		return zstd.NewWriter(w, zstd.WithEncoderLevel(level))
+	}
+
+	decoders[CompressZstd] = func(data []byte) ([]byte, error) {
+		dec, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		defer dec.Close()
+		return dec.DecodeAll(data, nil)
+	}
+}